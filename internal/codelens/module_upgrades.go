@@ -0,0 +1,132 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package codelens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/registry"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var moduleBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var moduleCallSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "source"},
+		{Name: "version"},
+	},
+}
+
+// ModuleVersionsReader provides versions of registry modules
+// as published in the registry
+type ModuleVersionsReader interface {
+	RegistryModuleVersions(addr tfaddr.Module) (version.Collection, error)
+}
+
+// ModuleUpgrades provides lenses on calls of registry modules
+// pointing out newer versions than the version constraint allows.
+// Newer major versions are pointed out separately, as these
+// are expected to contain breaking changes.
+func ModuleUpgrades(versionsReader ModuleVersionsReader) lang.CodeLensFunc {
+	return func(ctx context.Context, path lang.Path, file string) ([]lang.CodeLens, error) {
+		lenses := make([]lang.CodeLens, 0)
+		if path.LanguageID != "terraform" {
+			return lenses, nil
+		}
+
+		localCtx, err := decoder.PathCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		f, ok := localCtx.Files[file]
+		if !ok {
+			return lenses, nil
+		}
+
+		content, _, _ := f.Body.PartialContent(moduleBlockSchema)
+		for _, block := range content.Blocks {
+			addr, cons, ok := registryModuleCall(block)
+			if !ok {
+				continue
+			}
+
+			versions, err := versionsReader.RegistryModuleVersions(addr)
+			if err != nil {
+				continue
+			}
+			upgrades, ok := registry.FindModuleUpgrades(versions, cons)
+			if !ok {
+				continue
+			}
+
+			if upgrades.Compatible != nil {
+				lenses = append(lenses, lang.CodeLens{
+					Range: block.DefRange,
+					Command: lang.Command{
+						Title: fmt.Sprintf("newer version available: %s", upgrades.Compatible),
+					},
+				})
+			}
+			if upgrades.Major != nil {
+				lenses = append(lenses, lang.CodeLens{
+					Range: block.DefRange,
+					Command: lang.Command{
+						Title: fmt.Sprintf("newer major version available: %s", upgrades.Major),
+					},
+				})
+			}
+		}
+
+		return lenses, nil
+	}
+}
+
+// registryModuleCall returns the source address and version
+// constraints of a module call, if it calls a registry module
+// and declares the version
+func registryModuleCall(block *hcl.Block) (tfaddr.Module, version.Constraints, bool) {
+	content, _, _ := block.Body.PartialContent(moduleCallSchema)
+
+	source, ok := stringValue(content.Attributes["source"])
+	if !ok {
+		return tfaddr.Module{}, nil, false
+	}
+	addr, err := tfaddr.ParseModuleSource(source)
+	if err != nil {
+		return tfaddr.Module{}, nil, false
+	}
+
+	rawVersion, ok := stringValue(content.Attributes["version"])
+	if !ok {
+		return tfaddr.Module{}, nil, false
+	}
+	cons, err := version.NewConstraint(rawVersion)
+	if err != nil {
+		return tfaddr.Module{}, nil, false
+	}
+
+	return addr, cons, true
+}
+
+func stringValue(attr *hcl.Attribute) (string, bool) {
+	if attr == nil {
+		return "", false
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+		return "", false
+	}
+	return val.AsString(), true
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package codelens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plan"
+)
+
+// PlanReader provides changes of a saved plan of a root module
+type PlanReader interface {
+	Plan(modPath string) (*plan.Plan, bool)
+}
+
+// PlanChanges provides lenses summarizing planned changes
+// of each instance of resources and data sources, once
+// the root module is annotated with a saved plan
+func PlanChanges(planReader PlanReader) lang.CodeLensFunc {
+	return func(ctx context.Context, path lang.Path, file string) ([]lang.CodeLens, error) {
+		lenses := make([]lang.CodeLens, 0)
+		if path.LanguageID != "terraform" {
+			return lenses, nil
+		}
+
+		p, ok := planReader.Plan(path.Path)
+		if !ok {
+			return lenses, nil
+		}
+
+		localCtx, err := decoder.PathCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		f, ok := localCtx.Files[file]
+		if !ok {
+			return lenses, nil
+		}
+
+		content, _, _ := f.Body.PartialContent(resourceBlockSchema)
+		for _, block := range content.Blocks {
+			address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+			if block.Type == "data" {
+				address = "data." + address
+			}
+
+			for _, change := range p.Changes[address] {
+				lenses = append(lenses, lang.CodeLens{
+					Range: block.DefRange,
+					Command: lang.Command{
+						Title: change.Summary(),
+					},
+				})
+			}
+		}
+
+		return lenses, nil
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package codelens
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/features/policytest/ast"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var testCaseBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"resource_type", "test_case_name"}},
+		{Type: "provider", LabelNames: []string{"provider_type", "test_case_name"}},
+		{Type: "module", LabelNames: []string{"module_source", "test_case_name"}},
+	},
+}
+
+// PolicyTestRun provides lenses to run a whole policy test file
+// as well as individual test cases within it
+func PolicyTestRun() lang.CodeLensFunc {
+	return func(ctx context.Context, path lang.Path, file string) ([]lang.CodeLens, error) {
+		lenses := make([]lang.CodeLens, 0)
+		if path.LanguageID != "terraform-policytest" || !ast.IsPolicyTestFilename(file) {
+			return lenses, nil
+		}
+
+		localCtx, err := decoder.PathCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		f, ok := localCtx.Files[file]
+		if !ok {
+			return lenses, nil
+		}
+
+		cmdId := cmd.Name("policytest.run")
+		if prefix, ok := lsctx.CommandPrefix(ctx); ok && prefix != "" {
+			cmdId = prefix + "." + cmdId
+		}
+		fileUri := uri.FromPath(filepath.Join(path.Path, file))
+
+		lenses = append(lenses, lang.CodeLens{
+			Range: hcl.Range{
+				Filename: file,
+				Start:    hcl.InitialPos,
+				End:      hcl.InitialPos,
+			},
+			Command: lang.Command{
+				Title: "Run file",
+				ID:    cmdId,
+				Arguments: []lang.CommandArgument{
+					KeyValue{Key: "uri", Value: fileUri},
+				},
+			},
+		})
+
+		content, _, _ := f.Body.PartialContent(testCaseBlockSchema)
+		for _, block := range content.Blocks {
+			if isSkipped(block) {
+				continue
+			}
+			caseName := strings.Join(append([]string{block.Type}, block.Labels...), ".")
+			lenses = append(lenses, lang.CodeLens{
+				Range: block.DefRange,
+				Command: lang.Command{
+					Title: "Run test case",
+					ID:    cmdId,
+					Arguments: []lang.CommandArgument{
+						KeyValue{Key: "uri", Value: fileUri},
+						KeyValue{Key: "case", Value: caseName},
+					},
+				},
+			})
+		}
+
+		return lenses, nil
+	}
+}
+
+// isSkipped reports whether the test case sets skip = true,
+// in which case it is not run on its own
+func isSkipped(block *hcl.Block) bool {
+	attrs, _ := block.Body.JustAttributes()
+	attr, ok := attrs["skip"]
+	if !ok {
+		return false
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.Bool {
+		return false
+	}
+	return val.True()
+}
@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package codelens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+)
+
+var resourceBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+	},
+}
+
+// StateReader provides the state of a root module
+type StateReader interface {
+	State(modPath string) (*datadir.State, bool)
+}
+
+// StateInstances provides lenses with the number of instances
+// of each resource and data source recorded in the state
+// of the root module
+func StateInstances(stateReader StateReader) lang.CodeLensFunc {
+	return func(ctx context.Context, path lang.Path, file string) ([]lang.CodeLens, error) {
+		lenses := make([]lang.CodeLens, 0)
+		if path.LanguageID != "terraform" {
+			return lenses, nil
+		}
+
+		st, ok := stateReader.State(path.Path)
+		if !ok {
+			return lenses, nil
+		}
+
+		localCtx, err := decoder.PathCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		f, ok := localCtx.Files[file]
+		if !ok {
+			return lenses, nil
+		}
+
+		content, _, _ := f.Body.PartialContent(resourceBlockSchema)
+		for _, block := range content.Blocks {
+			address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+			if block.Type == "data" {
+				address = "data." + address
+			}
+
+			count := 0
+			if r, ok := st.Resources[address]; ok {
+				count = len(r.Instances)
+			}
+
+			lenses = append(lenses, lang.CodeLens{
+				Range: block.DefRange,
+				Command: lang.Command{
+					Title: getTitle("instance in state", "instances in state", count),
+				},
+			})
+		}
+
+		return lenses, nil
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package codelens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+var runBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "run", LabelNames: []string{"name"}},
+	},
+}
+
+// TestRun provides lenses to run a whole test file. Lenses of
+// individual run blocks also run the whole file, as Terraform
+// can only filter tests by file, but only show the block's result.
+func TestRun() lang.CodeLensFunc {
+	return func(ctx context.Context, path lang.Path, file string) ([]lang.CodeLens, error) {
+		lenses := make([]lang.CodeLens, 0)
+		if path.LanguageID != "terraform-test" || !ast.IsTestFilename(file) {
+			return lenses, nil
+		}
+
+		localCtx, err := decoder.PathCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		f, ok := localCtx.Files[file]
+		if !ok {
+			return lenses, nil
+		}
+
+		cmdId := cmd.Name("test.run")
+		if prefix, ok := lsctx.CommandPrefix(ctx); ok && prefix != "" {
+			cmdId = prefix + "." + cmdId
+		}
+		fileUri := uri.FromPath(filepath.Join(path.Path, file))
+
+		lenses = append(lenses, lang.CodeLens{
+			Range: hcl.Range{
+				Filename: file,
+				Start:    hcl.InitialPos,
+				End:      hcl.InitialPos,
+			},
+			Command: lang.Command{
+				Title: "Run file",
+				ID:    cmdId,
+				Arguments: []lang.CommandArgument{
+					KeyValue{Key: "uri", Value: fileUri},
+				},
+			},
+		})
+
+		content, _, _ := f.Body.PartialContent(runBlockSchema)
+		for _, block := range content.Blocks {
+			lenses = append(lenses, lang.CodeLens{
+				Range: block.DefRange,
+				Command: lang.Command{
+					Title: fmt.Sprintf("Run file (show %s result)", block.Labels[0]),
+					ID:    cmdId,
+					Arguments: []lang.CommandArgument{
+						KeyValue{Key: "uri", Value: fileUri},
+						KeyValue{Key: "run", Value: block.Labels[0]},
+					},
+				},
+			})
+		}
+
+		return lenses, nil
+	}
+}
+
+// KeyValue represents a command argument
+// in the key=value format expected by commands
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+func (kv KeyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(kv.Key + "=" + kv.Value)
+}
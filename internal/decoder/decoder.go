@@ -18,6 +18,8 @@ func DecoderContext(ctx context.Context) decoder.DecoderContext {
 	dCtx.UtmSource = utm.UtmSource
 	dCtx.UtmMedium = utm.UtmMedium(ctx)
 	dCtx.UseUtmContent = true
+	dCtx.CodeLenses = append(dCtx.CodeLenses, codelens.TestRun())
+	dCtx.CodeLenses = append(dCtx.CodeLenses, codelens.PolicyTestRun())
 
 	cc, err := ilsp.ClientCapabilities(ctx)
 	if err == nil {
@@ -30,6 +30,7 @@ type EventBus struct {
 
 	manifestChangeTopic   *Topic[ManifestChangeEvent]
 	pluginLockChangeTopic *Topic[PluginLockChangeEvent]
+	stateChangeTopic      *Topic[StateChangeEvent]
 }
 
 func NewEventBus() *EventBus {
@@ -41,6 +42,7 @@ func NewEventBus() *EventBus {
 		discoverTopic:         NewTopic[DiscoverEvent](),
 		manifestChangeTopic:   NewTopic[ManifestChangeEvent](),
 		pluginLockChangeTopic: NewTopic[PluginLockChangeEvent](),
+		stateChangeTopic:      NewTopic[StateChangeEvent](),
 	}
 }
 
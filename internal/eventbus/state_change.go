@@ -0,0 +1,30 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package eventbus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+// StateChangeEvent is an event that should be fired whenever the state
+// file (terraform.tfstate) or its JSON snapshot changes.
+type StateChangeEvent struct {
+	Context context.Context
+
+	Dir        document.DirHandle
+	ChangeType protocol.FileChangeType
+}
+
+func (n *EventBus) OnStateChange(identifier string, doneChannel DoneChannel) <-chan StateChangeEvent {
+	n.logger.Printf("bus: %q subscribed to OnStateChange", identifier)
+	return n.stateChangeTopic.Subscribe(doneChannel)
+}
+
+func (n *EventBus) StateChange(e StateChangeEvent) {
+	n.logger.Printf("bus: -> StateChange %s", e.Dir)
+	n.stateChangeTopic.Publish(e)
+}
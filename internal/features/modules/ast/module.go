@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 )
 
 type ModFilename string
@@ -26,7 +27,31 @@ func (mf ModFilename) IsIgnored() bool {
 
 func IsModuleFilename(name string) bool {
 	return strings.HasSuffix(name, ".tf") ||
-		strings.HasSuffix(name, ".tf.json")
+		strings.HasSuffix(name, ".tf.json") ||
+		strings.HasSuffix(name, ".tofu") ||
+		strings.HasSuffix(name, ".tofu.json")
+}
+
+// IsEngineModuleFilename returns true for module files which are
+// loaded by the given engine, i.e. *.tofu files are ignored by Terraform
+func IsEngineModuleFilename(name string, e engine.Engine) bool {
+	if engine.IsOpenTofuFilename(name) {
+		return e != engine.Terraform
+	}
+	return IsModuleFilename(name)
+}
+
+// OpenTofuOverrideFilename returns the name of the OpenTofu-specific
+// file which takes precedence over the given Terraform file,
+// e.g. main.tofu for main.tf, or false if there is no such file.
+func OpenTofuOverrideFilename(name string) (string, bool) {
+	if strings.HasSuffix(name, ".tf") {
+		return strings.TrimSuffix(name, ".tf") + ".tofu", true
+	}
+	if strings.HasSuffix(name, ".tf.json") {
+		return strings.TrimSuffix(name, ".tf.json") + ".tofu.json", true
+	}
+	return "", false
 }
 
 type ModFiles map[ModFilename]*hcl.File
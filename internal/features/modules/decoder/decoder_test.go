@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/features/modules/jobs"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
 )
 
@@ -41,6 +42,10 @@ func (r RootReaderMock) InstalledModulePath(rootPath string, normalizedSource st
 	return "", false
 }
 
+func (r RootReaderMock) InstalledProviders(modPath string) (map[tfaddr.Provider]*version.Version, error) {
+	return nil, nil
+}
+
 func TestDecoder_CodeLensesForFile_concurrencyBug(t *testing.T) {
 	globalStore, err := globalState.NewStateStore()
 	if err != nil {
@@ -7,13 +7,19 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	tfmodule "github.com/hashicorp/terraform-schema/module"
 	tfschema "github.com/hashicorp/terraform-schema/schema"
 )
 
 func schemaForModule(mod *state.ModuleRecord, stateReader CombinedReader) (*schema.BodySchema, error) {
 	resolvedVersion := tfschema.ResolveVersion(stateReader.TerraformVersion(mod.Path()), mod.Meta.CoreRequirements)
-	sm := tfschema.NewSchemaMerger(mustCoreSchemaForVersion(resolvedVersion))
+	coreSchema := mustCoreSchemaForVersion(resolvedVersion)
+	if stateReader.ModuleEngine(mod.Path()) == engine.OpenTofu {
+		coreSchema = patchSchemaForOpenTofu(coreSchema)
+	}
+
+	sm := tfschema.NewSchemaMerger(coreSchema)
 	sm.SetTerraformVersion(resolvedVersion)
 	sm.SetStateReader(stateReader)
 
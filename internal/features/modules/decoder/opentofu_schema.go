@@ -0,0 +1,174 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	keyProviderScope = lang.ScopeId("key_provider")
+	encMethodScope   = lang.ScopeId("encryption_method")
+)
+
+// patchSchemaForOpenTofu adds constructs which are only recognised
+// by OpenTofu to a copy of the given core schema, i.e. the
+// encryption block within terraform block and provider for_each.
+func patchSchemaForOpenTofu(bs *schema.BodySchema) *schema.BodySchema {
+	bs = bs.Copy()
+
+	if tfBlock, ok := bs.Blocks["terraform"]; ok && tfBlock.Body != nil {
+		if tfBlock.Body.Blocks == nil {
+			tfBlock.Body.Blocks = make(map[string]*schema.BlockSchema)
+		}
+		tfBlock.Body.Blocks["encryption"] = encryptionBlockSchema()
+	}
+
+	if providerBlock, ok := bs.Blocks["provider"]; ok && providerBlock.Body != nil {
+		if providerBlock.Body.Attributes == nil {
+			providerBlock.Body.Attributes = make(map[string]*schema.AttributeSchema)
+		}
+		providerBlock.Body.Attributes["for_each"] = &schema.AttributeSchema{
+			Constraint: schema.OneOf{
+				schema.AnyExpression{OfType: cty.Map(cty.DynamicPseudoType)},
+				schema.AnyExpression{OfType: cty.Set(cty.String)},
+			},
+			IsOptional: true,
+			Description: lang.Markdown("A meta-argument that accepts a map or a set of strings, and creates " +
+				"an instance of the provider configuration for each item in that map or set. " +
+				"Requires `alias` to be set."),
+		}
+		// The extension makes each.key and each.value available
+		// within the block, as with resources
+		if providerBlock.Body.Extensions == nil {
+			providerBlock.Body.Extensions = &schema.BodyExtensions{}
+		}
+		providerBlock.Body.Extensions.ForEach = true
+	}
+
+	return bs
+}
+
+func encryptionBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Description: lang.Markdown("State and plan encryption configuration"),
+		MaxItems:    1,
+		Body: &schema.BodySchema{
+			HoverURL: "https://opentofu.org/docs/language/state/encryption/",
+			Blocks: map[string]*schema.BlockSchema{
+				"key_provider": {
+					Description: lang.Markdown("Key provider generating or retrieving the encryption key, e.g. `pbkdf2` or `aws_kms`"),
+					Address: &schema.BlockAddrSchema{
+						Steps: []schema.AddrStep{
+							schema.StaticStep{Name: "key_provider"},
+							schema.LabelStep{Index: 0},
+							schema.LabelStep{Index: 1},
+						},
+						FriendlyName: "key_provider",
+						ScopeId:      keyProviderScope,
+						AsReference:  true,
+					},
+					Labels: []*schema.LabelSchema{
+						{Name: "type", Description: lang.PlainText("Key Provider Type"), Completable: true},
+						{Name: "name", Description: lang.PlainText("Key Provider Name")},
+					},
+					Body: &schema.BodySchema{
+						AnyAttribute: &schema.AttributeSchema{
+							Constraint: schema.AnyExpression{OfType: cty.DynamicPseudoType},
+							IsOptional: true,
+						},
+					},
+				},
+				"method": {
+					Description: lang.Markdown("Encryption method, e.g. `aes_gcm`"),
+					Address: &schema.BlockAddrSchema{
+						Steps: []schema.AddrStep{
+							schema.StaticStep{Name: "method"},
+							schema.LabelStep{Index: 0},
+							schema.LabelStep{Index: 1},
+						},
+						FriendlyName: "method",
+						ScopeId:      encMethodScope,
+						AsReference:  true,
+					},
+					Labels: []*schema.LabelSchema{
+						{Name: "type", Description: lang.PlainText("Method Type"), Completable: true},
+						{Name: "name", Description: lang.PlainText("Method Name")},
+					},
+					Body: &schema.BodySchema{
+						Attributes: map[string]*schema.AttributeSchema{
+							"keys": {
+								Constraint:  schema.Reference{OfScopeId: keyProviderScope},
+								IsOptional:  true,
+								Description: lang.Markdown("Key provider supplying the encryption key"),
+							},
+						},
+					},
+				},
+				"state": {
+					Description: lang.Markdown("Encryption of the state file"),
+					MaxItems:    1,
+					Body:        encryptionTargetBodySchema(),
+				},
+				"plan": {
+					Description: lang.Markdown("Encryption of the plan file"),
+					MaxItems:    1,
+					Body:        encryptionTargetBodySchema(),
+				},
+				"remote_state_data_sources": {
+					Description: lang.Markdown("Encryption of states read by `terraform_remote_state` data sources"),
+					MaxItems:    1,
+					Body: &schema.BodySchema{
+						Blocks: map[string]*schema.BlockSchema{
+							"default": {
+								Description: lang.Markdown("Encryption used for all remote state data sources by default"),
+								MaxItems:    1,
+								Body:        encryptionTargetBodySchema(),
+							},
+							"remote_state_data_source": {
+								Description: lang.Markdown("Encryption used for a particular remote state data source"),
+								Labels: []*schema.LabelSchema{
+									{Name: "name", Description: lang.PlainText("Data Source Address")},
+								},
+								Body: encryptionTargetBodySchema(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func encryptionTargetBodySchema() *schema.BodySchema {
+	methodAttr := &schema.AttributeSchema{
+		Constraint:  schema.Reference{OfScopeId: encMethodScope},
+		IsOptional:  true,
+		Description: lang.Markdown("Method used for encryption"),
+	}
+
+	return &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"method": methodAttr,
+			"enforced": {
+				Constraint:  schema.LiteralType{Type: cty.Bool},
+				IsOptional:  true,
+				Description: lang.Markdown("Whether to refuse writing unencrypted data"),
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"fallback": {
+				Description: lang.Markdown("Method used to decrypt data during migration from a previous method"),
+				MaxItems:    1,
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"method": methodAttr,
+					},
+				},
+			},
+		},
+	}
+}
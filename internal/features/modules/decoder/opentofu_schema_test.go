@@ -0,0 +1,37 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestPatchSchemaForOpenTofu(t *testing.T) {
+	coreSchema := mustCoreSchemaForVersion(version.Must(version.NewVersion("1.8.0")))
+
+	patched := patchSchemaForOpenTofu(coreSchema)
+
+	if _, ok := patched.Blocks["terraform"].Body.Blocks["encryption"]; !ok {
+		t.Fatal("expected encryption block in patched terraform block")
+	}
+	if _, ok := patched.Blocks["provider"].Body.Attributes["for_each"]; !ok {
+		t.Fatal("expected for_each attribute in patched provider block")
+	}
+	if ext := patched.Blocks["provider"].Body.Extensions; ext == nil || !ext.ForEach {
+		t.Fatal("expected for_each extension in patched provider block")
+	}
+
+	// the original schema must remain untouched
+	if _, ok := coreSchema.Blocks["terraform"].Body.Blocks["encryption"]; ok {
+		t.Fatal("unexpected encryption block in original schema")
+	}
+	if _, ok := coreSchema.Blocks["provider"].Body.Attributes["for_each"]; ok {
+		t.Fatal("unexpected for_each attribute in original schema")
+	}
+	if ext := coreSchema.Blocks["provider"].Body.Extensions; ext != nil && ext.ForEach {
+		t.Fatal("unexpected for_each extension in original schema")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
 	"github.com/hashicorp/terraform-schema/registry"
@@ -21,6 +22,7 @@ type StateReader interface {
 	DeclaredModuleCalls(modPath string) (map[string]tfmod.DeclaredModuleCall, error)
 	LocalModuleMeta(modPath string) (*tfmod.Meta, error)
 	ModuleRecordByPath(modPath string) (*state.ModuleRecord, error)
+	ModuleEngine(modPath string) engine.Engine
 	List() ([]*state.ModuleRecord, error)
 
 	RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*registry.ModuleData, error)
@@ -31,6 +33,7 @@ type RootReader interface {
 	InstalledModuleCalls(modPath string) (map[string]tfmod.InstalledModuleCall, error)
 	TerraformVersion(modPath string) *version.Version
 	InstalledModulePath(rootPath string, normalizedSource string) (string, bool)
+	InstalledProviders(modPath string) (map[tfaddr.Provider]*version.Version, error)
 }
 
 type CombinedReader struct {
@@ -4,14 +4,38 @@
 package decoder
 
 import (
+	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/decoder/validations"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
 	tfschema "github.com/hashicorp/terraform-schema/schema"
 )
 
+var providerScope = lang.ScopeId("provider")
+
 func referencesForModule(mod *state.ModuleRecord, stateReader CombinedReader) reference.Targets {
 	modPath := mod.Path()
 	resolvedVersion := tfschema.ResolveVersion(stateReader.TerraformVersion(modPath), mod.Meta.CoreRequirements)
 
-	return tfschema.BuiltinReferencesForVersion(resolvedVersion, modPath)
+	targets := tfschema.BuiltinReferencesForVersion(resolvedVersion, modPath)
+	return append(targets, configurationAliasTargets(mod)...)
+}
+
+// configurationAliasTargets returns targets for aliased provider
+// configurations which are passed to the module by the caller
+// (configuration_aliases) and therefore have no provider block
+func configurationAliasTargets(mod *state.ModuleRecord) reference.Targets {
+	targets := make(reference.Targets, 0)
+	for _, ref := range validations.ConfigurationAliases(mod.ParsedModuleFiles.AsMap()) {
+		targets = append(targets, reference.Target{
+			Addr: lang.Address{
+				lang.RootStep{Name: ref.LocalName},
+				lang.AttrStep{Name: ref.Alias},
+			},
+			ScopeId:     providerScope,
+			Name:        "provider",
+			Description: lang.PlainText("Provider configuration passed by the calling module"),
+		})
+	}
+	return targets
 }
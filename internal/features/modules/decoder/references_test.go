@@ -0,0 +1,54 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/ast"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+)
+
+func TestConfigurationAliasTargets(t *testing.T) {
+	cfg := `terraform {
+  required_providers {
+    aws = {
+      source                = "hashicorp/aws"
+      configuration_aliases = [aws.dst]
+    }
+  }
+}
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	mod := &state.ModuleRecord{
+		ParsedModuleFiles: ast.ModFiles{
+			"main.tf": f,
+		},
+	}
+
+	expectedTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws"},
+				lang.AttrStep{Name: "dst"},
+			},
+			ScopeId:     lang.ScopeId("provider"),
+			Name:        "provider",
+			Description: lang.PlainText("Provider configuration passed by the calling module"),
+		},
+	}
+	targets := configurationAliasTargets(mod)
+	if diff := cmp.Diff(expectedTargets, targets, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+var providerFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+		{Type: "ephemeral", LabelNames: []string{"type", "name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+		{Type: "terraform"},
+	},
+}
+
+var providerBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "provider"},
+		{Name: "providers"},
+	},
+}
+
+var terraformBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "required_providers"},
+	},
+}
+
+// ModuleProviders describes provider configurations
+// of a module called from the validated module
+type ModuleProviders struct {
+	// References are all provider configurations the module
+	// refers to, as found in its metadata
+	References map[tfmod.ProviderRef]tfaddr.Provider
+	// ConfigurationAliases are aliased provider configurations
+	// which the module expects to be passed by the caller
+	ConfigurationAliases []tfmod.ProviderRef
+}
+
+// ProviderConfigurations checks provider configurations referenced
+// from resources and module calls against configurations declared
+// in the module (refs), i.e. that
+//
+//   - provider arguments of resources, data sources and ephemeral
+//     resources refer to an aliased configuration which is declared
+//   - providers passed to a module call are declared in this module
+//     and in the called module
+//   - all configuration_aliases of the called module are passed
+//
+// Module calls can only be checked if the called module (keyed
+// by name of the module call) is available in moduleProviders.
+func ProviderConfigurations(ctx context.Context, pathCtx *decoder.PathContext, refs map[tfmod.ProviderRef]tfaddr.Provider, moduleProviders map[string]ModuleProviders) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	filenames := make([]string, 0, len(pathCtx.Files))
+	for filename := range pathCtx.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		content, _, _ := pathCtx.Files[filename].Body.PartialContent(providerFileSchema)
+		for _, block := range content.Blocks {
+			if block.Type == "terraform" {
+				continue
+			}
+			blockContent, _, _ := block.Body.PartialContent(providerBlockSchema)
+
+			var diags hcl.Diagnostics
+			switch block.Type {
+			case "resource", "data", "ephemeral":
+				if attr, ok := blockContent.Attributes["provider"]; ok {
+					diags = providerRefDiags(attr.Expr, refs)
+				}
+			case "module":
+				mp, ok := moduleProviders[block.Labels[0]]
+				if !ok {
+					continue
+				}
+				diags = moduleProvidersDiags(block, blockContent.Attributes["providers"], refs, mp)
+			}
+
+			diagsMap[filename] = diagsMap[filename].Extend(diags)
+		}
+	}
+
+	return diagsMap
+}
+
+func providerRefDiags(expr hcl.Expression, refs map[tfmod.ProviderRef]tfaddr.Provider) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	ref, ok := parseProviderRef(expr)
+	if !ok || ref.Alias == "" {
+		// default configurations don't need to be declared explicitly
+		return diags
+	}
+	if _, ok := refs[ref]; ok {
+		return diags
+	}
+
+	return append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Reference to undefined provider configuration",
+		Detail: fmt.Sprintf("There is no provider configuration %s declared in this module. "+
+			"Add a provider block with alias = %q, or add it to configuration_aliases in required_providers.",
+			providerRefString(ref), ref.Alias),
+		Subject: expr.Range().Ptr(),
+	})
+}
+
+func moduleProvidersDiags(block *hcl.Block, attr *hcl.Attribute, refs map[tfmod.ProviderRef]tfaddr.Provider, mp ModuleProviders) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	passed := make(map[tfmod.ProviderRef]bool)
+	if attr != nil {
+		pairs, _ := hcl.ExprMap(attr.Expr)
+		for _, pair := range pairs {
+			childRef, ok := parseProviderRef(pair.Key)
+			if !ok {
+				continue
+			}
+			passed[childRef] = true
+
+			if _, ok := mp.References[childRef]; !ok {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "Provider configuration not declared by module",
+					Detail: fmt.Sprintf("Module %q does not declare provider configuration %s, so passing it has no effect. "+
+						"Declare it in required_providers of the module, using configuration_aliases for aliased configurations.",
+						block.Labels[0], providerRefString(childRef)),
+					Subject: pair.Key.Range().Ptr(),
+				})
+			}
+
+			diags = append(diags, providerRefDiags(pair.Value, refs)...)
+		}
+	}
+
+	for _, alias := range mp.ConfigurationAliases {
+		if passed[alias] {
+			continue
+		}
+		subject := block.DefRange
+		if attr != nil {
+			subject = attr.NameRange
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing required provider configuration",
+			Detail: fmt.Sprintf("Module %q requires provider configuration %s to be passed via providers, "+
+				"because it is listed in configuration_aliases of the module.",
+				block.Labels[0], providerRefString(alias)),
+			Subject: subject.Ptr(),
+		})
+	}
+
+	return diags
+}
+
+// ConfigurationAliases returns aliased provider configurations
+// declared via configuration_aliases in required_providers
+// of the given module files
+func ConfigurationAliases(files map[string]*hcl.File) []tfmod.ProviderRef {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	aliases := make([]tfmod.ProviderRef, 0)
+	for _, filename := range filenames {
+		content, _, _ := files[filename].Body.PartialContent(providerFileSchema)
+		for _, block := range content.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			tfContent, _, _ := block.Body.PartialContent(terraformBlockSchema)
+			for _, rpBlock := range tfContent.Blocks {
+				attrs, _ := rpBlock.Body.JustAttributes()
+				names := make([]string, 0, len(attrs))
+				for name := range attrs {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				for _, name := range names {
+					aliases = append(aliases, requirementAliases(attrs[name].Expr)...)
+				}
+			}
+		}
+	}
+	return aliases
+}
+
+func requirementAliases(expr hcl.Expression) []tfmod.ProviderRef {
+	aliases := make([]tfmod.ProviderRef, 0)
+
+	pairs, diags := hcl.ExprMap(expr)
+	if diags.HasErrors() {
+		return aliases
+	}
+	for _, pair := range pairs {
+		if name, _ := ihcl.ObjectKeyName(pair.Key); name != "configuration_aliases" {
+			continue
+		}
+
+		exprs, diags := hcl.ExprList(pair.Value)
+		if diags.HasErrors() {
+			continue
+		}
+		for _, aliasExpr := range exprs {
+			ref, ok := parseProviderRef(aliasExpr)
+			if ok && ref.Alias != "" {
+				aliases = append(aliases, ref)
+			}
+		}
+	}
+	return aliases
+}
+
+// parseProviderRef parses a provider reference, such as aws or aws.west
+func parseProviderRef(expr hcl.Expression) (tfmod.ProviderRef, bool) {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() {
+		return tfmod.ProviderRef{}, false
+	}
+
+	switch len(traversal) {
+	case 1:
+		return tfmod.ProviderRef{
+			LocalName: traversal.RootName(),
+		}, true
+	case 2:
+		attr, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return tfmod.ProviderRef{}, false
+		}
+		return tfmod.ProviderRef{
+			LocalName: traversal.RootName(),
+			Alias:     attr.Name,
+		}, true
+	}
+	return tfmod.ProviderRef{}, false
+}
+
+func providerRefString(ref tfmod.ProviderRef) string {
+	if ref.Alias == "" {
+		return ref.LocalName
+	}
+	return fmt.Sprintf("%s.%s", ref.LocalName, ref.Alias)
+}
@@ -0,0 +1,159 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+func TestProviderConfigurations(t *testing.T) {
+	awsAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	refs := map[tfmod.ProviderRef]tfaddr.Provider{
+		{LocalName: "aws"}:                  awsAddr,
+		{LocalName: "aws", Alias: "west"}:   awsAddr,
+		{LocalName: "aws", Alias: "passed"}: awsAddr,
+	}
+	moduleProviders := map[string]ModuleProviders{
+		"replica": {
+			References: map[tfmod.ProviderRef]tfaddr.Provider{
+				{LocalName: "aws"}:               awsAddr,
+				{LocalName: "aws", Alias: "dst"}: awsAddr,
+			},
+			ConfigurationAliases: []tfmod.ProviderRef{
+				{LocalName: "aws", Alias: "dst"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  string
+		want []string
+	}{
+		{
+			"valid configurations",
+			`resource "aws_instance" "default" {
+  provider = aws
+}
+resource "aws_instance" "west" {
+  provider = aws.west
+}
+data "aws_ami" "passed" {
+  provider = aws.passed
+}
+module "replica" {
+  source = "./replica"
+  providers = {
+    aws     = aws
+    aws.dst = aws.west
+  }
+}
+module "unknown" {
+  source = "./unknown"
+}
+`,
+			[]string{},
+		},
+		{
+			"undefined aliases",
+			`resource "aws_instance" "east" {
+  provider = aws.east
+}
+module "replica" {
+  source = "./replica"
+  providers = {
+    aws.dst = aws.east
+  }
+}
+`,
+			[]string{
+				"test.tf:2,14-22: Reference to undefined provider configuration",
+				"test.tf:7,15-23: Reference to undefined provider configuration",
+			},
+		},
+		{
+			"missing and undeclared mappings",
+			`module "replica" {
+  source = "./replica"
+  providers = {
+    aws.src = aws.west
+  }
+}
+module "replica" {
+  source = "./replica"
+}
+`,
+			[]string{
+				"test.tf:4,5-12: Provider configuration not declared by module",
+				"test.tf:3,3-12: Missing required provider configuration",
+				"test.tf:7,1-17: Missing required provider configuration",
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.name), func(t *testing.T) {
+			ctx := context.Background()
+
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diags := ProviderConfigurations(ctx, pathCtx, refs, moduleProviders)
+			got := make([]string, 0)
+			for _, diag := range diags["test.tf"] {
+				got = append(got, fmt.Sprintf("%s: %s", diag.Subject, diag.Summary))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigurationAliases(t *testing.T) {
+	cfg := `terraform {
+  required_providers {
+    google = {
+      source = "hashicorp/google"
+    }
+    aws = {
+      source                = "hashicorp/aws"
+      configuration_aliases = [aws.src, aws.dst]
+    }
+  }
+}
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	aliases := ConfigurationAliases(map[string]*hcl.File{
+		"test.tf": f,
+	})
+	expectedAliases := []tfmod.ProviderRef{
+		{LocalName: "aws", Alias: "src"},
+		{LocalName: "aws", Alias: "dst"},
+	}
+	if diff := cmp.Diff(expectedAliases, aliases); diff != "" {
+		t.Fatalf("unexpected aliases: %s", diff)
+	}
+}
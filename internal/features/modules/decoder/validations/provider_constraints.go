@@ -0,0 +1,271 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ModuleRequirements are provider version constraints
+// declared by a single module of a module tree
+type ModuleRequirements struct {
+	// Address is the address of the module within the tree,
+	// e.g. module.network.module.subnet, or empty for the root module
+	Address string
+	// Path is the path to the module directory
+	Path         string
+	Requirements tfmod.ProviderRequirements
+}
+
+func (mr ModuleRequirements) displayAddress() string {
+	if mr.Address == "" {
+		return "root module"
+	}
+	return mr.Address
+}
+
+// ModuleTree represents a root module along with
+// all modules it calls, directly or indirectly
+type ModuleTree struct {
+	Modules []ModuleRequirements
+	// LockedProviders are provider versions selected
+	// in the dependency lock file of the root module
+	LockedProviders map[tfaddr.Provider]*version.Version
+}
+
+// providerRequirement is a required_providers entry
+// declaring version constraints
+type providerRequirement struct {
+	localName string
+	rng       hcl.Range
+}
+
+// ProviderConstraints checks that version constraints of each provider
+// declared across the given module trees can be satisfied at once,
+// and that they are met by the version selected in the lock file.
+//
+// Diagnostics are reported on required_providers entries of the module
+// at modPath, which contribute to the conflict. The module may be part
+// of multiple trees, or be part of a single tree multiple times.
+func ProviderConstraints(ctx context.Context, pathCtx *decoder.PathContext, modPath string, refs map[tfmod.ProviderRef]tfaddr.Provider, trees []ModuleTree) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	requirements := requiredProviderEntries(pathCtx.Files)
+	if len(requirements) == 0 {
+		return diagsMap
+	}
+
+	for _, tree := range trees {
+		for _, pAddr := range treeProviders(tree) {
+			constraints := make(version.Constraints, 0)
+			contributes := false
+			lines := make([]string, 0)
+			for _, mod := range tree.Modules {
+				cons := mod.Requirements[pAddr]
+				if len(cons) == 0 {
+					continue
+				}
+				if mod.Path == modPath {
+					contributes = true
+				}
+				constraints = append(constraints, cons...)
+				lines = append(lines, fmt.Sprintf("  - %s: %s", mod.displayAddress(), cons))
+			}
+			if !contributes {
+				continue
+			}
+
+			var diag *hcl.Diagnostic
+			if !satisfiable(constraints) {
+				diag = &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Conflicting provider version constraints",
+					Detail: fmt.Sprintf("No version of %s satisfies all version constraints declared across the module tree:\n%s",
+						pAddr.ForDisplay(), strings.Join(lines, "\n")),
+				}
+			} else if locked, ok := tree.LockedProviders[pAddr]; ok && locked != nil && !constraints.Check(locked) {
+				diag = &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Locked provider version does not match constraints",
+					Detail: fmt.Sprintf("Version %s of %s selected in the dependency lock file does not satisfy "+
+						"all version constraints declared across the module tree:\n%s\n\n"+
+						"Run terraform init -upgrade to select a matching version.",
+						locked, pAddr.ForDisplay(), strings.Join(lines, "\n")),
+				}
+			}
+			if diag == nil {
+				continue
+			}
+
+			for _, req := range requirements {
+				if refs[tfmod.ProviderRef{LocalName: req.localName}] != pAddr {
+					continue
+				}
+				reqDiag := *diag
+				reqDiag.Subject = req.rng.Ptr()
+				if !containsDiag(diagsMap[req.rng.Filename], &reqDiag) {
+					diagsMap[req.rng.Filename] = append(diagsMap[req.rng.Filename], &reqDiag)
+				}
+			}
+		}
+	}
+
+	return diagsMap
+}
+
+// treeProviders returns sorted addresses of providers
+// with version constraints in the given tree
+func treeProviders(tree ModuleTree) []tfaddr.Provider {
+	seen := make(map[tfaddr.Provider]bool)
+	providers := make([]tfaddr.Provider, 0)
+	for _, mod := range tree.Modules {
+		for pAddr, cons := range mod.Requirements {
+			if len(cons) == 0 || seen[pAddr] {
+				continue
+			}
+			seen[pAddr] = true
+			providers = append(providers, pAddr)
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].String() < providers[j].String()
+	})
+	return providers
+}
+
+// satisfiable returns true if there is a version which satisfies
+// all given constraints
+func satisfiable(constraints version.Constraints) bool {
+	candidates, err := candidateVersions(constraints)
+	if err != nil {
+		// we can't tell, so we assume the best
+		return true
+	}
+	for _, v := range candidates {
+		if constraints.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// lowestVersion returns the lowest version
+// which satisfies all given constraints
+func lowestVersion(constraints version.Constraints) (*version.Version, bool) {
+	candidates, err := candidateVersions(constraints)
+	if err != nil {
+		return nil, false
+	}
+	for _, v := range candidates {
+		if constraints.Check(v) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// candidateVersions returns sorted versions to check against the given
+// constraints. The lowest version satisfying the constraints is either
+// a version from one of the constraints or a version right above it,
+// so only these need to be checked.
+func candidateVersions(constraints version.Constraints) (version.Collection, error) {
+	candidates := version.Collection{version.Must(version.NewVersion("0.0.0"))}
+	for _, c := range constraints {
+		v, err := version.NewVersion(strings.TrimLeft(c.String(), "=!<>~ "))
+		if err != nil {
+			return nil, err
+		}
+		segments := v.Segments()
+		candidates = append(candidates, v, v.Core(),
+			version.Must(version.NewVersion(fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2]+1))),
+			version.Must(version.NewVersion(fmt.Sprintf("%d.%d.0", segments[0], segments[1]+1))),
+			version.Must(version.NewVersion(fmt.Sprintf("%d.0.0", segments[0]+1))),
+		)
+	}
+	sort.Sort(candidates)
+	return candidates, nil
+}
+
+// requiredProviderEntries returns required_providers entries
+// of the given files which declare version constraints
+func requiredProviderEntries(files map[string]*hcl.File) []providerRequirement {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	requirements := make([]providerRequirement, 0)
+	for _, filename := range filenames {
+		content, _, _ := files[filename].Body.PartialContent(providerFileSchema)
+		for _, block := range content.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			tfContent, _, _ := block.Body.PartialContent(terraformBlockSchema)
+			for _, rpBlock := range tfContent.Blocks {
+				attrs, _ := rpBlock.Body.JustAttributes()
+				for name, attr := range attrs {
+					rng, ok := versionRange(attr.Expr)
+					if !ok {
+						continue
+					}
+					requirements = append(requirements, providerRequirement{
+						localName: name,
+						rng:       rng,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(requirements, func(i, j int) bool {
+		if requirements[i].rng.Filename != requirements[j].rng.Filename {
+			return requirements[i].rng.Filename < requirements[j].rng.Filename
+		}
+		return requirements[i].rng.Start.Byte < requirements[j].rng.Start.Byte
+	})
+	return requirements
+}
+
+// versionRange returns the range of the version constraint
+// of a required_providers entry, which is either an object
+// with the version attribute or a legacy version string
+func versionRange(expr hcl.Expression) (hcl.Range, bool) {
+	pairs, diags := hcl.ExprMap(expr)
+	if diags.HasErrors() {
+		val, diags := expr.Value(nil)
+		if diags.HasErrors() || !val.Type().Equals(cty.String) {
+			return hcl.Range{}, false
+		}
+		return expr.Range(), true
+	}
+
+	for _, pair := range pairs {
+		if name, _ := ihcl.ObjectKeyName(pair.Key); name == "version" {
+			return pair.Value.Range(), true
+		}
+	}
+	return hcl.Range{}, false
+}
+
+func containsDiag(diags hcl.Diagnostics, diag *hcl.Diagnostic) bool {
+	for _, d := range diags {
+		if d.Summary == diag.Summary && d.Detail == diag.Detail && d.Subject.String() == diag.Subject.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+)
+
+func TestProviderConstraints(t *testing.T) {
+	awsAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	refs := map[tfmod.ProviderRef]tfaddr.Provider{
+		{LocalName: "aws"}: awsAddr,
+	}
+	cfg := `terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+`
+
+	tests := []struct {
+		name       string
+		trees      []ModuleTree
+		wantDiags  []string
+		wantDetail string
+	}{
+		{
+			"compatible constraints",
+			[]ModuleTree{
+				{
+					Modules: []ModuleRequirements{
+						{Path: "root", Requirements: tfmod.ProviderRequirements{
+							awsAddr: version.MustConstraints(version.NewConstraint(">= 5.0")),
+						}},
+						{Address: "module.network", Path: "network", Requirements: tfmod.ProviderRequirements{
+							awsAddr: version.MustConstraints(version.NewConstraint("~> 5.2")),
+						}},
+					},
+					LockedProviders: map[tfaddr.Provider]*version.Version{
+						awsAddr: version.Must(version.NewVersion("5.4.0")),
+					},
+				},
+			},
+			[]string{},
+			"",
+		},
+		{
+			"conflicting constraints",
+			[]ModuleTree{
+				{
+					Modules: []ModuleRequirements{
+						{Path: "root", Requirements: tfmod.ProviderRequirements{
+							awsAddr: version.MustConstraints(version.NewConstraint(">= 5.0")),
+						}},
+						{Address: "module.network", Path: "network", Requirements: tfmod.ProviderRequirements{}},
+						{Address: "module.network.module.subnet", Path: "subnet", Requirements: tfmod.ProviderRequirements{
+							awsAddr: version.MustConstraints(version.NewConstraint("~> 4.0")),
+						}},
+					},
+				},
+			},
+			[]string{
+				"test.tf:5,17-25: Conflicting provider version constraints",
+			},
+			`No version of hashicorp/aws satisfies all version constraints declared across the module tree:
+  - root module: >= 5.0
+  - module.network.module.subnet: ~> 4.0`,
+		},
+		{
+			"conflict without the module",
+			[]ModuleTree{
+				{
+					Modules: []ModuleRequirements{
+						{Path: "root", Requirements: tfmod.ProviderRequirements{}},
+						{Address: "module.a", Path: "a", Requirements: tfmod.ProviderRequirements{
+							awsAddr: version.MustConstraints(version.NewConstraint("< 4.0")),
+						}},
+						{Address: "module.b", Path: "b", Requirements: tfmod.ProviderRequirements{
+							awsAddr: version.MustConstraints(version.NewConstraint("> 4.0")),
+						}},
+					},
+				},
+			},
+			[]string{},
+			"",
+		},
+		{
+			"locked version not matching",
+			[]ModuleTree{
+				{
+					Modules: []ModuleRequirements{
+						{Path: "root", Requirements: tfmod.ProviderRequirements{
+							awsAddr: version.MustConstraints(version.NewConstraint(">= 5.0")),
+						}},
+					},
+					LockedProviders: map[tfaddr.Provider]*version.Version{
+						awsAddr: version.Must(version.NewVersion("4.67.0")),
+					},
+				},
+			},
+			[]string{
+				"test.tf:5,17-25: Locked provider version does not match constraints",
+			},
+			`Version 4.67.0 of hashicorp/aws selected in the dependency lock file does not satisfy all version constraints declared across the module tree:
+  - root module: >= 5.0
+
+Run terraform init -upgrade to select a matching version.`,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.name), func(t *testing.T) {
+			ctx := context.Background()
+
+			f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diags := ProviderConstraints(ctx, pathCtx, "root", refs, tt.trees)
+			got := make([]string, 0)
+			for _, diag := range diags["test.tf"] {
+				got = append(got, fmt.Sprintf("%s: %s", diag.Subject, diag.Summary))
+			}
+			if diff := cmp.Diff(tt.wantDiags, got); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+			if tt.wantDetail != "" {
+				if diff := cmp.Diff(tt.wantDetail, diags["test.tf"][0].Detail); diff != "" {
+					t.Fatalf("unexpected detail: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestSatisfiable(t *testing.T) {
+	tests := []struct {
+		constraints string
+		expected    bool
+	}{
+		{">= 5.0, ~> 5.2", true},
+		{">= 5.0, ~> 4.0", false},
+		{"~> 4.0.1, < 4.0.5", true},
+		{"~> 4.0.1, >= 4.1.0", false},
+		{"> 1.0.0, < 1.0.1", false},
+		{"!= 1.2.0, = 1.2.0", false},
+		{"< 2.0", true},
+		{"= 1.2.0-beta1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraints, func(t *testing.T) {
+			constraints := version.MustConstraints(version.NewConstraint(tt.constraints))
+			if satisfiable(constraints) != tt.expected {
+				t.Fatalf("expected %q satisfiable: %t", tt.constraints, tt.expected)
+			}
+		})
+	}
+}
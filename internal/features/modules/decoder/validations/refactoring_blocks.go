@@ -0,0 +1,272 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+var (
+	resourceScope = lang.ScopeId("resource")
+	moduleScope   = lang.ScopeId("module")
+
+	v1_6 = version.Must(version.NewVersion("1.6.0"))
+	v1_7 = version.Must(version.NewVersion("1.7.0"))
+)
+
+var refactoringFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "moved"},
+		{Type: "import"},
+		{Type: "removed"},
+	},
+}
+
+var refactoringBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "from"},
+		{Name: "to"},
+		{Name: "id"},
+		{Name: "for_each"},
+	},
+}
+
+// objectAddress represents an address of a resource or module (instance)
+// as used in moved, import or removed blocks
+type objectAddress struct {
+	// full is the address without any instance keys,
+	// e.g. module.network.aws_vpc.main
+	full string
+	// declared is the address of the resource or module call which
+	// must be declared in this module, e.g. module.network
+	declared string
+}
+
+func (a objectAddress) isNested() bool {
+	return a.full != a.declared
+}
+
+// RefactoringBlocks checks moved, import and removed blocks against
+// resources and modules declared in the module, i.e. that
+//
+//   - moved.to and import.to point to a declared resource or module
+//   - moved.from and removed.from are no longer declared
+//
+// Objects nested in module calls can only be checked as far as
+// the module call. Import blocks are also checked against
+// capabilities of the given Terraform version, if known.
+func RefactoringBlocks(ctx context.Context, pathCtx *decoder.PathContext, tfVersion *version.Version) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	declared := make(map[string]bool)
+	for _, target := range pathCtx.ReferenceTargets {
+		if target.ScopeId == resourceScope || target.ScopeId == moduleScope {
+			declared[target.Addr.String()] = true
+		}
+	}
+
+	filenames := make([]string, 0, len(pathCtx.Files))
+	for filename := range pathCtx.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	blocks := make([]*hcl.Block, 0)
+	contents := make(map[*hcl.Block]*hcl.BodyContent)
+	// moved blocks may form a chain, where the target
+	// of one is the source of another one
+	movedFrom := make(map[string]bool)
+	for _, filename := range filenames {
+		content, _, _ := pathCtx.Files[filename].Body.PartialContent(refactoringFileSchema)
+		for _, block := range content.Blocks {
+			blockContent, _, _ := block.Body.PartialContent(refactoringBlockSchema)
+			blocks = append(blocks, block)
+			contents[block] = blockContent
+
+			if block.Type != "moved" {
+				continue
+			}
+			if from, ok := addressAttr(blockContent, "from"); ok {
+				movedFrom[from.full] = true
+			}
+		}
+	}
+
+	for _, block := range blocks {
+		content := contents[block]
+		var diags hcl.Diagnostics
+
+		switch block.Type {
+		case "moved":
+			diags = movedBlockDiags(content, declared, movedFrom)
+		case "import":
+			diags = importBlockDiags(content, declared, tfVersion)
+		case "removed":
+			diags = removedBlockDiags(content, declared)
+		}
+
+		diagsMap[block.DefRange.Filename] = diagsMap[block.DefRange.Filename].Extend(diags)
+	}
+
+	return diagsMap
+}
+
+func movedBlockDiags(content *hcl.BodyContent, declared, movedFrom map[string]bool) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	from, fromOk := addressAttr(content, "from")
+	to, toOk := addressAttr(content, "to")
+
+	if toOk && !declared[to.declared] && !movedFrom[to.full] {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("No declaration found for %q", to.declared),
+			Detail:   "The target of a moved block must be declared in this module, or be the source of another moved block.",
+			Subject:  content.Attributes["to"].Expr.Range().Ptr(),
+		})
+	}
+
+	if fromOk && !from.isNested() && declared[from.declared] {
+		if toOk && to.full == from.full {
+			// moving between instances of the same resource or module
+			return diags
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Moved object still exists",
+			Detail: fmt.Sprintf("This statement declares a move from %s, but that object is still declared. "+
+				"Remove or rename the declaration, so that the object is only declared at the new address.", from.declared),
+			Subject: content.Attributes["from"].Expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+func importBlockDiags(content *hcl.BodyContent, declared map[string]bool, tfVersion *version.Version) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if to, ok := addressAttr(content, "to"); ok && !declared[to.declared] {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Configuration for import target does not exist",
+			Detail:   fmt.Sprintf("The configuration for %s does not exist. Import targets must be declared in this module.", to.declared),
+			Subject:  content.Attributes["to"].Expr.Range().Ptr(),
+		})
+	}
+
+	if tfVersion == nil {
+		return diags
+	}
+
+	if attr, ok := content.Attributes["for_each"]; ok && tfVersion.LessThan(v1_7) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported import argument",
+			Detail:   fmt.Sprintf("Importing multiple resources via for_each requires Terraform 1.7 or later, but Terraform %s is used.", tfVersion),
+			Subject:  attr.NameRange.Ptr(),
+		})
+	}
+
+	if attr, ok := content.Attributes["id"]; ok && tfVersion.LessThan(v1_6) && !isLiteral(attr.Expr) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid import id",
+			Detail:   fmt.Sprintf("Terraform %s only supports literal strings as import IDs. Expressions are supported in Terraform 1.6 or later.", tfVersion),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+func removedBlockDiags(content *hcl.BodyContent, declared map[string]bool) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	from, ok := addressAttr(content, "from")
+	if !ok || from.isNested() || !declared[from.declared] {
+		return diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Removed object still exists",
+		Detail:   fmt.Sprintf("This statement declares that %s was removed, but it is still declared in configuration.", from.declared),
+		Subject:  content.Attributes["from"].Expr.Range().Ptr(),
+	})
+
+	return diags
+}
+
+func addressAttr(content *hcl.BodyContent, name string) (objectAddress, bool) {
+	attr, ok := content.Attributes[name]
+	if !ok {
+		return objectAddress{}, false
+	}
+
+	steps, ok := addressSteps(attr.Expr)
+	if !ok || len(steps) < 2 {
+		return objectAddress{}, false
+	}
+
+	return objectAddress{
+		full:     strings.Join(steps, "."),
+		declared: strings.Join(steps[:2], "."),
+	}, true
+}
+
+// addressSteps returns names of the address steps, ignoring any instance
+// keys, which may also be expressions, e.g. in import blocks with for_each
+func addressSteps(expr hcl.Expression) ([]string, bool) {
+	if traversal, diags := hcl.AbsTraversalForExpr(expr); !diags.HasErrors() {
+		return traversalNames(traversal), true
+	}
+
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return traversalNames(e.Traversal), true
+	case *hclsyntax.IndexExpr:
+		return addressSteps(e.Collection)
+	case *hclsyntax.RelativeTraversalExpr:
+		steps, ok := addressSteps(e.Source)
+		if !ok {
+			return nil, false
+		}
+		return append(steps, traversalNames(e.Traversal)...), true
+	}
+
+	return nil, false
+}
+
+func traversalNames(traversal hcl.Traversal) []string {
+	names := make([]string, 0, len(traversal))
+	for _, step := range traversal {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			names = append(names, s.Name)
+		case hcl.TraverseAttr:
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// isLiteral reports whether the expression evaluates
+// without any references or function calls
+func isLiteral(expr hcl.Expression) bool {
+	if len(expr.Variables()) > 0 {
+		return false
+	}
+	val, diags := expr.Value(nil)
+	return !diags.HasErrors() && val.IsWhollyKnown()
+}
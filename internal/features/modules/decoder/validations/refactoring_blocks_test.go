@@ -0,0 +1,170 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestRefactoringBlocks(t *testing.T) {
+	targets := reference.Targets{
+		{
+			Addr:    lang.Address{lang.RootStep{Name: "aws_instance"}, lang.AttrStep{Name: "web"}},
+			ScopeId: lang.ScopeId("resource"),
+		},
+		{
+			Addr:    lang.Address{lang.RootStep{Name: "module"}, lang.AttrStep{Name: "network"}},
+			ScopeId: lang.ScopeId("module"),
+		},
+		{
+			Addr:    lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "ids"}},
+			ScopeId: lang.ScopeId("variable"),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		cfg       string
+		tfVersion *version.Version
+		want      []string
+	}{
+		{
+			"valid blocks",
+			`moved {
+  from = aws_instance.old
+  to   = aws_instance.web
+}
+moved {
+  from = aws_instance.web[0]
+  to   = aws_instance.web["a"]
+}
+moved {
+  from = module.network.aws_vpc.main
+  to   = aws_instance.web
+}
+import {
+  to = module.network.aws_vpc.main
+  id = "vpc-123"
+}
+removed {
+  from = aws_instance.gone
+}
+removed {
+  from = module.network.aws_vpc.gone
+}
+`,
+			nil,
+			[]string{},
+		},
+		{
+			"undeclared targets",
+			`moved {
+  from = aws_instance.old
+  to   = aws_instance.new
+}
+import {
+  to = aws_instance.db
+  id = "i-123"
+}
+`,
+			nil,
+			[]string{
+				"test.tf:3,10-26: No declaration found for \"aws_instance.new\"",
+				"test.tf:6,8-23: Configuration for import target does not exist",
+			},
+		},
+		{
+			"moved chain",
+			`moved {
+  from = aws_instance.a
+  to   = aws_instance.b
+}
+moved {
+  from = aws_instance.b
+  to   = aws_instance.web
+}
+`,
+			nil,
+			[]string{},
+		},
+		{
+			"sources still declared",
+			`moved {
+  from = module.network
+  to   = aws_instance.web
+}
+removed {
+  from = aws_instance.web
+}
+`,
+			nil,
+			[]string{
+				"test.tf:2,10-24: Moved object still exists",
+				"test.tf:6,10-26: Removed object still exists",
+			},
+		},
+		{
+			"import with for_each and expression id on old version",
+			`import {
+  for_each = var.ids
+  to       = aws_instance.web[each.key]
+  id       = each.value
+}
+`,
+			version.Must(version.NewVersion("1.5.7")),
+			[]string{
+				"test.tf:2,3-11: Unsupported import argument",
+				"test.tf:4,14-24: Invalid import id",
+			},
+		},
+		{
+			"import with for_each on new version",
+			`import {
+  for_each = var.ids
+  to       = aws_instance.web[each.key]
+  id       = each.value
+}
+`,
+			version.Must(version.NewVersion("1.7.0")),
+			[]string{},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.name), func(t *testing.T) {
+			ctx := context.Background()
+
+			f, pDiags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				ReferenceTargets: targets,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			diags := RefactoringBlocks(ctx, pathCtx, tt.tfVersion)
+			got := make([]string, 0)
+			for _, diag := range diags["test.tf"] {
+				got = append(got, fmt.Sprintf("%s: %s", diag.Subject, diag.Summary))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
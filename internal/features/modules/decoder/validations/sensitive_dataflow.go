@@ -0,0 +1,526 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// valueFlags describes what a value is derived from
+type valueFlags struct {
+	sensitive bool
+	ephemeral bool
+}
+
+func (f valueFlags) union(other valueFlags) valueFlags {
+	return valueFlags{
+		sensitive: f.sensitive || other.sensitive,
+		ephemeral: f.ephemeral || other.ephemeral,
+	}
+}
+
+// dataflow follows sensitive and ephemeral values from their sources,
+// i.e. variables, provider schema and ephemeral resources,
+// through local values to where they are used
+type dataflow struct {
+	pathCtx *decoder.PathContext
+
+	origins   map[string]reference.Origins
+	variables map[string]valueFlags
+	locals    map[string]hcl.Expression
+
+	localFlags map[string]valueFlags
+	// visiting tracks local values being resolved to avoid cycles
+	visiting map[string]bool
+}
+
+// SensitiveDataflow reports outputs which refer to sensitive values
+// without being marked as sensitive, ephemeral values used in
+// resource arguments or non-ephemeral outputs and references
+// to write-only attributes, whose value is never available
+func SensitiveDataflow(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+
+	df := &dataflow{
+		pathCtx:    pathCtx,
+		origins:    make(map[string]reference.Origins),
+		variables:  make(map[string]valueFlags),
+		locals:     make(map[string]hcl.Expression),
+		localFlags: make(map[string]valueFlags),
+		visiting:   make(map[string]bool),
+	}
+	for _, origin := range pathCtx.ReferenceOrigins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok {
+			continue
+		}
+		filename := localOrigin.Range.Filename
+		df.origins[filename] = append(df.origins[filename], localOrigin)
+	}
+
+	bodies := make(map[string]*hclsyntax.Body, len(pathCtx.Files))
+	for filename, f := range pathCtx.Files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		bodies[filename] = body
+		diagsMap[filename] = make(hcl.Diagnostics, 0)
+
+		for _, block := range body.Blocks {
+			switch {
+			case block.Type == "variable" && len(block.Labels) == 1:
+				df.variables[block.Labels[0]] = valueFlags{
+					sensitive: isTrue(block.Body.Attributes["sensitive"]),
+					ephemeral: isTrue(block.Body.Attributes["ephemeral"]),
+				}
+			case block.Type == "locals":
+				for name, attr := range block.Body.Attributes {
+					df.locals[name] = attr.Expr
+				}
+			}
+		}
+	}
+
+	for filename, body := range bodies {
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "output":
+				diagsMap[filename] = append(diagsMap[filename], df.outputDiags(block)...)
+			case "resource", "data":
+				if len(block.Labels) != 2 {
+					continue
+				}
+				bodySchema, _ := df.resourceSchema(block.Type, block.Labels[0])
+				diagsMap[filename] = append(diagsMap[filename], df.resourceArgumentDiags(block.Body, bodySchema)...)
+			}
+		}
+
+		diagsMap[filename] = append(diagsMap[filename], df.writeOnlyReferenceDiags(filename)...)
+	}
+
+	return diagsMap
+}
+
+func (df *dataflow) outputDiags(block *hclsyntax.Block) hcl.Diagnostics {
+	diags := make(hcl.Diagnostics, 0)
+
+	value, ok := block.Body.Attributes["value"]
+	if !ok {
+		return diags
+	}
+	flags := df.exprFlags(value.Expr)
+
+	if flags.sensitive && !isTrue(block.Body.Attributes["sensitive"]) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Output refers to sensitive values",
+			Detail: "To reduce the risk of accidentally exporting sensitive data that was intended to be only internal, " +
+				"Terraform requires that any output containing sensitive data be explicitly marked as sensitive " +
+				"via sensitive = true, to confirm your intent.",
+			Subject: value.Expr.Range().Ptr(),
+		})
+	}
+	if flags.ephemeral && !isTrue(block.Body.Attributes["ephemeral"]) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Ephemeral value not allowed",
+			Detail: "This output value is not declared as returning an ephemeral value, " +
+				"so it cannot be set to a result derived from an ephemeral value.",
+			Subject: value.Expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+// resourceArgumentDiags reports ephemeral values used in arguments
+// of resources and data sources, which are persisted in the plan and
+// state, unless the arguments are write-only
+func (df *dataflow) resourceArgumentDiags(body *hclsyntax.Body, bodySchema *schema.BodySchema) hcl.Diagnostics {
+	diags := make(hcl.Diagnostics, 0)
+
+	names := make([]string, 0, len(body.Attributes))
+	for name := range body.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attr := body.Attributes[name]
+		if isMetaArgument(name) {
+			continue
+		}
+		if bodySchema != nil {
+			if attrSchema, ok := bodySchema.Attributes[name]; ok && attrSchema.IsWriteOnly {
+				continue
+			}
+		}
+		if !df.exprFlags(attr.Expr).ephemeral {
+			continue
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid use of ephemeral value",
+			Detail: fmt.Sprintf("Ephemeral values are not valid for %q, because it is not a write-only attribute "+
+				"and must be persisted to state.", name),
+			Subject: attr.Expr.Range().Ptr(),
+		})
+	}
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "lifecycle", "provisioner", "connection":
+			// ephemeral values are allowed in provisioners and connections
+			continue
+		case "dynamic":
+			if len(block.Labels) != 1 {
+				continue
+			}
+			var nestedSchema *schema.BodySchema
+			if bodySchema != nil {
+				if blockSchema, ok := bodySchema.Blocks[block.Labels[0]]; ok {
+					nestedSchema = blockSchema.Body
+				}
+			}
+			for _, content := range block.Body.Blocks {
+				if content.Type == "content" {
+					diags = append(diags, df.resourceArgumentDiags(content.Body, nestedSchema)...)
+				}
+			}
+		default:
+			var nestedSchema *schema.BodySchema
+			if bodySchema != nil {
+				if blockSchema, ok := bodySchema.Blocks[block.Type]; ok {
+					nestedSchema = blockSchema.Body
+				}
+			}
+			diags = append(diags, df.resourceArgumentDiags(block.Body, nestedSchema)...)
+		}
+	}
+
+	return diags
+}
+
+// writeOnlyReferenceDiags reports references to write-only attributes
+// of resources, which are always null when referenced
+func (df *dataflow) writeOnlyReferenceDiags(filename string) hcl.Diagnostics {
+	diags := make(hcl.Diagnostics, 0)
+
+	for _, origin := range df.origins[filename] {
+		localOrigin := origin.(reference.LocalOrigin)
+		attrSchema, name, ok := df.referencedAttribute(localOrigin.Addr)
+		if !ok || !attrSchema.IsWriteOnly {
+			continue
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Reference to write-only attribute",
+			Detail: fmt.Sprintf("The value of %q is not persisted to plan or state "+
+				"and is always null when referenced.", name),
+			Subject: localOrigin.Range.Ptr(),
+		})
+	}
+
+	return diags
+}
+
+// exprFlags returns flags of all values the expression refers to
+//
+// Values passed to nonsensitive() and ephemeralasnull()
+// lose their sensitivity and ephemerality respectively,
+// while values passed to sensitive() become sensitive.
+func (df *dataflow) exprFlags(expr hcl.Expression) valueFlags {
+	var flags valueFlags
+
+	rng := expr.Range()
+	nonsensitiveRanges := make([]hcl.Range, 0)
+	ephemeralAsNullRanges := make([]hcl.Range, 0)
+	projections := make([]projection, 0)
+	if syntaxExpr, ok := expr.(hclsyntax.Expression); ok {
+		hclsyntax.VisitAll(syntaxExpr, func(node hclsyntax.Node) hcl.Diagnostics {
+			switch node := node.(type) {
+			case *hclsyntax.SplatExpr:
+				projections = append(projections, splatProjection(node))
+			case *hclsyntax.ForExpr:
+				projections = append(projections, forProjection(node))
+			case *hclsyntax.FunctionCallExpr:
+				switch node.Name {
+				case "sensitive":
+					flags.sensitive = true
+				case "nonsensitive":
+					nonsensitiveRanges = append(nonsensitiveRanges, node.Range())
+				case "ephemeralasnull":
+					ephemeralAsNullRanges = append(ephemeralAsNullRanges, node.Range())
+				}
+			}
+			return nil
+		})
+	}
+
+	for _, origin := range df.origins[rng.Filename] {
+		localOrigin := origin.(reference.LocalOrigin)
+		if !withinAnyRange([]hcl.Range{rng}, localOrigin.Range) {
+			continue
+		}
+
+		originFlags := df.originFlags(localOrigin, projections)
+		if withinAnyRange(nonsensitiveRanges, localOrigin.Range) {
+			originFlags.sensitive = false
+		}
+		if withinAnyRange(ephemeralAsNullRanges, localOrigin.Range) {
+			originFlags.ephemeral = false
+		}
+		flags = flags.union(originFlags)
+	}
+
+	return flags
+}
+
+// projection describes which attributes of each element of a collection
+// a splat or for expression selects, such as address in
+// aws_db_instance.main[*].address
+type projection struct {
+	source hcl.Range
+	// whole reports whether whole elements are used,
+	// in which case attributes are irrelevant
+	whole      bool
+	attributes []string
+}
+
+func splatProjection(expr *hclsyntax.SplatExpr) projection {
+	p := projection{source: expr.Source.Range()}
+
+	each, ok := expr.Each.(*hclsyntax.RelativeTraversalExpr)
+	if !ok || len(each.Traversal) == 0 {
+		p.whole = true
+		return p
+	}
+	step, ok := each.Traversal[0].(hcl.TraverseAttr)
+	if !ok {
+		p.whole = true
+		return p
+	}
+	p.attributes = []string{step.Name}
+	return p
+}
+
+func forProjection(expr *hclsyntax.ForExpr) projection {
+	p := projection{source: expr.CollExpr.Range()}
+
+	for _, e := range []hclsyntax.Expression{expr.KeyExpr, expr.ValExpr, expr.CondExpr} {
+		if e == nil {
+			continue
+		}
+		for _, traversal := range e.Variables() {
+			if traversal.RootName() != expr.ValVar {
+				continue
+			}
+			if len(traversal) < 2 {
+				p.whole = true
+				continue
+			}
+			step, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				p.whole = true
+				continue
+			}
+			p.attributes = append(p.attributes, step.Name)
+		}
+	}
+	return p
+}
+
+// originFlags returns flags of the value the origin refers to.
+// Whole resources used as the source of a splat or for expression
+// only pass on flags of the attributes selected from each instance.
+func (df *dataflow) originFlags(origin reference.LocalOrigin, projections []projection) valueFlags {
+	address := origin.Addr
+	if _, ok := df.addressSchema(address); !ok || len(objectAttributeSteps(address)) > 0 {
+		return df.addressFlags(address)
+	}
+
+	// the innermost projection determines what is used
+	var p *projection
+	for i := range projections {
+		if withinAnyRange([]hcl.Range{projections[i].source}, origin.Range) {
+			p = &projections[i]
+		}
+	}
+	if p == nil || p.whole {
+		return df.addressFlags(address)
+	}
+
+	var flags valueFlags
+	for _, name := range p.attributes {
+		attrAddress := make(lang.Address, 0, len(address)+1)
+		attrAddress = append(attrAddress, address...)
+		attrAddress = append(attrAddress, lang.AttrStep{Name: name})
+		flags = flags.union(df.addressFlags(attrAddress))
+	}
+	return flags
+}
+
+// addressFlags returns flags of the value at the given address
+func (df *dataflow) addressFlags(address lang.Address) valueFlags {
+	if len(address) < 2 {
+		return valueFlags{}
+	}
+	name, ok := ihcl.AddressStepName(address[1])
+	if !ok {
+		return valueFlags{}
+	}
+
+	switch address[0].String() {
+	case "var":
+		return df.variables[name]
+	case "local":
+		return df.localValueFlags(name)
+	case "ephemeral":
+		return valueFlags{ephemeral: true}
+	}
+
+	attrSchema, _, ok := df.referencedAttribute(address)
+	if ok {
+		return valueFlags{sensitive: attrSchema.IsSensitive}
+	}
+
+	// references to whole resources are sensitive
+	// if any of their attributes are sensitive
+	bodySchema, ok := df.addressSchema(address)
+	if !ok {
+		return valueFlags{}
+	}
+	if len(objectAttributeSteps(address)) > 0 {
+		return valueFlags{}
+	}
+	for _, attrSchema := range bodySchema.Attributes {
+		if attrSchema.IsSensitive {
+			return valueFlags{sensitive: true}
+		}
+	}
+	return valueFlags{}
+}
+
+func (df *dataflow) localValueFlags(name string) valueFlags {
+	if flags, ok := df.localFlags[name]; ok {
+		return flags
+	}
+	expr, ok := df.locals[name]
+	if !ok || df.visiting[name] {
+		return valueFlags{}
+	}
+
+	df.visiting[name] = true
+	flags := df.exprFlags(expr)
+	delete(df.visiting, name)
+
+	df.localFlags[name] = flags
+	return flags
+}
+
+// referencedAttribute returns the schema and name of the top-level
+// attribute of a resource or data source the address refers to
+func (df *dataflow) referencedAttribute(address lang.Address) (*schema.AttributeSchema, string, bool) {
+	bodySchema, ok := df.addressSchema(address)
+	if !ok {
+		return nil, "", false
+	}
+	steps := objectAttributeSteps(address)
+	if len(steps) == 0 {
+		return nil, "", false
+	}
+	name, ok := ihcl.AddressStepName(steps[0])
+	if !ok {
+		return nil, "", false
+	}
+	attrSchema, ok := bodySchema.Attributes[name]
+	if !ok {
+		return nil, "", false
+	}
+	return attrSchema, name, true
+}
+
+// addressSchema returns the body schema of the resource
+// or data source the address refers to
+func (df *dataflow) addressSchema(address lang.Address) (*schema.BodySchema, bool) {
+	if len(address) < 2 {
+		return nil, false
+	}
+	if address[0].String() == "data" {
+		if len(address) < 3 {
+			return nil, false
+		}
+		resourceType, ok := ihcl.AddressStepName(address[1])
+		if !ok {
+			return nil, false
+		}
+		return df.resourceSchema("data", resourceType)
+	}
+	return df.resourceSchema("resource", address[0].String())
+}
+
+func (df *dataflow) resourceSchema(blockType, resourceType string) (*schema.BodySchema, bool) {
+	if df.pathCtx.Schema == nil {
+		return nil, false
+	}
+	blockSchema, ok := df.pathCtx.Schema.Blocks[blockType]
+	if !ok {
+		return nil, false
+	}
+	bodySchema, ok := blockSchema.DependentBody[schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{
+			{Index: 0, Value: resourceType},
+		},
+	})]
+	return bodySchema, ok && bodySchema != nil
+}
+
+// objectAttributeSteps returns steps of the address following
+// the resource or data source name and its instance key, if any
+func objectAttributeSteps(address lang.Address) lang.Address {
+	i := 2
+	if address[0].String() == "data" {
+		i = 3
+	}
+	if i < len(address) {
+		if _, ok := address[i].(lang.IndexStep); ok {
+			i++
+		}
+	}
+	if i >= len(address) {
+		return lang.Address{}
+	}
+	return address[i:]
+}
+
+func isMetaArgument(name string) bool {
+	switch name {
+	case "count", "for_each", "depends_on", "provider":
+		return true
+	}
+	return false
+}
+
+func isTrue(attr *hclsyntax.Attribute) bool {
+	if attr == nil {
+		return false
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.Bool {
+		return false
+	}
+	return val.True()
+}
@@ -0,0 +1,249 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestSensitiveDataflow(t *testing.T) {
+	resourceKey := func(resourceType string) schema.SchemaKey {
+		return schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: resourceType},
+			},
+		})
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					resourceKey("aws_db_instance"): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"password":    {IsOptional: true, IsSensitive: true},
+							"password_wo": {IsOptional: true, IsWriteOnly: true},
+							"username":    {IsOptional: true},
+						},
+					},
+				},
+			},
+			"data": {
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					resourceKey("aws_secret"): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"value": {IsComputed: true, IsSensitive: true},
+							"name":  {IsRequired: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  string
+		want []string
+	}{
+		{
+			"sensitive variable through local",
+			`variable "token" {
+  sensitive = true
+}
+locals {
+  header = "Bearer ${var.token}"
+  auth   = local.header
+}
+output "auth" {
+  value = local.auth
+}
+output "marked" {
+  value     = local.auth
+  sensitive = true
+}
+output "unmarked" {
+  value = nonsensitive(local.auth)
+}
+`,
+			[]string{
+				"9:11: Output refers to sensitive values",
+			},
+		},
+		{
+			"sensitive provider attributes",
+			`resource "aws_db_instance" "main" {
+  username = "admin"
+}
+data "aws_secret" "db" {
+  name = "db"
+}
+output "password" {
+  value = aws_db_instance.main.password
+}
+output "username" {
+  value = aws_db_instance.main.username
+}
+output "secret" {
+  value = data.aws_secret.db.value
+}
+output "instance" {
+  value = aws_db_instance.main
+}
+output "explicit" {
+  value = sensitive("foo")
+}
+`,
+			[]string{
+				"8:11: Output refers to sensitive values",
+				"14:11: Output refers to sensitive values",
+				"17:11: Output refers to sensitive values",
+				"20:11: Output refers to sensitive values",
+			},
+		},
+		{
+			"projections of sensitive resources",
+			`resource "aws_db_instance" "main" {
+  count    = 2
+  username = "admin"
+}
+output "splat" {
+  value = aws_db_instance.main[*].username
+}
+output "legacy_splat" {
+  value = aws_db_instance.main.*.username
+}
+output "for" {
+  value = [for db in aws_db_instance.main : db.username]
+}
+output "for_map" {
+  value = { for i, db in aws_db_instance.main : db.username => i if db.username != "" }
+}
+output "splat_password" {
+  value = aws_db_instance.main[*].password
+}
+output "for_password" {
+  value = [for db in aws_db_instance.main : db.password]
+}
+output "splat_whole" {
+  value = aws_db_instance.main[*]
+}
+output "for_whole" {
+  value = [for db in aws_db_instance.main : db]
+}
+`,
+			[]string{
+				"18:11: Output refers to sensitive values",
+				"21:11: Output refers to sensitive values",
+				"24:11: Output refers to sensitive values",
+				"27:11: Output refers to sensitive values",
+			},
+		},
+		{
+			"ephemeral values",
+			`variable "session" {
+  ephemeral = true
+}
+locals {
+  password = ephemeral.random_password.db.result
+}
+resource "aws_db_instance" "main" {
+  username    = var.session
+  password    = local.password
+  password_wo = local.password
+
+  timeouts {
+    create = var.session
+  }
+}
+output "session" {
+  value = var.session
+}
+output "ephemeral" {
+  value     = var.session
+  ephemeral = true
+}
+output "null" {
+  value = ephemeralasnull(var.session)
+}
+`,
+			[]string{
+				"9:17: Invalid use of ephemeral value",
+				"8:17: Invalid use of ephemeral value",
+				"13:14: Invalid use of ephemeral value",
+				"17:11: Ephemeral value not allowed",
+			},
+		},
+		{
+			"write-only attribute reference",
+			`resource "aws_db_instance" "main" {
+  password_wo = "s3cr3t"
+}
+locals {
+  password = aws_db_instance.main.password_wo
+  username = aws_db_instance.main.username
+}
+`,
+			[]string{
+				"5:14: Reference to write-only attribute",
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.name), func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(tt.cfg), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Schema:           bodySchema,
+				Files:            map[string]*hcl.File{"test.tf": f},
+				ReferenceOrigins: testOriginsFromFile(t, f),
+			}
+
+			diagsMap := SensitiveDataflow(context.Background(), pathCtx)
+
+			got := make([]string, 0)
+			for _, diag := range diagsMap["test.tf"] {
+				got = append(got, fmt.Sprintf("%d:%d: %s", diag.Subject.Start.Line, diag.Subject.Start.Column, diag.Summary))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+// testOriginsFromFile returns local origins for all traversals
+// in the file, as they would be decoded for a module
+func testOriginsFromFile(t *testing.T, f *hcl.File) reference.Origins {
+	origins := make(reference.Origins, 0)
+	hclsyntax.VisitAll(f.Body.(*hclsyntax.Body), func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+		addr, err := lang.TraversalToAddress(expr.Traversal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		origins = append(origins, reference.LocalOrigin{
+			Addr:  addr,
+			Range: expr.Range(),
+		})
+		return nil
+	})
+	return origins
+}
@@ -0,0 +1,305 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+)
+
+var (
+	// Features which are not described by core schemas,
+	// along with the versions they were introduced in
+	terraformDataVersion     = version.Must(version.NewVersion("1.4.0"))
+	importForEachVersion     = v1_7
+	providerFunctionsVersion = version.Must(version.NewVersion("1.8.0"))
+)
+
+// coreSchemas holds core schemas of each minor Terraform version,
+// in ascending order, so that we can tell which version
+// introduced a block or an attribute
+var coreSchemas struct {
+	once     sync.Once
+	versions []*version.Version
+	schemas  []*schema.BodySchema
+}
+
+func loadCoreSchemas() {
+	coreSchemas.once.Do(func() {
+		latest := tfschema.LatestAvailableVersion.Segments()
+		for major := 0; major <= latest[0]; major++ {
+			minor := 0
+			if major == 0 {
+				minor = 12
+			}
+			lastMinor := 15
+			if major == latest[0] {
+				lastMinor = latest[1]
+			}
+			for ; minor <= lastMinor; minor++ {
+				v := version.Must(version.NewVersion(fmt.Sprintf("%d.%d.0", major, minor)))
+				s, err := tfschema.CoreModuleSchemaForVersion(v)
+				if err != nil {
+					continue
+				}
+				coreSchemas.versions = append(coreSchemas.versions, v)
+				coreSchemas.schemas = append(coreSchemas.schemas, s)
+			}
+		}
+	})
+}
+
+// coreSchemaForVersion returns core schema of the given version,
+// or of the oldest available version if the version is older
+func coreSchemaForVersion(v *version.Version) *schema.BodySchema {
+	loadCoreSchemas()
+	var s *schema.BodySchema
+	for i, sv := range coreSchemas.versions {
+		if sv.GreaterThan(v.Core()) {
+			break
+		}
+		s = coreSchemas.schemas[i]
+	}
+	if s == nil {
+		s = coreSchemas.schemas[0]
+	}
+	return s
+}
+
+// introducedIn returns the first version whose core schema
+// has the feature, as checked via the given function
+func introducedIn(has func(*schema.BodySchema) bool) (*version.Version, bool) {
+	loadCoreSchemas()
+	for i, s := range coreSchemas.schemas {
+		if has(s) {
+			return coreSchemas.versions[i], true
+		}
+	}
+	return nil, false
+}
+
+// TerraformVersionCompatibility checks the required_version constraint
+// of the module against the discovered Terraform version and the version
+// pinned in .terraform-version, if known.
+//
+// It also checks that the configuration doesn't use language features
+// introduced after the lowest version allowed by required_version.
+// Versions in which blocks and attributes were introduced are derived
+// from versioned core schemas.
+func TerraformVersionCompatibility(ctx context.Context, pathCtx *decoder.PathContext, required version.Constraints, discovered, pinned *version.Version) lang.DiagnosticsMap {
+	diagsMap := make(lang.DiagnosticsMap)
+	if len(required) == 0 {
+		return diagsMap
+	}
+
+	filenames := make([]string, 0, len(pathCtx.Files))
+	for filename := range pathCtx.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		body, ok := pathCtx.Files[filename].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			attr, ok := block.Body.Attributes["required_version"]
+			if !ok {
+				continue
+			}
+			diagsMap[filename] = diagsMap[filename].Extend(requiredVersionDiags(attr, required, discovered, pinned))
+		}
+	}
+
+	lowest, ok := lowestVersion(required)
+	if !ok {
+		return diagsMap
+	}
+	lowestSchema := coreSchemaForVersion(lowest)
+	latestSchema := coreSchemaForVersion(tfschema.LatestAvailableVersion)
+
+	for _, filename := range filenames {
+		body, ok := pathCtx.Files[filename].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		fc := featureChecker{
+			required:     required,
+			lowest:       lowest,
+			lowestSchema: lowestSchema,
+			latestSchema: latestSchema,
+		}
+		for _, block := range body.Blocks {
+			fc.checkBlock(block)
+		}
+		diagsMap[filename] = diagsMap[filename].Extend(fc.diags)
+	}
+
+	return diagsMap
+}
+
+func requiredVersionDiags(attr *hclsyntax.Attribute, required version.Constraints, discovered, pinned *version.Version) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if discovered != nil && !required.Check(discovered.Core()) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported Terraform version",
+			Detail: fmt.Sprintf("The installed Terraform %s does not satisfy required_version %q of this module.",
+				discovered, required),
+			Subject: attr.Expr.Range().Ptr(),
+		})
+	}
+	if pinned != nil && !required.Check(pinned.Core()) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported Terraform version",
+			Detail: fmt.Sprintf("Terraform %s pinned in .terraform-version does not satisfy required_version %q of this module.",
+				pinned, required),
+			Subject: attr.Expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+type featureChecker struct {
+	required     version.Constraints
+	lowest       *version.Version
+	lowestSchema *schema.BodySchema
+	latestSchema *schema.BodySchema
+
+	diags hcl.Diagnostics
+}
+
+func (fc *featureChecker) report(feature string, introduced *version.Version, rng hcl.Range) {
+	segments := introduced.Segments()
+	fc.diags = append(fc.diags, &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Feature not supported by minimum required Terraform version",
+		Detail: fmt.Sprintf("Using %s requires Terraform %d.%d or later, but required_version %q allows Terraform %s. "+
+			"Raise the minimum version in required_version, or avoid using this feature.",
+			feature, segments[0], segments[1], fc.required, fc.lowest),
+		Subject: rng.Ptr(),
+	})
+}
+
+func (fc *featureChecker) checkBlock(block *hclsyntax.Block) {
+	blockType := block.Type
+	latest, ok := fc.latestSchema.Blocks[blockType]
+	if !ok {
+		return
+	}
+
+	lowest, ok := fc.lowestSchema.Blocks[blockType]
+	if !ok {
+		introduced, ok := introducedIn(func(s *schema.BodySchema) bool {
+			_, ok := s.Blocks[blockType]
+			return ok
+		})
+		if ok {
+			fc.report(fmt.Sprintf("%q blocks", blockType), introduced, block.TypeRange)
+		}
+		return
+	}
+
+	if lowest.Body != nil && latest.Body != nil {
+		fc.checkBody(blockType, block.Body, lowest.Body, latest.Body)
+	}
+
+	if blockType == "resource" && len(block.Labels) > 0 && block.Labels[0] == "terraform_data" &&
+		fc.lowest.LessThan(terraformDataVersion) {
+		fc.report("the terraform_data resource", terraformDataVersion, block.LabelRanges[0])
+	}
+
+	if blockType == "import" && fc.lowest.LessThan(importForEachVersion) && !hasAttribute(latest.Body, "for_each") {
+		if attr, ok := block.Body.Attributes["for_each"]; ok {
+			fc.report("for_each in import blocks", importForEachVersion, attr.NameRange)
+		}
+	}
+
+	if fc.lowest.LessThan(providerFunctionsVersion) {
+		hclsyntax.VisitAll(block.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+			call, ok := node.(*hclsyntax.FunctionCallExpr)
+			if ok && strings.HasPrefix(call.Name, "provider::") {
+				fc.report("provider-defined functions", providerFunctionsVersion, call.NameRange)
+			}
+			return nil
+		})
+	}
+}
+
+// checkBody reports attributes and nested blocks of the given block,
+// which are not present in the lowest schema, but are in the latest one
+func (fc *featureChecker) checkBody(blockType string, body *hclsyntax.Body, lowest, latest *schema.BodySchema) {
+	names := make([]string, 0, len(body.Attributes))
+	for name := range body.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := latest.Attributes[name]; !ok {
+			continue
+		}
+		if _, ok := lowest.Attributes[name]; ok {
+			continue
+		}
+		introduced, ok := introducedIn(func(s *schema.BodySchema) bool {
+			bs, ok := s.Blocks[blockType]
+			if !ok || bs.Body == nil {
+				return false
+			}
+			_, ok = bs.Body.Attributes[name]
+			return ok
+		})
+		if ok {
+			fc.report(fmt.Sprintf("the %q argument in %s blocks", name, blockType), introduced, body.Attributes[name].NameRange)
+		}
+	}
+
+	for _, nested := range body.Blocks {
+		if _, ok := latest.Blocks[nested.Type]; !ok {
+			continue
+		}
+		if _, ok := lowest.Blocks[nested.Type]; ok {
+			continue
+		}
+		nestedType := nested.Type
+		introduced, ok := introducedIn(func(s *schema.BodySchema) bool {
+			bs, ok := s.Blocks[blockType]
+			if !ok || bs.Body == nil {
+				return false
+			}
+			_, ok = bs.Body.Blocks[nestedType]
+			return ok
+		})
+		if ok {
+			fc.report(fmt.Sprintf("%q blocks in %s blocks", nestedType, blockType), introduced, nested.TypeRange)
+		}
+	}
+}
+
+func hasAttribute(body *schema.BodySchema, name string) bool {
+	if body == nil {
+		return false
+	}
+	_, ok := body.Attributes[name]
+	return ok
+}
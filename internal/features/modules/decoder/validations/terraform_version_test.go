@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestTerraformVersionCompatibility(t *testing.T) {
+	cfg := `terraform {
+  required_version = ">= 1.5.0"
+}
+
+variable "token" {
+  ephemeral = true
+}
+
+removed {
+  from = aws_instance.old
+}
+
+import {
+  for_each = var.ids
+  to       = aws_instance.web[each.key]
+  id       = each.value
+}
+
+resource "terraform_data" "example" {
+  input = provider::aws::arn_parse("arn")
+}
+
+check "health" {
+  assert {
+    condition     = true
+    error_message = "unhealthy"
+  }
+}
+`
+
+	tests := []struct {
+		name       string
+		required   string
+		discovered *version.Version
+		pinned     *version.Version
+		want       []string
+	}{
+		{
+			"lowest version supports everything",
+			">= 1.10.0",
+			version.Must(version.NewVersion("1.10.2")),
+			nil,
+			[]string{},
+		},
+		{
+			"features newer than lowest version",
+			">= 1.5.0",
+			nil,
+			nil,
+			[]string{
+				`test.tf:6,3-12: Using the "ephemeral" argument in variable blocks requires Terraform 1.10 or later, but required_version ">= 1.5.0" allows Terraform 1.5.0.`,
+				`test.tf:9,1-8: Using "removed" blocks requires Terraform 1.7 or later, but required_version ">= 1.5.0" allows Terraform 1.5.0.`,
+				`test.tf:14,3-11: Using for_each in import blocks requires Terraform 1.7 or later, but required_version ">= 1.5.0" allows Terraform 1.5.0.`,
+				`test.tf:20,11-35: Using provider-defined functions requires Terraform 1.8 or later, but required_version ">= 1.5.0" allows Terraform 1.5.0.`,
+			},
+		},
+		{
+			"unsupported versions",
+			"~> 1.10.0",
+			version.Must(version.NewVersion("1.9.8")),
+			version.Must(version.NewVersion("1.11.0")),
+			[]string{
+				`test.tf:2,22-32: The installed Terraform 1.9.8 does not satisfy required_version "~> 1.10.0" of this module.`,
+				`test.tf:2,22-32: Terraform 1.11.0 pinned in .terraform-version does not satisfy required_version "~> 1.10.0" of this module.`,
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.name), func(t *testing.T) {
+			ctx := context.Background()
+
+			f, pDiags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			pathCtx := &decoder.PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			required := version.MustConstraints(version.NewConstraint(tt.required))
+			diags := TerraformVersionCompatibility(ctx, pathCtx, required, tt.discovered, tt.pinned)
+			got := make([]string, 0)
+			for _, diag := range diags["test.tf"] {
+				detail := strings.TrimSuffix(diag.Detail, " Raise the minimum version in required_version, or avoid using this feature.")
+				got = append(got, fmt.Sprintf("%s: %s", diag.Subject, detail))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLowestVersion(t *testing.T) {
+	tests := []struct {
+		constraints string
+		expected    string
+	}{
+		{">= 1.5.2", "1.5.2"},
+		{"~> 1.5", "1.5.0"},
+		{"> 1.5.0, < 2.0", "1.5.1"},
+		{"< 2.0", "0.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraints, func(t *testing.T) {
+			constraints := version.MustConstraints(version.NewConstraint(tt.constraints))
+			v, ok := lowestVersion(constraints)
+			if !ok {
+				t.Fatalf("expected lowest version for %q", tt.constraints)
+			}
+			if v.String() != tt.expected {
+				t.Fatalf("expected %s, %s given", tt.expected, v)
+			}
+		})
+	}
+}
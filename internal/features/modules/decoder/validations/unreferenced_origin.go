@@ -6,17 +6,32 @@ package validations
 import (
 	"context"
 	"fmt"
-	"slices"
+	"math/big"
 
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
 	diagsMap := make(lang.DiagnosticsMap)
 
+	// resource types declared in the module help us tell references
+	// to resources apart from other references, such as iterators
+	// of for expressions or dynamic blocks
+	resourceTypes := make(map[string]bool)
+	for _, target := range pathCtx.ReferenceTargets {
+		if target.ScopeId == resourceScope && len(target.Addr) == 2 {
+			resourceTypes[target.Addr[0].String()] = true
+		}
+	}
+	scopedDataSources := scopedDataSourceAddresses(pathCtx.Files)
+	addressRanges := refactoringAddressRanges(pathCtx.Files)
+
 	for _, origin := range pathCtx.ReferenceOrigins {
 		localOrigin, ok := origin.(reference.LocalOrigin)
 		if !ok {
@@ -36,27 +51,43 @@ func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang
 		}
 
 		address := localOrigin.Address()
-
-		if len(address) > 2 {
-			// We temporarily ignore references with more than 2 segments
-			// as these indicate references to complex types
-			// which we do not fully support yet.
-			// TODO: revisit as part of https://github.com/hashicorp/terraform-ls/issues/653
+		if withinAnyRange(addressRanges, localOrigin.Range) {
+			// addresses in moved, import and removed blocks are
+			// validated separately, see RefactoringBlocks
 			continue
 		}
 
-		// we only initially validate variables & local values
-		// resources and data sources can have unknown schema
-		// and will be researched at a later point
-		// TODO: revisit as part of https://github.com/hashicorp/terraform-ls/issues/1364
-		supported := []string{"var", "local"}
-		firstStep := address[0].String()
-		if !slices.Contains(supported, firstStep) {
-			continue
+		var declared bool
+		switch firstStep := address[0].String(); firstStep {
+		case "var", "local":
+			if len(address) > 2 {
+				// We temporarily ignore references with more than 2 segments
+				// as these indicate references to complex types
+				// which we do not fully support yet.
+				// TODO: revisit as part of https://github.com/hashicorp/terraform-ls/issues/653
+				continue
+			}
+			_, declared = pathCtx.ReferenceTargets.Match(localOrigin)
+		case "data", "ephemeral":
+			if len(address) >= 3 && scopedDataSources[address.FirstSteps(3).String()] {
+				// data sources scoped to check blocks are not targetable
+				continue
+			}
+			declared = isDeclaredObject(pathCtx.ReferenceTargets, address, 3)
+		case "module":
+			declared = isDeclaredObject(pathCtx.ReferenceTargets, address, 2)
+		case "self":
+			declared = isDeclaredSelf(pathCtx.ReferenceTargets, address, localOrigin.Range)
+		case "count", "each":
+			declared = isDeclaredLocally(pathCtx.ReferenceTargets, address, localOrigin.Range)
+		default:
+			if !resourceTypes[firstStep] {
+				continue
+			}
+			declared = isDeclaredObject(pathCtx.ReferenceTargets, address, 2)
 		}
 
-		_, ok = pathCtx.ReferenceTargets.Match(localOrigin)
-		if !ok {
+		if !declared {
 			// target not found
 			fileName := origin.OriginRange().Filename
 			d := &hcl.Diagnostic{
@@ -73,3 +104,208 @@ func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang
 
 	return diagsMap
 }
+
+// isDeclaredObject checks whether the resource, data source or module
+// call the address points to is declared and whether the rest of
+// the address is valid for it, i.e. whether it matches the schema
+// of the resource or data source, or outputs of the module.
+//
+// The address is considered valid whenever the schema is unknown,
+// such as when the provider or module is not installed.
+func isDeclaredObject(targets reference.Targets, address lang.Address, length int) bool {
+	if len(address) < length {
+		return true
+	}
+	objectAddr := address.FirstSteps(uint(length))
+
+	found := false
+	typedTargets := make(reference.Targets, 0)
+	for _, target := range targets {
+		if !target.Addr.Equals(objectAddr) {
+			continue
+		}
+		found = true
+		if target.Type != cty.NilType {
+			typedTargets = append(typedTargets, target)
+		}
+	}
+	if !found {
+		return false
+	}
+	if len(typedTargets) == 0 {
+		return true
+	}
+
+	attrAddr := address
+	if len(address) > length {
+		if _, ok := address[length].(lang.IndexStep); ok {
+			// skip instance key of resources
+			// and modules with count or for_each
+			attrAddr = append(objectAddr.Copy(), address[length+1:]...)
+		}
+	}
+
+	for _, target := range typedTargets {
+		if isValidPath(target, attrAddr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeclaredSelf checks the address of a self reference against
+// the block it is declared in, if its schema is known
+func isDeclaredSelf(targets reference.Targets, address lang.Address, rng hcl.Range) bool {
+	for _, target := range targets {
+		if !target.LocalAddr.Equals(address.FirstSteps(1)) || !isTargetableFrom(target, rng) {
+			continue
+		}
+		if target.Type == cty.NilType {
+			return true
+		}
+		attrAddr := append(target.Addr.Copy(), address[1:]...)
+		return isValidPath(target, attrAddr)
+	}
+	return true
+}
+
+// isDeclaredLocally checks references to count and each,
+// which are only available within blocks where count
+// or for_each is declared
+func isDeclaredLocally(targets reference.Targets, address lang.Address, rng hcl.Range) bool {
+	for _, target := range targets {
+		if len(target.LocalAddr) == 0 || len(target.LocalAddr) > len(address) ||
+			!isTargetableFrom(target, rng) {
+			continue
+		}
+		if !target.LocalAddr.Equals(address.FirstSteps(uint(len(target.LocalAddr)))) {
+			continue
+		}
+		if isValidType(target.Type, address[len(target.LocalAddr):]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTargetableFrom(target reference.Target, rng hcl.Range) bool {
+	return target.TargetableFromRangePtr != nil && target.TargetableFromRangePtr.Overlaps(rng)
+}
+
+// isValidPath checks whether the address can be traversed within
+// the given target, using its nested targets, as far as available,
+// and its type otherwise
+func isValidPath(target reference.Target, address lang.Address) bool {
+	for _, nestedTarget := range target.NestedTargets {
+		if len(nestedTarget.Addr) <= len(target.Addr) || len(nestedTarget.Addr) > len(address) {
+			continue
+		}
+		if nestedTarget.Addr.Equals(address.FirstSteps(uint(len(nestedTarget.Addr)))) {
+			return isValidPath(nestedTarget, address)
+		}
+	}
+
+	return isValidType(target.Type, address[len(target.Addr):])
+}
+
+// isValidType checks whether the steps can be traversed within
+// a value of the given type, e.g. whether an attribute exists
+// in an object. Unknown types are considered valid.
+func isValidType(typ cty.Type, steps lang.Address) bool {
+	for _, step := range steps {
+		switch {
+		case typ == cty.NilType || typ == cty.DynamicPseudoType:
+			return true
+		case typ.IsObjectType():
+			name, ok := ihcl.AddressStepName(step)
+			if !ok || !typ.HasAttribute(name) {
+				return false
+			}
+			typ = typ.AttributeType(name)
+		case typ.IsMapType():
+			typ = typ.ElementType()
+		case typ.IsListType() || typ.IsSetType():
+			if _, ok := step.(lang.IndexStep); !ok {
+				return false
+			}
+			typ = typ.ElementType()
+		case typ.IsTupleType():
+			indexStep, ok := step.(lang.IndexStep)
+			if !ok {
+				return false
+			}
+			i, ok := tupleIndex(indexStep.Key)
+			if !ok || i >= typ.Length() {
+				// out of range indexes are not
+				// a matter of missing declaration
+				return true
+			}
+			typ = typ.TupleElementType(i)
+		default:
+			// primitive types cannot be traversed
+			return false
+		}
+	}
+	return true
+}
+
+func tupleIndex(key cty.Value) (int, bool) {
+	if key.Type() != cty.Number || !key.IsKnown() || key.IsNull() {
+		return 0, false
+	}
+	i, acc := key.AsBigFloat().Int64()
+	if acc != big.Exact || i < 0 {
+		return 0, false
+	}
+	return int(i), true
+}
+
+// scopedDataSourceAddresses returns addresses of data sources
+// nested in check blocks, which are not reference targets
+func scopedDataSourceAddresses(files map[string]*hcl.File) map[string]bool {
+	addresses := make(map[string]bool)
+	for _, f := range files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "check" {
+				continue
+			}
+			for _, nestedBlock := range block.Body.Blocks {
+				if nestedBlock.Type == "data" && len(nestedBlock.Labels) == 2 {
+					addresses[fmt.Sprintf("data.%s.%s", nestedBlock.Labels[0], nestedBlock.Labels[1])] = true
+				}
+			}
+		}
+	}
+	return addresses
+}
+
+// refactoringAddressRanges returns ranges of the addresses
+// in moved, import and removed blocks
+func refactoringAddressRanges(files map[string]*hcl.File) []hcl.Range {
+	ranges := make([]hcl.Range, 0)
+	for _, f := range files {
+		content, _, _ := f.Body.PartialContent(refactoringFileSchema)
+		for _, block := range content.Blocks {
+			blockContent, _, _ := block.Body.PartialContent(refactoringBlockSchema)
+			for _, name := range []string{"from", "to"} {
+				if attr, ok := blockContent.Attributes[name]; ok {
+					ranges = append(ranges, attr.Expr.Range())
+				}
+			}
+		}
+	}
+	return ranges
+}
+
+func withinAnyRange(ranges []hcl.Range, rng hcl.Range) bool {
+	for _, r := range ranges {
+		if r.Filename == rng.Filename && r.ContainsOffset(rng.Start.Byte) {
+			return true
+		}
+	}
+	return false
+}
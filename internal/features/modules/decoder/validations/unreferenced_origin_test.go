@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestUnreferencedOrigins(t *testing.T) {
@@ -185,3 +187,143 @@ func TestUnreferencedOrigins(t *testing.T) {
 		})
 	}
 }
+
+func TestUnreferencedOrigins_objects(t *testing.T) {
+	instanceAddr := lang.Address{
+		lang.RootStep{Name: "aws_instance"},
+		lang.AttrStep{Name: "web"},
+	}
+	instanceType := cty.Object(map[string]cty.Type{
+		"id":         cty.String,
+		"private_ip": cty.String,
+		"tags":       cty.Map(cty.String),
+		"ebs_block_device": cty.List(cty.Object(map[string]cty.Type{
+			"volume_id": cty.String,
+		})),
+	})
+	bodyRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+		End:      hcl.Pos{Line: 10, Column: 1, Byte: 200},
+	}
+	targets := reference.Targets{
+		{
+			Addr:    instanceAddr,
+			ScopeId: lang.ScopeId("resource"),
+		},
+		{
+			Addr:                   instanceAddr,
+			LocalAddr:              lang.Address{lang.RootStep{Name: "self"}},
+			TargetableFromRangePtr: bodyRange.Ptr(),
+			ScopeId:                lang.ScopeId("resource"),
+			Type:                   instanceType,
+			NestedTargets: reference.Targets{
+				{
+					Addr: append(instanceAddr.Copy(), lang.AttrStep{Name: "id"}),
+					Type: cty.String,
+				},
+				{
+					Addr: append(instanceAddr.Copy(), lang.AttrStep{Name: "user_data"}),
+				},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_s3_bucket"},
+				lang.AttrStep{Name: "logs"},
+			},
+			ScopeId: lang.ScopeId("resource"),
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "module"},
+				lang.AttrStep{Name: "vpc"},
+			},
+			ScopeId: lang.ScopeId("module"),
+			Type: cty.Object(map[string]cty.Type{
+				"subnet_ids": cty.DynamicPseudoType,
+			}),
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "module"},
+				lang.AttrStep{Name: "remote"},
+			},
+			ScopeId: lang.ScopeId("module"),
+		},
+		{
+			LocalAddr: lang.Address{
+				lang.RootStep{Name: "count"},
+				lang.AttrStep{Name: "index"},
+			},
+			TargetableFromRangePtr: bodyRange.Ptr(),
+			Type:                   cty.Number,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		line    int
+		want    []string
+	}{
+		{"resource attribute", "aws_instance.web.private_ip", 20, []string{}},
+		{"resource attribute typo", "aws_instance.web.privte_ip", 20, []string{`No declaration found for "aws_instance.web.privte_ip"`}},
+		{"resource instance attribute", "aws_instance.web[0].private_ip", 20, []string{}},
+		{"attribute without type", "aws_instance.web.user_data.foo", 20, []string{}},
+		{"map element", `aws_instance.web.tags["Name"]`, 20, []string{}},
+		{"nested block", "aws_instance.web.ebs_block_device[0].volume_id", 20, []string{}},
+		{"nested block typo", "aws_instance.web.ebs_block_device[0].volume", 20, []string{`No declaration found for "aws_instance.web.ebs_block_device[0].volume"`}},
+		{"nested block without index", "aws_instance.web.ebs_block_device.volume_id", 20, []string{`No declaration found for "aws_instance.web.ebs_block_device.volume_id"`}},
+		{"primitive attribute traversal", "aws_instance.web.id.foo", 20, []string{`No declaration found for "aws_instance.web.id.foo"`}},
+		{"undeclared resource", "aws_instance.db.id", 20, []string{`No declaration found for "aws_instance.db.id"`}},
+		{"unknown schema", "aws_s3_bucket.logs.anything", 20, []string{}},
+		{"unknown resource type", "foo.bar", 20, []string{}},
+		{"module output", "module.vpc.subnet_ids[0]", 20, []string{}},
+		{"module output typo", "module.vpc.subnet_idz", 20, []string{`No declaration found for "module.vpc.subnet_idz"`}},
+		{"module without metadata", "module.remote.anything", 20, []string{}},
+		{"undeclared module", "module.db.endpoint", 20, []string{`No declaration found for "module.db.endpoint"`}},
+		{"undeclared data source", "data.aws_ami.ubuntu.id", 20, []string{`No declaration found for "data.aws_ami.ubuntu.id"`}},
+		{"self attribute", "self.private_ip", 2, []string{}},
+		{"self attribute typo", "self.privte_ip", 2, []string{`No declaration found for "self.privte_ip"`}},
+		{"count index", "count.index", 2, []string{}},
+		{"count index outside of block", "count.index", 20, []string{`No declaration found for "count.index"`}},
+		{"count typo", "count.idx", 2, []string{`No declaration found for "count.idx"`}},
+		{"each outside of block", "each.key", 2, []string{`No declaration found for "each.key"`}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.name), func(t *testing.T) {
+			traversal, diags := hclsyntax.ParseTraversalAbs([]byte(tt.address), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			addr, err := lang.TraversalToAddress(traversal)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pathCtx := &decoder.PathContext{
+				ReferenceTargets: targets,
+				ReferenceOrigins: reference.Origins{
+					reference.LocalOrigin{
+						Addr: addr,
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: tt.line, Column: 1, Byte: tt.line * 20},
+							End:      hcl.Pos{Line: tt.line, Column: 5, Byte: tt.line*20 + 5},
+						},
+					},
+				},
+			}
+
+			got := make([]string, 0)
+			for _, diag := range UnreferencedOrigins(context.Background(), pathCtx)["test.tf"] {
+				got = append(got, diag.Summary)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+var cidrHostFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "hostnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+
+		hostNum := args[1].AsBigFloat()
+		num, acc := hostNum.Int(nil)
+		if acc != big.Exact {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "hostnum must be a whole number")
+		}
+
+		size := network.size(network.prefixLen)
+		if num.Sign() < 0 {
+			// negative numbers count backwards from the end of the range
+			num.Add(num, size)
+		}
+		if num.Sign() < 0 || num.Cmp(size) >= 0 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "prefix of %d bits cannot accommodate a host numbered %s", network.prefixLen, hostNum.Text('f', -1))
+		}
+
+		return cty.StringVal(network.ip(new(big.Int).Add(network.start, num)).String()), nil
+	},
+})
+
+var cidrNetmaskFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		if network.bits != 32 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "only IPv4 prefixes have a netmask")
+		}
+
+		return cty.StringVal(net.IP(net.CIDRMask(network.prefixLen, network.bits)).String()), nil
+	},
+})
+
+var cidrSubnetFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "newbits", Type: cty.Number},
+		{Name: "netnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+
+		var newBits int
+		if err := gocty.FromCtyValue(args[1], &newBits); err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+		prefixLen := network.prefixLen + newBits
+		if newBits < 0 || prefixLen > network.bits {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "insufficient address space to extend prefix of %d by %d", network.prefixLen, newBits)
+		}
+
+		num, acc := args[2].AsBigFloat().Int(nil)
+		if acc != big.Exact || num.Sign() < 0 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(2, "netnum must be a whole non-negative number")
+		}
+		if num.Cmp(new(big.Int).Lsh(big.NewInt(1), uint(newBits))) >= 0 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(2, "prefix extension of %d does not accommodate a subnet numbered %s", newBits, num)
+		}
+
+		start := new(big.Int).Add(network.start, num.Mul(num, network.size(prefixLen)))
+		return cty.StringVal(network.subnet(start, prefixLen)), nil
+	},
+})
+
+var cidrSubnetsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+	},
+	VarParam: &function.Parameter{
+		Name: "newbits",
+		Type: cty.Number,
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		network, err := parseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		if len(args) == 1 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+
+		end := new(big.Int).Add(network.start, network.size(network.prefixLen))
+		subnets := make([]cty.Value, 0, len(args)-1)
+
+		// subnets are allocated consecutively, each aligned to its own size
+		var current *big.Int
+		var currentLen int
+		for i, arg := range args[1:] {
+			var newBits int
+			if err := gocty.FromCtyValue(arg, &newBits); err != nil {
+				return cty.UnknownVal(retType), function.NewArgError(i+1, err)
+			}
+			prefixLen := network.prefixLen + newBits
+			if newBits < 1 || prefixLen > network.bits {
+				return cty.UnknownVal(retType), function.NewArgErrorf(i+1, "would extend prefix to %d bits, which is too long for an IPv%d address", prefixLen, network.version())
+			}
+
+			next := new(big.Int).Set(network.start)
+			if current != nil {
+				size := network.size(prefixLen)
+				last := new(big.Int).Add(current, network.size(currentLen))
+				last.Sub(last, big.NewInt(1))
+				next = last.Div(last, size)
+				next.Mul(next, size)
+				next.Add(next, size)
+			}
+			if new(big.Int).Add(next, network.size(prefixLen)).Cmp(end) > 0 {
+				return cty.UnknownVal(retType), function.NewArgErrorf(i+1, "not enough remaining address space for a subnet with a prefix of %d bits after %s", prefixLen, subnets[len(subnets)-1].AsString())
+			}
+
+			subnets = append(subnets, cty.StringVal(network.subnet(next, prefixLen)))
+			current, currentLen = next, prefixLen
+		}
+
+		return cty.ListVal(subnets), nil
+	},
+})
+
+// ipNetwork represents a network with its addresses as integers
+type ipNetwork struct {
+	start     *big.Int
+	prefixLen int
+	bits      int
+}
+
+func parseCIDR(prefix string) (ipNetwork, error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return ipNetwork{}, fmt.Errorf("invalid CIDR expression: %s", err)
+	}
+	prefixLen, bits := network.Mask.Size()
+	return ipNetwork{
+		start:     new(big.Int).SetBytes(network.IP),
+		prefixLen: prefixLen,
+		bits:      bits,
+	}, nil
+}
+
+// size returns the number of addresses within a prefix of the given length
+func (n ipNetwork) size(prefixLen int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(n.bits-prefixLen))
+}
+
+func (n ipNetwork) ip(num *big.Int) net.IP {
+	b := num.Bytes()
+	ip := make(net.IP, n.bits/8)
+	copy(ip[len(ip)-len(b):], b)
+	return ip
+}
+
+func (n ipNetwork) subnet(start *big.Int, prefixLen int) string {
+	return fmt.Sprintf("%s/%d", n.ip(start), prefixLen)
+}
+
+func (n ipNetwork) version() int {
+	if n.bits == 32 {
+		return 4
+	}
+	return 6
+}
@@ -0,0 +1,298 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package eval statically evaluates expressions within a module,
+// i.e. without any state, plan or provider involved.
+package eval
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// SensitiveMark marks values derived from sensitive variables
+const SensitiveMark = "sensitive"
+
+var moduleSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "locals"},
+	},
+}
+
+var variableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "type"},
+		{Name: "default"},
+		{Name: "sensitive"},
+	},
+}
+
+// Evaluator evaluates expressions in the context of a module.
+//
+// Input variables are resolved from variable files loaded automatically
+// by Terraform and from their defaults. Local values are resolved
+// recursively. Anything else, such as attributes of resources,
+// is considered unknown.
+type Evaluator struct {
+	variables map[string]*hcl.Block
+	varValues map[string]hcl.Expression
+	locals    map[string]*hcl.Attribute
+	functions map[string]function.Function
+
+	variableCache map[string]cty.Value
+	localCache    map[string]cty.Value
+	evaluating    map[string]bool
+}
+
+// NewEvaluator returns an evaluator for the module consisting of the
+// given files, using functions of the given Terraform version.
+// Only the variable files which Terraform loads automatically are
+// considered, i.e. terraform.tfvars and *.auto.tfvars, along
+// with their JSON variants.
+func NewEvaluator(files, varsFiles map[string]*hcl.File, tfVersion *version.Version) *Evaluator {
+	e := &Evaluator{
+		variables:     make(map[string]*hcl.Block),
+		varValues:     make(map[string]hcl.Expression),
+		locals:        make(map[string]*hcl.Attribute),
+		functions:     Functions(tfVersion),
+		variableCache: make(map[string]cty.Value),
+		localCache:    make(map[string]cty.Value),
+		evaluating:    make(map[string]bool),
+	}
+
+	for _, filename := range sortedFilenames(files) {
+		content, _, _ := files[filename].Body.PartialContent(moduleSchema)
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "variable":
+				e.variables[block.Labels[0]] = block
+			case "locals":
+				attrs, _ := block.Body.JustAttributes()
+				for name, attr := range attrs {
+					e.locals[name] = attr
+				}
+			}
+		}
+	}
+
+	for _, filename := range autoLoadedFilenames(varsFiles) {
+		attrs, _ := varsFiles[filename].Body.JustAttributes()
+		for name, attr := range attrs {
+			// later files override earlier ones
+			e.varValues[name] = attr.Expr
+		}
+	}
+
+	return e
+}
+
+// Evaluate returns the value of the expression, which is unknown
+// if it depends on anything which cannot be evaluated statically
+func (e *Evaluator) Evaluate(expr hcl.Expression) (cty.Value, hcl.Diagnostics) {
+	return expr.Value(e.evalContext(expr))
+}
+
+// EvaluateString parses and evaluates the given expression
+func (e *Evaluator) EvaluateString(src string) (cty.Value, hcl.Diagnostics) {
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "<expression>", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+	return e.Evaluate(expr)
+}
+
+// Local returns the value of the local value with the given name
+func (e *Evaluator) Local(name string) (cty.Value, bool) {
+	if _, ok := e.locals[name]; !ok {
+		return cty.NilVal, false
+	}
+	return e.local(name), true
+}
+
+// Variable returns the value of the input variable with the given name
+func (e *Evaluator) Variable(name string) (cty.Value, bool) {
+	if _, ok := e.variables[name]; !ok {
+		return cty.NilVal, false
+	}
+	return e.variable(name), true
+}
+
+func (e *Evaluator) evalContext(expr hcl.Expression) *hcl.EvalContext {
+	vars := make(map[string]cty.Value)
+	variables := make(map[string]cty.Value)
+	locals := make(map[string]cty.Value)
+
+	for _, traversal := range expr.Variables() {
+		root := traversal.RootName()
+		switch root {
+		case "var", "local":
+			if len(traversal) < 2 {
+				continue
+			}
+			step, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				continue
+			}
+			if root == "var" {
+				if _, ok := e.variables[step.Name]; ok {
+					variables[step.Name] = e.variable(step.Name)
+				}
+				continue
+			}
+			if _, ok := e.locals[step.Name]; ok {
+				locals[step.Name] = e.local(step.Name)
+			}
+		default:
+			// resources, data sources, module outputs, path,
+			// count, each etc. are only known at runtime
+			vars[root] = cty.DynamicVal
+		}
+	}
+	vars["var"] = cty.ObjectVal(variables)
+	vars["local"] = cty.ObjectVal(locals)
+
+	functions := e.functions
+	if names := providerFunctionNames(expr); len(names) > 0 {
+		functions = make(map[string]function.Function, len(e.functions)+len(names))
+		for name, fn := range e.functions {
+			functions[name] = fn
+		}
+		for _, name := range names {
+			functions[name] = unknownFunction
+		}
+	}
+
+	return &hcl.EvalContext{
+		Variables: vars,
+		Functions: functions,
+	}
+}
+
+func (e *Evaluator) local(name string) cty.Value {
+	if val, ok := e.localCache[name]; ok {
+		return val
+	}
+	key := "local." + name
+	if e.evaluating[key] {
+		// self-referencing local values are invalid
+		return cty.DynamicVal
+	}
+	e.evaluating[key] = true
+	defer delete(e.evaluating, key)
+
+	val, diags := e.Evaluate(e.locals[name].Expr)
+	if diags.HasErrors() {
+		val = cty.DynamicVal
+	}
+	e.localCache[name] = val
+	return val
+}
+
+func (e *Evaluator) variable(name string) cty.Value {
+	if val, ok := e.variableCache[name]; ok {
+		return val
+	}
+
+	content, _, _ := e.variables[name].Body.PartialContent(variableSchema)
+
+	typ := cty.DynamicPseudoType
+	var defaults *typeexpr.Defaults
+	if attr, ok := content.Attributes["type"]; ok {
+		ty, tyDefaults, diags := typeexpr.TypeConstraintWithDefaults(attr.Expr)
+		if !diags.HasErrors() {
+			typ = ty
+			defaults = tyDefaults
+		}
+	}
+
+	val := cty.UnknownVal(typ)
+	if expr, ok := e.varValues[name]; ok {
+		val = literalValue(expr, val)
+	} else if attr, ok := content.Attributes["default"]; ok {
+		val = literalValue(attr.Expr, val)
+	}
+
+	if defaults != nil {
+		val = defaults.Apply(val)
+	}
+	if converted, err := convert.Convert(val, typ); err == nil {
+		val = converted
+	} else {
+		val = cty.UnknownVal(typ)
+	}
+
+	if attr, ok := content.Attributes["sensitive"]; ok {
+		sensitive, diags := attr.Expr.Value(nil)
+		if !diags.HasErrors() && sensitive.Type() == cty.Bool && sensitive.IsKnown() && sensitive.True() {
+			val = val.Mark(SensitiveMark)
+		}
+	}
+
+	e.variableCache[name] = val
+	return val
+}
+
+// literalValue returns the value of an expression which
+// cannot refer to anything, such as a variable default
+func literalValue(expr hcl.Expression, fallback cty.Value) cty.Value {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return fallback
+	}
+	return val
+}
+
+// providerFunctionNames returns names of provider-defined
+// functions called within the expression
+func providerFunctionNames(expr hcl.Expression) []string {
+	syntaxExpr, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0)
+	hclsyntax.VisitAll(syntaxExpr, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok && strings.Contains(call.Name, "::") {
+			names = append(names, call.Name)
+		}
+		return nil
+	})
+	return names
+}
+
+// autoLoadedFilenames returns names of variable files loaded
+// automatically by Terraform, in the order they are loaded
+func autoLoadedFilenames(varsFiles map[string]*hcl.File) []string {
+	filenames := make([]string, 0)
+	autoFilenames := make([]string, 0)
+	for filename := range varsFiles {
+		name := path.Base(filename)
+		switch {
+		case name == "terraform.tfvars" || name == "terraform.tfvars.json":
+			filenames = append(filenames, filename)
+		case strings.HasSuffix(name, ".auto.tfvars") || strings.HasSuffix(name, ".auto.tfvars.json"):
+			autoFilenames = append(autoFilenames, filename)
+		}
+	}
+	sort.Strings(filenames)
+	sort.Strings(autoFilenames)
+	return append(filenames, autoFilenames...)
+}
+
+func sortedFilenames(files map[string]*hcl.File) []string {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
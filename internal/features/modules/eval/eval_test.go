@@ -0,0 +1,111 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func TestEvaluator(t *testing.T) {
+	files := parseFiles(t, map[string]string{
+		"main.tf": `variable "cidr" {
+  default = "10.0.0.0/16"
+}
+
+variable "env" {
+  type = string
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "password" {
+  default   = "secret"
+  sensitive = true
+}
+
+locals {
+  subnets = [for i in range(2) : cidrsubnet(var.cidr, 8, i)]
+  tags    = merge(var.tags, { Env = var.env })
+  name    = format("%s-%s", var.env, local.suffix)
+  suffix  = "app"
+  id      = aws_instance.web.id
+  label   = "${local.name}-${local.id}"
+  loop    = local.loop
+  secret  = upper(var.password)
+}
+`,
+	})
+	varsFiles := parseFiles(t, map[string]string{
+		"terraform.tfvars":      `env = "dev"`,
+		"prod.auto.tfvars":      `env = "prod"`,
+		"staging.tfvars":        `env = "staging"`,
+		"tags.auto.tfvars.json": `{"tags": {"Team": "core"}}`,
+	})
+
+	e := NewEvaluator(files, varsFiles, nil)
+
+	tests := []struct {
+		expr  string
+		want  string
+		known bool
+	}{
+		{`local.subnets`, `["10.0.0.0/24", "10.0.1.0/24"]`, true},
+		{`local.tags`, `{
+  Env  = "prod"
+  Team = "core"
+}`, true},
+		{`local.name`, `"prod-app"`, true},
+		{`local.label`, ``, false},
+		{`local.loop`, ``, false},
+		{`local.secret`, `(sensitive value)`, true},
+		{`length(local.subnets) * 2`, `4`, true},
+		{`upper(local.suffix)`, `"APP"`, true},
+	}
+	for _, tt := range tests {
+		val, diags := e.EvaluateString(tt.expr)
+		if diags.HasErrors() {
+			t.Fatalf("%s: %s", tt.expr, diags)
+		}
+		got, ok := FormatValue(val)
+		if ok != tt.known {
+			t.Fatalf("%s: expected known to be %t, got %#v", tt.expr, tt.known, val)
+		}
+		if got != tt.want {
+			t.Fatalf("%s: expected %s, got %s", tt.expr, tt.want, got)
+		}
+	}
+
+	_, diags := e.EvaluateString(`var.undeclared`)
+	if !diags.HasErrors() {
+		t.Fatal("expected error for undeclared variable")
+	}
+}
+
+func parseFiles(t *testing.T, cfgs map[string]string) map[string]*hcl.File {
+	t.Helper()
+
+	p := hclparse.NewParser()
+	files := make(map[string]*hcl.File)
+	for filename, cfg := range cfgs {
+		var f *hcl.File
+		var diags hcl.Diagnostics
+		if strings.HasSuffix(filename, ".json") {
+			f, diags = p.ParseJSON([]byte(cfg), filename)
+		} else {
+			f, diags = p.ParseHCL([]byte(cfg), filename)
+		}
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		files[filename] = f
+	}
+	return files
+}
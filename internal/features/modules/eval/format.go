@@ -0,0 +1,43 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const sensitivePlaceholder = "(sensitive value)"
+
+// maskedValue stands in for marked values nested within a value
+// which is being formatted, before it is replaced by the placeholder
+const maskedValue = "__terraform_ls_sensitive_value__"
+
+// FormatValue returns the value in HCL syntax, or false if the value
+// is not fully known. Sensitive values, including those nested within
+// collections and objects, are represented by a placeholder.
+func FormatValue(val cty.Value) (string, bool) {
+	if val.IsMarked() {
+		return sensitivePlaceholder, true
+	}
+
+	if val.ContainsMarked() {
+		val, _ = cty.Transform(val, func(p cty.Path, v cty.Value) (cty.Value, error) {
+			if v.IsMarked() {
+				return cty.StringVal(maskedValue), nil
+			}
+			return v, nil
+		})
+	}
+	if !val.IsWhollyKnown() {
+		return "", false
+	}
+
+	src := hclwrite.Format(hclwrite.TokensForValue(val).Bytes())
+	masked := string(hclwrite.TokensForValue(cty.StringVal(maskedValue)).Bytes())
+	formatted := strings.ReplaceAll(string(src), masked, sensitivePlaceholder)
+	return strings.TrimSpace(formatted), true
+}
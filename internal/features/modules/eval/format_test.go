@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFormatValue(t *testing.T) {
+	testCases := []struct {
+		val           cty.Value
+		expectedValue string
+		expectedKnown bool
+	}{
+		{cty.StringVal("foo"), `"foo"`, true},
+		{cty.StringVal("foo").Mark(SensitiveMark), `(sensitive value)`, true},
+		{cty.UnknownVal(cty.String), ``, false},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"id":       cty.StringVal("db-1"),
+				"password": cty.StringVal("s3cr3t").Mark(SensitiveMark),
+				"ports":    cty.ListVal([]cty.Value{cty.NumberIntVal(5432).Mark(SensitiveMark)}),
+			}),
+			`{
+  id       = "db-1"
+  password = (sensitive value)
+  ports    = [(sensitive value)]
+}`,
+			true,
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"id":       cty.UnknownVal(cty.String),
+				"password": cty.StringVal("s3cr3t").Mark(SensitiveMark),
+			}),
+			``,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expectedValue, func(t *testing.T) {
+			value, known := FormatValue(tc.val)
+			if known != tc.expectedKnown {
+				t.Fatalf("expected known: %t, given: %t", tc.expectedKnown, known)
+			}
+			if value != tc.expectedValue {
+				t.Fatalf("unexpected value:\nexpected: %s\ngiven: %s", tc.expectedValue, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,571 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// implementations maps names of Terraform functions
+// to their implementation, where one is available.
+// Functions of cty's stdlib are only used where Terraform uses them
+// too, as some share a name with a Terraform function of different
+// semantics, such as index.
+//
+// Functions which depend on anything outside of the configuration,
+// such as files, time or randomness, are intentionally left out.
+var implementations = map[string]function.Function{
+	"abs":             stdlib.AbsoluteFunc,
+	"alltrue":         allTrueFunc,
+	"anytrue":         anyTrueFunc,
+	"base64decode":    base64DecodeFunc,
+	"base64encode":    stringFunc(func(s string) (string, error) { return base64.StdEncoding.EncodeToString([]byte(s)), nil }),
+	"can":             tryfunc.CanFunc,
+	"ceil":            stdlib.CeilFunc,
+	"chomp":           stdlib.ChompFunc,
+	"chunklist":       stdlib.ChunklistFunc,
+	"cidrhost":        cidrHostFunc,
+	"cidrnetmask":     cidrNetmaskFunc,
+	"cidrsubnet":      cidrSubnetFunc,
+	"cidrsubnets":     cidrSubnetsFunc,
+	"coalesce":        coalesceFunc,
+	"coalescelist":    stdlib.CoalesceListFunc,
+	"compact":         stdlib.CompactFunc,
+	"concat":          stdlib.ConcatFunc,
+	"contains":        stdlib.ContainsFunc,
+	"csvdecode":       stdlib.CSVDecodeFunc,
+	"distinct":        stdlib.DistinctFunc,
+	"element":         stdlib.ElementFunc,
+	"endswith":        stringPredicateFunc(strings.HasSuffix),
+	"flatten":         stdlib.FlattenFunc,
+	"floor":           stdlib.FloorFunc,
+	"format":          stdlib.FormatFunc,
+	"formatdate":      stdlib.FormatDateFunc,
+	"formatlist":      stdlib.FormatListFunc,
+	"indent":          stdlib.IndentFunc,
+	"index":           indexFunc,
+	"join":            stdlib.JoinFunc,
+	"jsondecode":      stdlib.JSONDecodeFunc,
+	"jsonencode":      stdlib.JSONEncodeFunc,
+	"keys":            stdlib.KeysFunc,
+	"length":          lengthFunc,
+	"log":             stdlib.LogFunc,
+	"lookup":          lookupFunc,
+	"lower":           stdlib.LowerFunc,
+	"max":             stdlib.MaxFunc,
+	"md5":             hashFunc(func(b []byte) []byte { h := md5.Sum(b); return h[:] }),
+	"merge":           stdlib.MergeFunc,
+	"min":             stdlib.MinFunc,
+	"nonsensitive":    nonsensitiveFunc,
+	"one":             oneFunc,
+	"parseint":        stdlib.ParseIntFunc,
+	"pow":             stdlib.PowFunc,
+	"range":           stdlib.RangeFunc,
+	"regex":           stdlib.RegexFunc,
+	"regexall":        stdlib.RegexAllFunc,
+	"replace":         replaceFunc,
+	"reverse":         stdlib.ReverseListFunc,
+	"sensitive":       sensitiveFunc,
+	"setintersection": stdlib.SetIntersectionFunc,
+	"setproduct":      stdlib.SetProductFunc,
+	"setsubtract":     stdlib.SetSubtractFunc,
+	"setunion":        stdlib.SetUnionFunc,
+	"sha1":            hashFunc(func(b []byte) []byte { h := sha1.Sum(b); return h[:] }),
+	"sha256":          hashFunc(func(b []byte) []byte { h := sha256.Sum256(b); return h[:] }),
+	"sha512":          hashFunc(func(b []byte) []byte { h := sha512.Sum512(b); return h[:] }),
+	"signum":          stdlib.SignumFunc,
+	"slice":           stdlib.SliceFunc,
+	"sort":            stdlib.SortFunc,
+	"split":           stdlib.SplitFunc,
+	"startswith":      stringPredicateFunc(strings.HasPrefix),
+	"strcontains":     stringPredicateFunc(strings.Contains),
+	"strrev":          stdlib.ReverseFunc,
+	"substr":          stdlib.SubstrFunc,
+	"sum":             sumFunc,
+	"timeadd":         stdlib.TimeAddFunc,
+	"title":           stdlib.TitleFunc,
+	"tobool":          conversionFunc(cty.Bool),
+	"tolist":          conversionFunc(cty.List(cty.DynamicPseudoType)),
+	"tomap":           conversionFunc(cty.Map(cty.DynamicPseudoType)),
+	"tonumber":        conversionFunc(cty.Number),
+	"toset":           conversionFunc(cty.Set(cty.DynamicPseudoType)),
+	"tostring":        conversionFunc(cty.String),
+	"trim":            stdlib.TrimFunc,
+	"trimprefix":      stdlib.TrimPrefixFunc,
+	"trimspace":       stdlib.TrimSpaceFunc,
+	"trimsuffix":      stdlib.TrimSuffixFunc,
+	"try":             tryfunc.TryFunc,
+	"upper":           stdlib.UpperFunc,
+	"urlencode":       stringFunc(func(s string) (string, error) { return url.QueryEscape(s), nil }),
+	"values":          stdlib.ValuesFunc,
+	"zipmap":          stdlib.ZipmapFunc,
+}
+
+// Functions returns functions available in the given Terraform version.
+//
+// Functions without an implementation return an unknown value,
+// so that expressions calling them evaluate to an unknown value
+// rather than to an error.
+func Functions(tfVersion *version.Version) map[string]function.Function {
+	signatures, err := tfschema.FunctionsForVersion(tfschema.ResolveVersion(tfVersion, nil))
+	if err != nil {
+		return implementations
+	}
+
+	functions := make(map[string]function.Function, len(signatures))
+	for name := range signatures {
+		if fn, ok := implementations[name]; ok {
+			functions[name] = fn
+			continue
+		}
+		functions[name] = unknownFunction
+	}
+	return functions
+}
+
+// Implementations returns the implementations of the named functions.
+// Functions without an implementation are left out.
+func Implementations(names ...string) map[string]function.Function {
+	functions := make(map[string]function.Function, len(names))
+	for _, name := range names {
+		if fn, ok := implementations[name]; ok {
+			functions[name] = fn
+		}
+	}
+	return functions
+}
+
+var unknownFunction = function.New(&function.Spec{
+	VarParam: &function.Parameter{
+		Name:             "args",
+		Type:             cty.DynamicPseudoType,
+		AllowUnknown:     true,
+		AllowNull:        true,
+		AllowDynamicType: true,
+		AllowMarked:      true,
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.DynamicVal, nil
+	},
+})
+
+func stringFunc(fn func(string) (string, error)) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "str", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			out, err := fn(args[0].AsString())
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+			return cty.StringVal(out), nil
+		},
+	})
+}
+
+func stringPredicateFunc(fn func(string, string) bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "str", Type: cty.String},
+			{Name: "substr", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.BoolVal(fn(args[0].AsString(), args[1].AsString())), nil
+		},
+	})
+}
+
+func hashFunc(fn func([]byte) []byte) function.Function {
+	return stringFunc(func(s string) (string, error) {
+		return hex.EncodeToString(fn([]byte(s))), nil
+	})
+}
+
+var base64DecodeFunc = stringFunc(func(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64 data %q", s)
+	}
+	if !utf8.Valid(b) {
+		return "", fmt.Errorf("the result of decoding the provided string is not valid UTF-8")
+	}
+	return string(b), nil
+})
+
+func conversionFunc(typ cty.Type) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name:             "v",
+				Type:             cty.DynamicPseudoType,
+				AllowNull:        true,
+				AllowDynamicType: true,
+			},
+		},
+		Type: func(args []cty.Value) (cty.Type, error) {
+			got := args[0].Type()
+			if got.Equals(typ) {
+				return got, nil
+			}
+			val, err := convert.Convert(cty.UnknownVal(got), typ)
+			if err != nil {
+				return cty.NilType, function.NewArgErrorf(0, "cannot convert %s to %s", got.FriendlyName(), typ.FriendlyNameForConstraint())
+			}
+			return val.Type(), nil
+		},
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			val, err := convert.Convert(args[0], retType)
+			if err != nil {
+				return cty.NilVal, function.NewArgError(0, err)
+			}
+			return val, nil
+		},
+	})
+}
+
+var lengthFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowDynamicType: true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		typ := args[0].Type()
+		switch {
+		case typ == cty.String || typ == cty.DynamicPseudoType ||
+			typ.IsCollectionType() || typ.IsTupleType() || typ.IsObjectType():
+			return cty.Number, nil
+		}
+		return cty.NilType, function.NewArgErrorf(0, "argument must be a string, a collection type, or a structural type")
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val, marks := args[0].Unmark()
+		if !val.IsKnown() {
+			return cty.UnknownVal(cty.Number).WithMarks(marks), nil
+		}
+		if val.Type() == cty.String {
+			length, err := stdlib.Strlen(val)
+			return length.WithMarks(marks), err
+		}
+		return val.Length().WithMarks(marks), nil
+	},
+})
+
+var replaceFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+		{Name: "substr", Type: cty.String},
+		{Name: "replace", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		str, substr, replace := args[0].AsString(), args[1].AsString(), args[2].AsString()
+
+		// a substring wrapped in forward slashes is a regular expression
+		if len(substr) > 1 && substr[0] == '/' && substr[len(substr)-1] == '/' {
+			re, err := regexp.Compile(substr[1 : len(substr)-1])
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+			return cty.StringVal(re.ReplaceAllString(str, replace)), nil
+		}
+		return cty.StringVal(strings.ReplaceAll(str, substr, replace)), nil
+	},
+})
+
+func boolListFunc(fn func([]cty.Value) bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "list", Type: cty.List(cty.Bool)},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			if !args[0].IsWhollyKnown() {
+				return cty.UnknownVal(cty.Bool), nil
+			}
+			return cty.BoolVal(fn(args[0].AsValueSlice())), nil
+		},
+	})
+}
+
+var allTrueFunc = boolListFunc(func(vals []cty.Value) bool {
+	for _, v := range vals {
+		if v.IsNull() || v.False() {
+			return false
+		}
+	}
+	return true
+})
+
+var anyTrueFunc = boolListFunc(func(vals []cty.Value) bool {
+	for _, v := range vals {
+		if !v.IsNull() && v.True() {
+			return true
+		}
+	}
+	return false
+})
+
+var sumFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.DynamicPseudoType},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		list := args[0]
+		typ := list.Type()
+		if !typ.IsListType() && !typ.IsSetType() && !typ.IsTupleType() {
+			return cty.NilVal, function.NewArgErrorf(0, "cannot sum %s", typ.FriendlyName())
+		}
+		if !list.IsWhollyKnown() {
+			return cty.UnknownVal(cty.Number), nil
+		}
+		if list.LengthInt() == 0 {
+			return cty.NilVal, function.NewArgErrorf(0, "cannot sum an empty list")
+		}
+
+		sum := cty.NumberIntVal(0)
+		for _, v := range list.AsValueSlice() {
+			num, err := convert.Convert(v, cty.Number)
+			if err != nil || num.IsNull() {
+				return cty.NilVal, function.NewArgErrorf(0, "argument must be list, set, or tuple of number values")
+			}
+			sum = sum.Add(num)
+		}
+		return sum, nil
+	},
+})
+
+var oneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.DynamicPseudoType},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		typ := args[0].Type()
+		switch {
+		case typ.IsListType() || typ.IsSetType():
+			return typ.ElementType(), nil
+		case typ.IsTupleType():
+			if types := typ.TupleElementTypes(); len(types) == 1 {
+				return types[0], nil
+			}
+			return cty.DynamicPseudoType, nil
+		}
+		return cty.NilType, function.NewArgErrorf(0, "must be a list, set, or tuple value with either zero or one elements")
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		list := args[0]
+		if !list.IsKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		switch list.LengthInt() {
+		case 0:
+			return cty.NullVal(retType), nil
+		case 1:
+			return list.AsValueSlice()[0], nil
+		}
+		return cty.NilVal, function.NewArgErrorf(0, "must be a list, set, or tuple value with either zero or one elements")
+	},
+})
+
+var sensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowUnknown:     true,
+			AllowNull:        true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return args[0].Mark(SensitiveMark), nil
+	},
+})
+
+var nonsensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowUnknown:     true,
+			AllowNull:        true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val, _ := args[0].UnmarkDeep()
+		return val, nil
+	},
+})
+
+// indexFunc returns the index of the first element of a list or tuple
+// equal to the given value, unlike cty's index, which looks up elements
+var indexFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "list", Type: cty.DynamicPseudoType},
+		{Name: "value", Type: cty.DynamicPseudoType},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		list := args[0]
+		if !list.Type().IsListType() && !list.Type().IsTupleType() {
+			return cty.NilVal, function.NewArgErrorf(0, "argument must be a list or tuple")
+		}
+		if !list.IsKnown() {
+			return cty.UnknownVal(cty.Number), nil
+		}
+		if list.LengthInt() == 0 {
+			return cty.NilVal, function.NewArgErrorf(0, "cannot search an empty list")
+		}
+
+		for it := list.ElementIterator(); it.Next(); {
+			i, v := it.Element()
+			eq, err := stdlib.Equal(v, args[1])
+			if err != nil {
+				return cty.NilVal, err
+			}
+			if !eq.IsKnown() {
+				return cty.UnknownVal(cty.Number), nil
+			}
+			if eq.True() {
+				return i, nil
+			}
+		}
+		return cty.NilVal, function.NewArgErrorf(1, "item not found")
+	},
+})
+
+// coalesceFunc returns the first argument which is neither null
+// nor an empty string, unlike cty's coalesce, which only skips nulls
+var coalesceFunc = function.New(&function.Spec{
+	VarParam: &function.Parameter{
+		Name:             "vals",
+		Type:             cty.DynamicPseudoType,
+		AllowUnknown:     true,
+		AllowDynamicType: true,
+		AllowNull:        true,
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		argTypes := make([]cty.Type, len(args))
+		for i, val := range args {
+			argTypes[i] = val.Type()
+		}
+		retType, _ := convert.UnifyUnsafe(argTypes)
+		if retType == cty.NilType {
+			return cty.NilType, fmt.Errorf("all arguments must have the same type")
+		}
+		return retType, nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		for _, arg := range args {
+			val, _ := convert.Convert(arg, retType)
+			if !val.IsKnown() {
+				return cty.UnknownVal(retType), nil
+			}
+			if val.IsNull() {
+				continue
+			}
+			if retType == cty.String && val.RawEquals(cty.StringVal("")) {
+				continue
+			}
+			return val, nil
+		}
+		return cty.NilVal, fmt.Errorf("no non-null, non-empty-string arguments")
+	},
+})
+
+// lookupFunc returns the element of a map or attribute of an object
+// with the given key, or the optional default if there is none.
+// Unlike in cty's lookup, the default may be omitted.
+var lookupFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "inputMap", Type: cty.DynamicPseudoType},
+		{Name: "key", Type: cty.String},
+	},
+	VarParam: &function.Parameter{
+		Name:             "default",
+		Type:             cty.DynamicPseudoType,
+		AllowUnknown:     true,
+		AllowDynamicType: true,
+		AllowNull:        true,
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		switch len(args) {
+		case 2:
+			typ := args[0].Type()
+			switch {
+			case typ.IsMapType():
+				return typ.ElementType(), nil
+			case typ.IsObjectType():
+				if !args[1].IsKnown() {
+					return cty.DynamicPseudoType, nil
+				}
+				key := args[1].AsString()
+				if !typ.HasAttribute(key) {
+					return cty.DynamicPseudoType, nil
+				}
+				return typ.AttributeType(key), nil
+			}
+			return cty.NilType, function.NewArgErrorf(0, "the first argument must be a map or an object")
+		case 3:
+			return stdlib.LookupFunc.ReturnTypeForValues(args)
+		}
+		return cty.NilType, fmt.Errorf("lookup() takes two or three arguments, got %d", len(args))
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) == 3 {
+			return stdlib.LookupFunc.Call(args)
+		}
+
+		inputMap, key := args[0], args[1]
+		if !inputMap.IsWhollyKnown() || !key.IsKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		name := key.AsString()
+		switch {
+		case inputMap.Type().IsObjectType():
+			if inputMap.Type().HasAttribute(name) {
+				return inputMap.GetAttr(name), nil
+			}
+		case inputMap.HasIndex(key).True():
+			return inputMap.Index(key), nil
+		}
+		return cty.NilVal, function.NewArgErrorf(1, "lookup failed to find key %q", name)
+	},
+})
@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package eval
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`cidrsubnet("10.0.0.0/16", 8, 2)`, `"10.0.2.0/24"`},
+		{`cidrsubnet("fd00:fd12:3456:7890::/56", 16, 162)`, `"fd00:fd12:3456:7800:a200::/72"`},
+		{`cidrhost("10.12.112.0/20", 268)`, `"10.12.113.12"`},
+		{`cidrhost("10.12.112.0/20", -2)`, `"10.12.127.254"`},
+		{`cidrhost("fd00:fd12:3456:7890:00a2::/72", 34)`, `"fd00:fd12:3456:7890::22"`},
+		{`cidrnetmask("172.16.0.0/12")`, `"255.240.0.0"`},
+		{`cidrsubnets("10.1.0.0/16", 4, 4, 8, 4)`, `["10.1.0.0/20", "10.1.16.0/20", "10.1.32.0/24", "10.1.48.0/20"]`},
+		{`length("abc")`, `3`},
+		{`length({ a = 1, b = 2 })`, `2`},
+		{`replace("1 + 2 + 3", "+", "-")`, `"1 - 2 - 3"`},
+		{`replace("hello world", "/w.*d/", "everybody")`, `"hello everybody"`},
+		{`tostring(1)`, `"1"`},
+		{`tolist(["a", "b"])`, `["a", "b"]`},
+		{`sum([1, 2, 3])`, `6`},
+		{`one(["a"])`, `"a"`},
+		{`alltrue([true, false])`, `false`},
+		{`startswith("hello", "he")`, `true`},
+		{`md5("hello")`, `"5d41402abc4b2a76b9719d911017c592"`},
+		{`base64decode(base64encode("hello"))`, `"hello"`},
+		{`try(tonumber("x"), 5)`, `5`},
+		{`abs(-3)`, `3`},
+		{`reverse(["a", "b"])`, `["b", "a"]`},
+		{`strrev("abc")`, `"cba"`},
+		{`index(["a", "b", "c"], "b")`, `1`},
+		{`coalesce("", "a")`, `"a"`},
+		{`coalesce(null, 1)`, `1`},
+		{`lookup({ a = "x" }, "a")`, `"x"`},
+		{`lookup({ a = "x" }, "b", "y")`, `"y"`},
+	}
+
+	functions := Functions(version.Must(version.NewVersion("1.9.0")))
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, tt.expr), func(t *testing.T) {
+			expr, diags := hclsyntax.ParseExpression([]byte(tt.expr), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			val, diags := expr.Value(&hcl.EvalContext{Functions: functions})
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			got, ok := FormatValue(val)
+			if !ok {
+				t.Fatalf("expected known value, got %#v", val)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFunctions_errors(t *testing.T) {
+	tests := []string{
+		`index(["a", "b"], "c")`,
+		`index({ a = "b" }, "b")`,
+		`coalesce("", null)`,
+		`lookup({ a = "x" }, "b")`,
+	}
+
+	functions := Functions(version.Must(version.NewVersion("1.9.0")))
+	for i, expr := range tests {
+		t.Run(fmt.Sprintf("%2d-%s", i, expr), func(t *testing.T) {
+			e, diags := hclsyntax.ParseExpression([]byte(expr), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			_, diags = e.Value(&hcl.EvalContext{Functions: functions})
+			if !diags.HasErrors() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestFunctions_version(t *testing.T) {
+	functions := Functions(version.Must(version.NewVersion("1.4.0")))
+	if _, ok := functions["strcontains"]; ok {
+		t.Fatal("expected strcontains not to be available in Terraform 1.4")
+	}
+	if _, ok := functions["startswith"]; !ok {
+		t.Fatal("expected startswith to be available in Terraform 1.4")
+	}
+
+	// functions without implementation evaluate to an unknown value
+	val, diags := hclsyntax.ParseExpression([]byte(`timestamp()`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	v, diags := val.Value(&hcl.EvalContext{Functions: functions})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if v.IsKnown() {
+		t.Fatalf("expected unknown value, got %#v", v)
+	}
+}
+
+func TestImplementations(t *testing.T) {
+	functions := Implementations("coalesce", "timestamp")
+	if _, ok := functions["timestamp"]; ok {
+		t.Fatal("expected timestamp without implementation to be left out")
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(`coalesce("", "a")`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	val, diags := expr.Value(&hcl.EvalContext{Functions: functions})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	got, _ := FormatValue(val)
+	if got != `"a"` {
+		t.Fatalf("expected %s, got %s", `"a"`, got)
+	}
+}
@@ -349,7 +349,7 @@ func (f *ModulesFeature) decodeModule(ctx context.Context, dir document.DirHandl
 				_, err = f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
 					Dir: dir,
 					Func: func(ctx context.Context) error {
-						return jobs.ReferenceValidation(ctx, f.Store, f.rootFeature, dir.Path())
+						return jobs.ReferenceValidation(ctx, f.fs, f.Store, f.rootFeature, dir.Path())
 					},
 					Type:        op.OpTypeReferenceValidation.String(),
 					DependsOn:   job.IDs{refOriginsId, refTargetsId},
@@ -393,7 +393,7 @@ func (f *ModulesFeature) decodeModule(ctx context.Context, dir document.DirHandl
 	_, err = f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
 		Dir: dir,
 		Func: func(ctx context.Context) error {
-			return jobs.GetModuleDataFromRegistry(ctx, f.registryClient,
+			return jobs.GetModuleDataFromRegistry(ctx, f.registryClient.ForEngine(f.Store.ModuleEngine(path)),
 				f.Store, f.stateStore.RegistryModules, path)
 		},
 		Priority:  job.LowPriority,
@@ -8,6 +8,8 @@ package hooks
 
 import (
 	"log"
+	"sync"
+	"time"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
@@ -19,4 +21,9 @@ type Hooks struct {
 	RegistryClient registry.Client
 	AlgoliaClient  *search.Client
 	Logger         *log.Logger
+
+	// algoliaFailedAt helps avoid blocking completion on repeated
+	// timeouts when the search service is unreachable
+	algoliaMu       sync.Mutex
+	algoliaFailedAt time.Time
 }
@@ -6,10 +6,12 @@ package hooks
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -18,7 +20,29 @@ type RegistryModule struct {
 	Description string `json:"description"`
 }
 
-const algoliaModuleIndex = "tf-registry:prod:modules"
+const (
+	algoliaModuleIndex = "tf-registry:prod:modules"
+
+	// algoliaBackoff represents how long we skip searching
+	// after a failed search request
+	algoliaBackoff = 1 * time.Minute
+)
+
+func (h *Hooks) isAlgoliaAvailable() bool {
+	h.algoliaMu.Lock()
+	defer h.algoliaMu.Unlock()
+	return h.algoliaFailedAt.IsZero() || time.Since(h.algoliaFailedAt) > algoliaBackoff
+}
+
+func (h *Hooks) setAlgoliaFailed(failed bool) {
+	h.algoliaMu.Lock()
+	defer h.algoliaMu.Unlock()
+	if failed {
+		h.algoliaFailedAt = time.Now()
+		return
+	}
+	h.algoliaFailedAt = time.Time{}
+}
 
 func (h *Hooks) fetchModulesFromAlgolia(ctx context.Context, term string) ([]RegistryModule, error) {
 	modules := make([]RegistryModule, 0)
@@ -54,15 +78,23 @@ func (h *Hooks) RegistryModuleSources(ctx context.Context, value cty.Value) ([]d
 		return candidates, nil
 	}
 
-	if h.AlgoliaClient == nil {
+	if h.AlgoliaClient == nil || !h.isAlgoliaAvailable() {
+		return candidates, nil
+	}
+
+	// The Algolia index only covers the Terraform Registry
+	if path, ok := decoder.PathFromContext(ctx); ok && h.ModStore != nil &&
+		h.ModStore.ModuleEngine(path.Path) == engine.OpenTofu {
 		return candidates, nil
 	}
 
 	modules, err := h.fetchModulesFromAlgolia(ctx, prefix)
 	if err != nil {
+		h.setAlgoliaFailed(true)
 		h.Logger.Printf("Error fetching modules from Algolia: %#v", err)
 		return candidates, err
 	}
+	h.setAlgoliaFailed(false)
 
 	for _, mod := range modules {
 		c := decoder.ExpressionCompletionCandidate(decoder.ExpressionCandidate{
@@ -21,6 +21,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/ast"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/zclconf/go-cty/cty"
@@ -290,3 +292,49 @@ func buildSearchClientMock(t *testing.T, handler http.HandlerFunc) *search.Clien
 
 	return searchClient
 }
+
+func TestHooks_RegistryModuleSourcesOpenTofu(t *testing.T) {
+	tmpDir := t.TempDir()
+	ctx := decoder.WithPath(context.Background(), lang.Path{
+		Path:       tmpDir,
+		LanguageID: "terraform",
+	})
+
+	s, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := state.NewModuleStore(s.ProviderSchemas, s.RegistryModules, s.ChangeStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Add(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.UpdateParsedModuleFiles(tmpDir, ast.ModFiles{
+		"main.tofu": &hcl.File{},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the Algolia index only covers the Terraform Registry
+	searchClient := buildSearchClientMock(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, fmt.Sprintf("unexpected request: %q", r.RequestURI), 400)
+	}))
+
+	h := &Hooks{
+		ModStore:      store,
+		AlgoliaClient: searchClient,
+		Logger:        log.New(io.Discard, "", 0),
+	}
+
+	candidates, err := h.RegistryModuleSources(ctx, cty.StringVal("aws"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 0 {
+		t.Fatalf("expected no candidates for OpenTofu module, given: %#v", candidates)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/registry"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
 	"github.com/zclconf/go-cty/cty"
@@ -65,8 +66,12 @@ func (h *Hooks) RegistryModuleVersions(ctx context.Context, value cty.Value) ([]
 		return candidates, nil
 	}
 
-	versions, err := h.RegistryClient.GetModuleVersions(ctx, registryAddr)
+	client := h.RegistryClient.ForEngine(h.ModStore.ModuleEngine(path.Path))
+	versions, err := client.GetModuleVersions(ctx, registryAddr)
 	if err != nil {
+		if errors.Is(err, registry.ErrOffline) {
+			return candidates, nil
+		}
 		return candidates, err
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/job"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 )
@@ -38,7 +39,8 @@ func ParseModuleConfiguration(ctx context.Context, fs ReadOnlyFS, modStore *stat
 	var diags ast.ModDiags
 	rpcContext := lsctx.DocumentContext(ctx)
 	// Only parse the file that's being changed/opened, unless this is 1st-time parsing
-	if mod.ModuleDiagnosticsState[globalAst.HCLParsingSource] == op.OpStateLoaded && rpcContext.IsDidChangeRequest() && rpcContext.LanguageID == ilsp.Terraform.String() {
+	if mod.ModuleDiagnosticsState[globalAst.HCLParsingSource] == op.OpStateLoaded && rpcContext.IsDidChangeRequest() && rpcContext.LanguageID == ilsp.Terraform.String() &&
+		isParsedIndividually(modStore, mod, rpcContext.URI) {
 		// the file has already been parsed, so only examine this file and not the whole module
 		err = modStore.SetModuleDiagnosticsState(modPath, globalAst.HCLParsingSource, op.OpStateLoading)
 		if err != nil {
@@ -74,7 +76,7 @@ func ParseModuleConfiguration(ctx context.Context, fs ReadOnlyFS, modStore *stat
 			return err
 		}
 
-		files, diags, err = parser.ParseModuleFiles(fs, modPath)
+		files, diags, err = parser.ParseModuleFiles(fs, modPath, modStore.Engine())
 	}
 
 	if err != nil {
@@ -93,3 +95,29 @@ func ParseModuleConfiguration(ctx context.Context, fs ReadOnlyFS, modStore *stat
 
 	return err
 }
+
+// isParsedIndividually reports whether the changed file can be parsed
+// on its own, i.e. whether it is already among the module files loaded
+// by the engine and cannot change which other files are loaded,
+// as e.g. a new main.tofu replaces main.tf in OpenTofu
+func isParsedIndividually(modStore *state.ModuleStore, mod *state.ModuleRecord, docURI string) bool {
+	filePath, err := uri.PathFromURI(docURI)
+	if err != nil {
+		return true
+	}
+	fileName := filepath.Base(filePath)
+
+	eng := modStore.ModuleEngine(mod.Path())
+	if !ast.IsEngineModuleFilename(fileName, eng) {
+		return false
+	}
+	if _, ok := mod.ParsedModuleFiles[ast.ModFilename(fileName)]; ok {
+		return true
+	}
+	if tofuName, ok := ast.OpenTofuOverrideFilename(fileName); ok && eng == engine.OpenTofu {
+		if _, ok := mod.ParsedModuleFiles[ast.ModFilename(tofuName)]; ok {
+			return false
+		}
+	}
+	return !engine.IsOpenTofuFilename(fileName)
+}
@@ -97,115 +97,179 @@ func GetModuleDataFromRegistry(ctx context.Context, regClient registry.Client, m
 			continue
 		}
 
-		// check if that address was already cached
-		// if there was an error finding in cache, so cache again
-		exists, err := modRegStore.Exists(sourceAddr, declaredModule.Version)
+		err := CacheRegistryModuleData(ctx, regClient, modRegStore, sourceAddr, declaredModule.Version)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
 		}
-		if exists {
-			// entry in cache, no need to look up
-			continue
-		}
 
-		// get module data from Terraform Registry
-		metaData, err := regClient.GetModuleData(ctx, sourceAddr, declaredModule.Version)
+		err = cacheRegistryModuleUpgrades(ctx, regClient, modStore, modRegStore, modPath, sourceAddr, declaredModule.Version)
 		if err != nil {
 			errs = multierror.Append(errs, err)
+		}
+	}
 
-			clientError := registry.ClientError{}
-			if errors.As(err, &clientError) &&
-				((clientError.StatusCode >= 400 && clientError.StatusCode < 408) ||
-					(clientError.StatusCode > 408 && clientError.StatusCode < 429)) {
-				// Still cache the module
-				err = modRegStore.CacheError(sourceAddr)
-				if err != nil {
-					errs = multierror.Append(errs, err)
-				}
-			}
+	return errs.ErrorOrNil()
+}
 
+// cacheRegistryModuleUpgrades caches all published versions of the given
+// module, along with data (inputs & outputs) of versions which the module
+// call can be upgraded to, so that upgrades can be offered and compared
+// without reaching out to the Registry API.
+func cacheRegistryModuleUpgrades(ctx context.Context, regClient registry.Client, modStore *state.ModuleStore, modRegStore *globalState.RegistryModuleStore, modPath string, sourceAddr tfaddr.Module, cons version.Constraints) error {
+	if len(cons) == 0 {
+		// the latest version is always used already
+		return nil
+	}
+
+	versions, err := regClient.GetModuleVersions(ctx, sourceAddr)
+	if err != nil {
+		clientError := registry.ClientError{}
+		if errors.Is(err, registry.ErrOffline) || errors.As(err, &clientError) {
+			// any client errors are reported when obtaining module data
+			return nil
+		}
+		return err
+	}
+
+	err = modStore.CacheRegistryModuleVersions(modPath, sourceAddr, versions)
+	if err != nil {
+		return err
+	}
+
+	upgrades, ok := registry.FindModuleUpgrades(versions, cons)
+	if !ok {
+		return nil
+	}
+
+	var errs *multierror.Error
+	for _, v := range []*version.Version{upgrades.Compatible, upgrades.Major} {
+		if v == nil {
 			continue
 		}
+		vCons := version.MustConstraints(version.NewConstraint(v.String()))
+		err := CacheRegistryModuleData(ctx, regClient, modRegStore, sourceAddr, vCons)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
 
-		registryInputs := metaData.Root.Inputs
-		registryOutputs := metaData.Root.Outputs
+	return errs.ErrorOrNil()
+}
 
-		// Check if the source address contains a submodule
-		// If we can find the submodule in the API response, we will use its inputs and outputs instead
-		if sourceAddr.Subdir != "" {
-			for _, mod := range metaData.Submodules {
-				if mod.Path == sourceAddr.Subdir {
-					registryInputs = mod.Inputs
-					registryOutputs = mod.Outputs
+// CacheRegistryModuleData obtains data about the given module (inputs & outputs)
+// from the Registry API and caches it, unless it was already cached.
+func CacheRegistryModuleData(ctx context.Context, regClient registry.Client, modRegStore *globalState.RegistryModuleStore, sourceAddr tfaddr.Module, cons version.Constraints) error {
+	// check if that address was already cached
+	// if there was an error finding in cache, so cache again
+	exists, err := modRegStore.Exists(sourceAddr, cons)
+	if err != nil {
+		return err
+	}
+	if exists {
+		// entry in cache, no need to look up
+		return nil
+	}
 
-					break
-				}
-			}
+	// get module data from Terraform Registry
+	metaData, err := regClient.GetModuleData(ctx, sourceAddr, cons)
+	if err != nil {
+		if errors.Is(err, registry.ErrOffline) {
+			// no cached data available, we'll try again once online
+			return nil
 		}
+		var errs *multierror.Error
+		errs = multierror.Append(errs, err)
 
-		inputs := make([]tfregistry.Input, len(registryInputs))
-		for i, input := range registryInputs {
-			isRequired := isRegistryModuleInputRequired(metaData.PublishedAt, input)
-			inputs[i] = tfregistry.Input{
-				Name:        input.Name,
-				Description: lang.Markdown(input.Description),
-				Required:    isRequired,
+		clientError := registry.ClientError{}
+		if errors.As(err, &clientError) &&
+			((clientError.StatusCode >= 400 && clientError.StatusCode < 408) ||
+				(clientError.StatusCode > 408 && clientError.StatusCode < 429)) {
+			// Still cache the module
+			err = modRegStore.CacheError(sourceAddr)
+			if err != nil {
+				errs = multierror.Append(errs, err)
 			}
+		}
 
-			inputType := cty.DynamicPseudoType
-			if input.Type != "" {
-				// Registry API unfortunately doesn't marshal types using
-				// cty marshalers, making it lossy, so we just try to decode
-				// on best-effort basis.
-				rawType := []byte(fmt.Sprintf("%q", input.Type))
-				typ, err := ctyjson.UnmarshalType(rawType)
-				if err == nil {
-					inputType = typ
-				}
-			}
-			inputs[i].Type = inputType
-
-			if input.Default != "" {
-				// Registry API unfortunately doesn't marshal values using
-				// cty marshalers, making it lossy, so we just try to decode
-				// on best-effort basis.
-				val, err := ctyjson.Unmarshal([]byte(input.Default), inputType)
-				if err == nil {
-					inputs[i].Default = val
-				}
+		return errs.ErrorOrNil()
+	}
+
+	registryInputs := metaData.Root.Inputs
+	registryOutputs := metaData.Root.Outputs
+
+	// Check if the source address contains a submodule
+	// If we can find the submodule in the API response, we will use its inputs and outputs instead
+	if sourceAddr.Subdir != "" {
+		for _, mod := range metaData.Submodules {
+			if mod.Path == sourceAddr.Subdir {
+				registryInputs = mod.Inputs
+				registryOutputs = mod.Outputs
+
+				break
 			}
 		}
-		outputs := make([]tfregistry.Output, len(registryOutputs))
-		for i, output := range registryOutputs {
-			outputs[i] = tfregistry.Output{
-				Name:        output.Name,
-				Description: lang.Markdown(output.Description),
-			}
+	}
+
+	inputs := make([]tfregistry.Input, len(registryInputs))
+	for i, input := range registryInputs {
+		isRequired := isRegistryModuleInputRequired(metaData.PublishedAt, input)
+		inputs[i] = tfregistry.Input{
+			Name:        input.Name,
+			Description: lang.Markdown(input.Description),
+			Required:    isRequired,
 		}
 
-		modVersion, err := version.NewVersion(metaData.Version)
-		if err != nil {
-			errs = multierror.Append(errs, err)
-			continue
+		inputType := cty.DynamicPseudoType
+		if input.Type != "" {
+			// Registry API unfortunately doesn't marshal types using
+			// cty marshalers, making it lossy, so we just try to decode
+			// on best-effort basis.
+			rawType := []byte(fmt.Sprintf("%q", input.Type))
+			typ, err := ctyjson.UnmarshalType(rawType)
+			if err == nil {
+				inputType = typ
+			}
 		}
+		inputs[i].Type = inputType
 
-		// if not, cache it
-		err = modRegStore.Cache(sourceAddr, modVersion, inputs, outputs)
-		if err != nil {
-			// A different job which ran in parallel for a different module block
-			// with the same source may have already cached the same module.
-			existsError := &globalState.AlreadyExistsError{}
-			if errors.As(err, &existsError) {
-				continue
+		if input.Default != "" {
+			// Registry API unfortunately doesn't marshal values using
+			// cty marshalers, making it lossy, so we just try to decode
+			// on best-effort basis.
+			val, err := ctyjson.Unmarshal([]byte(input.Default), inputType)
+			if err == nil {
+				inputs[i].Default = val
 			}
+		}
+	}
+	outputs := make([]tfregistry.Output, len(registryOutputs))
+	for i, output := range registryOutputs {
+		outputs[i] = tfregistry.Output{
+			Name:        output.Name,
+			Description: lang.Markdown(output.Description),
+		}
+	}
 
-			errs = multierror.Append(errs, err)
-			continue
+	modVersion, err := version.NewVersion(metaData.Version)
+	if err != nil {
+		return err
+	}
+
+	// if not, cache it
+	err = modRegStore.Cache(sourceAddr, modVersion, inputs, outputs)
+	if err != nil {
+		// A different job which ran in parallel for a different module block
+		// with the same source may have already cached the same module.
+		existsError := &globalState.AlreadyExistsError{}
+		if errors.As(err, &existsError) {
+			return nil
 		}
+
+		return err
 	}
 
-	return errs.ErrorOrNil()
+	return nil
 }
 
 // isRegistryModuleInputRequired checks whether the module input is required.
@@ -919,3 +919,91 @@ var randomSchemaJSON = `{
 		}
 	}
 }`
+
+func TestGetModuleDataFromRegistry_moduleUpgrades(t *testing.T) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, err := state.NewModuleStore(gs.ProviderSchemas, gs.RegistryModules, gs.ChangeStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "outdated-external-module")
+
+	err = ms.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ms, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = LoadModuleMetadata(ctx, ms, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	regClient := registry.NewClient()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/v1/modules/cloudposse/label/null/versions" {
+			w.Write([]byte(labelNullModuleVersionsMockResponse))
+			return
+		}
+		if r.RequestURI == "/v1/modules/cloudposse/label/null/0.25.0" {
+			w.Write([]byte(labelNullModuleDataOldMockResponse))
+			return
+		}
+		if r.RequestURI == "/v1/modules/cloudposse/label/null/0.26.0" {
+			w.Write([]byte(labelNullModuleDataNewMockResponse))
+			return
+		}
+		http.Error(w, fmt.Sprintf("unexpected request: %q", r.RequestURI), 400)
+	}))
+	regClient.BaseURL = srv.URL
+	t.Cleanup(srv.Close)
+
+	err = GetModuleDataFromRegistry(ctx, regClient, ms, gs.RegistryModules, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := tfaddr.ParseModuleSource("cloudposse/label/null")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := ms.RegistryModuleVersions(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedVersions := []string{"0.26.0", "0.25.0"}
+	givenVersions := make([]string, 0, len(versions))
+	for _, v := range versions {
+		givenVersions = append(givenVersions, v.String())
+	}
+	if diff := cmp.Diff(expectedVersions, givenVersions); diff != "" {
+		t.Fatalf("unexpected versions: %s", diff)
+	}
+
+	// data of the version to upgrade to should be cached
+	// even though no module call uses it yet
+	newCons := version.MustConstraints(version.NewConstraint("0.26.0"))
+	meta, err := ms.RegistryModuleMeta(addr, newCons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(labelNullExpectedNewModuleData, meta, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("metadata mismatch: %s", diff)
+	}
+}
@@ -6,7 +6,11 @@ package jobs
 import (
 	"context"
 	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl/v2"
@@ -21,8 +25,11 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
 )
 
 // SchemaModuleValidation does schema-based validation
@@ -96,10 +103,15 @@ func SchemaModuleValidation(ctx context.Context, modStore *state.ModuleStore, ro
 
 // ReferenceValidation does validation based on (mis)matched
 // reference origins and targets, to flag up "orphaned" references.
+// It also follows sensitive and ephemeral values through references
+// to flag up places where such values are not allowed and checks
+// provider configurations passed to resources and called modules,
+// as well as provider version constraints across module trees
+// and compatibility of the configuration with Terraform versions.
 //
 // It relies on [DecodeReferenceTargets] and [DecodeReferenceOrigins]
 // to supply both origins and targets to compare.
-func ReferenceValidation(ctx context.Context, modStore *state.ModuleStore, rootFeature fdecoder.RootReader, modPath string) error {
+func ReferenceValidation(ctx context.Context, fs ReadOnlyFS, modStore *state.ModuleStore, rootFeature fdecoder.RootReader, modPath string) error {
 	mod, err := modStore.ModuleRecordByPath(modPath)
 	if err != nil {
 		return err
@@ -128,9 +140,162 @@ func ReferenceValidation(ctx context.Context, modStore *state.ModuleStore, rootF
 	}
 
 	diags := validations.UnreferencedOrigins(ctx, pathCtx)
+	diags = diags.Extend(validations.RefactoringBlocks(ctx, pathCtx, rootFeature.TerraformVersion(modPath)))
+	diags = diags.Extend(validations.SensitiveDataflow(ctx, pathCtx))
+	diags = diags.Extend(validations.ProviderConfigurations(ctx, pathCtx,
+		mod.Meta.ProviderReferences, moduleCallProviders(modStore, rootFeature, modPath)))
+	diags = diags.Extend(validations.ProviderConstraints(ctx, pathCtx, modPath,
+		mod.Meta.ProviderReferences, moduleTrees(modStore, rootFeature, modPath)))
+	// required_version of OpenTofu modules refers to OpenTofu versions,
+	// which Terraform versions of language features don't apply to
+	if modStore.ModuleEngine(modPath) != engine.OpenTofu {
+		diags = diags.Extend(validations.TerraformVersionCompatibility(ctx, pathCtx, mod.Meta.CoreRequirements,
+			rootFeature.TerraformVersion(modPath), pinnedTerraformVersion(fs, modPath)))
+	}
 	return modStore.UpdateModuleDiagnostics(modPath, globalAst.ReferenceValidationSource, ast.ModDiagsFromMap(diags))
 }
 
+// moduleCallProviders collects provider configurations of modules
+// called from the given module, leaving out modules which
+// are not installed or whose metadata are not loaded yet
+func moduleCallProviders(modStore *state.ModuleStore, rootFeature fdecoder.RootReader, modPath string) map[string]validations.ModuleProviders {
+	providers := make(map[string]validations.ModuleProviders)
+
+	declared, err := modStore.DeclaredModuleCalls(modPath)
+	if err != nil {
+		return providers
+	}
+
+	for name, mc := range declared {
+		mcPath, ok := moduleCallPath(rootFeature, modPath, modPath, mc)
+		if !ok {
+			continue
+		}
+
+		meta, err := modStore.LocalModuleMeta(mcPath)
+		if err != nil {
+			continue
+		}
+		mcRecord, err := modStore.ModuleRecordByPath(mcPath)
+		if err != nil {
+			continue
+		}
+
+		providers[name] = validations.ModuleProviders{
+			References:           meta.ProviderReferences,
+			ConfigurationAliases: validations.ConfigurationAliases(mcRecord.ParsedModuleFiles.AsMap()),
+		}
+	}
+
+	return providers
+}
+
+// pinnedTerraformVersion returns the Terraform version pinned
+// in the .terraform-version file of the module, if any
+func pinnedTerraformVersion(fs ReadOnlyFS, modPath string) *version.Version {
+	b, err := fs.ReadFile(filepath.Join(modPath, ".terraform-version"))
+	if err != nil {
+		return nil
+	}
+	// version managers also accept values such as "latest",
+	// which we can't resolve here
+	v, err := version.NewVersion(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// moduleCallPath resolves the path to the module called from the module
+// at modPath. Installed modules are looked up in the manifest
+// of the root module at rootPath.
+func moduleCallPath(rootFeature fdecoder.RootReader, rootPath, modPath string, mc tfmod.DeclaredModuleCall) (string, bool) {
+	switch source := mc.SourceAddr.(type) {
+	case tfmod.LocalSourceAddr:
+		return filepath.Join(modPath, filepath.FromSlash(source.String())), true
+	case tfaddr.Module, tfmod.RemoteSourceAddr:
+		installedDir, ok := rootFeature.InstalledModulePath(rootPath, source.String())
+		if !ok {
+			return "", false
+		}
+		return filepath.Join(rootPath, filepath.FromSlash(installedDir)), true
+	}
+	return "", false
+}
+
+// moduleTrees returns trees of all modules known to the store, which
+// contain the module at modPath. Any module not called from another
+// module is considered a root of a tree.
+func moduleTrees(modStore *state.ModuleStore, rootFeature fdecoder.RootReader, modPath string) []validations.ModuleTree {
+	trees := make([]validations.ModuleTree, 0)
+
+	records, err := modStore.List()
+	if err != nil {
+		return trees
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Path() < records[j].Path()
+	})
+	byPath := make(map[string]*state.ModuleRecord, len(records))
+	for _, record := range records {
+		byPath[record.Path()] = record
+	}
+
+	called := make(map[string]bool)
+	treeModules := make(map[string][]validations.ModuleRequirements, len(records))
+	for _, root := range records {
+		modules := make([]validations.ModuleRequirements, 0)
+		var walk func(path, address string, level int)
+		walk = func(path, address string, level int) {
+			record, ok := byPath[path]
+			if !ok || level > modStore.MaxModuleNesting {
+				return
+			}
+			modules = append(modules, validations.ModuleRequirements{
+				Address:      address,
+				Path:         path,
+				Requirements: record.Meta.ProviderRequirements,
+			})
+
+			names := make([]string, 0, len(record.Meta.ModuleCalls))
+			for name := range record.Meta.ModuleCalls {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				mcPath, ok := moduleCallPath(rootFeature, root.Path(), path, record.Meta.ModuleCalls[name])
+				if !ok {
+					continue
+				}
+				called[mcPath] = true
+				walk(mcPath, strings.TrimPrefix(address+".module."+name, "."), level+1)
+			}
+		}
+		walk(root.Path(), "", 0)
+		treeModules[root.Path()] = modules
+	}
+
+	for _, record := range records {
+		if called[record.Path()] {
+			continue
+		}
+		modules := treeModules[record.Path()]
+		for _, mod := range modules {
+			if mod.Path != modPath {
+				continue
+			}
+			lockedProviders, _ := rootFeature.InstalledProviders(record.Path())
+			trees = append(trees, validations.ModuleTree{
+				Modules:         modules,
+				LockedProviders: lockedProviders,
+			})
+			break
+		}
+	}
+
+	return trees
+}
+
 // TerraformValidate uses Terraform CLI to run validate subcommand
 // and turn the provided (JSON) output into diagnostics associated
 // with "invalid" parts of code.
@@ -9,13 +9,17 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type RootReaderMock struct{}
@@ -32,6 +36,10 @@ func (r RootReaderMock) InstalledModulePath(rootPath string, normalizedSource st
 	return "", false
 }
 
+func (r RootReaderMock) InstalledProviders(modPath string) (map[tfaddr.Provider]*version.Version, error) {
+	return nil, nil
+}
+
 func TestSchemaModuleValidation_FullModule(t *testing.T) {
 	ctx := context.Background()
 	gs, err := globalState.NewStateStore()
@@ -129,3 +137,187 @@ func TestSchemaModuleValidation_SingleFile(t *testing.T) {
 		t.Fatalf("expected %d diagnostics, %d given", expectedCount, diagsCount)
 	}
 }
+
+func TestReferenceValidation_providerConstraints(t *testing.T) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, err := state.NewModuleStore(gs.ProviderSchemas, gs.RegistryModules, gs.ChangeStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootPath := filepath.Join(testData, "provider-constraints")
+	childPath := filepath.Join(rootPath, "network")
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	for _, modPath := range []string{rootPath, childPath} {
+		err = ms.Add(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ParseModuleConfiguration(ctx, fs, ms, modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = LoadModuleMetadata(ctx, ms, modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, modPath := range []string{rootPath, childPath} {
+		err = ReferenceValidation(ctx, fs, ms, RootReaderMock{}, modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mod, err := ms.ModuleRecordByPath(modPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource]["main.tf"]
+		if len(diags) != 1 {
+			t.Fatalf("%s: expected 1 diagnostic, %d given: %#v", modPath, len(diags), diags)
+		}
+		expectedSummary := "Conflicting provider version constraints"
+		if diags[0].Summary != expectedSummary {
+			t.Fatalf("%s: expected summary %q, given: %q", modPath, expectedSummary, diags[0].Summary)
+		}
+	}
+}
+
+func TestReferenceValidation_openTofuProviderForEach(t *testing.T) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, err := state.NewModuleStore(gs.ProviderSchemas, gs.RegistryModules, gs.ChangeStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "opentofu-provider-for-each")
+
+	err = gs.ProviderSchemas.AddPreloadedSchema(tfaddr.MustParseProviderSource("hashicorp/aws"),
+		version.Must(version.NewVersion("5.0.0")), &tfschema.ProviderSchema{
+			Provider: &schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"region": {
+						Constraint: schema.AnyExpression{OfType: cty.String},
+						IsOptional: true,
+					},
+				},
+			},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ms.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ms, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ms, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(ctx, ms, RootReaderMock{}, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ms, RootReaderMock{}, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, fs, ms, RootReaderMock{}, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ms.ModuleRecordByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each.value within the provider block is declared by for_each,
+	// while each.key outside of it is not
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource]["main.tofu"]
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %#v", len(diags), diags)
+	}
+	expectedSummary := `No declaration found for "each.key"`
+	if diags[0].Summary != expectedSummary {
+		t.Fatalf("expected summary %q, given: %q", expectedSummary, diags[0].Summary)
+	}
+}
+
+func TestReferenceValidation_openTofuRequiredVersion(t *testing.T) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, err := state.NewModuleStore(gs.ProviderSchemas, gs.RegistryModules, gs.ChangeStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modPath := filepath.Join(testData, "opentofu-required-version")
+
+	err = ms.Add(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+	err = ParseModuleConfiguration(ctx, fs, ms, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadModuleMetadata(ctx, ms, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, fs, ms, RootReaderMock{}, modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := ms.ModuleRecordByPath(modPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// check blocks were introduced in Terraform 1.5, which
+	// says nothing about OpenTofu versions allowed by the module
+	diags := mod.ModuleDiagnostics[ast.ReferenceValidationSource]["main.tofu"]
+	if len(diags) > 0 {
+		t.Fatalf("expected no diagnostics, %d given: %#v", len(diags), diags)
+	}
+}
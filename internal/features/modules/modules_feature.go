@@ -13,10 +13,14 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/algolia"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/eventbus"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/ast"
 	fdecoder "github.com/hashicorp/terraform-ls/internal/features/modules/decoder"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/eval"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/hooks"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/jobs"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/state"
@@ -25,8 +29,14 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/registry"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/telemetry"
+	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/hashicorp/terraform-schema/backend"
 	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfregistry "github.com/hashicorp/terraform-schema/registry"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
 )
 
 // ModulesFeature groups everything related to modules. Its internal
@@ -67,6 +77,17 @@ func (f *ModulesFeature) SetLogger(logger *log.Logger) {
 	f.Store.SetLogger(logger)
 }
 
+// SetEngine sets the engine configured (or detected) for the session.
+// engine.Auto means that the engine is detected for each module separately.
+func (f *ModulesFeature) SetEngine(e engine.Engine) {
+	f.Store.SetEngine(e)
+}
+
+// ModuleEngine returns the engine the module at the given path is written for
+func (f *ModulesFeature) ModuleEngine(modPath string) engine.Engine {
+	return f.Store.ModuleEngine(modPath)
+}
+
 // Start starts the features separate goroutine.
 // It listens to various events from the EventBus and performs corresponding actions.
 func (f *ModulesFeature) Start(ctx context.Context) {
@@ -148,6 +169,15 @@ func (f *ModulesFeature) ProviderRequirements(modPath string) (tfmod.ProviderReq
 	return mod.Meta.ProviderRequirements, nil
 }
 
+func (f *ModulesFeature) ProviderReferences(modPath string) (map[tfmod.ProviderRef]tfaddr.Provider, error) {
+	mod, err := f.Store.ModuleRecordByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return mod.Meta.ProviderReferences, nil
+}
+
 func (f *ModulesFeature) CoreRequirements(modPath string) (version.Constraints, error) {
 	mod, err := f.Store.ModuleRecordByPath(modPath)
 	if err != nil {
@@ -166,6 +196,14 @@ func (f *ModulesFeature) ModuleInputs(modPath string) (map[string]tfmod.Variable
 	return mod.Meta.Variables, nil
 }
 
+func (f *ModulesFeature) RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*tfregistry.ModuleData, error) {
+	return f.Store.RegistryModuleMeta(addr, cons)
+}
+
+func (f *ModulesFeature) RegistryModuleVersions(addr tfaddr.Module) (version.Collection, error) {
+	return f.Store.RegistryModuleVersions(addr)
+}
+
 func (f *ModulesFeature) AppendCompletionHooks(srvCtx context.Context, decoderContext decoder.DecoderContext) {
 	h := hooks.Hooks{
 		ModStore:       f.Store,
@@ -173,8 +211,9 @@ func (f *ModulesFeature) AppendCompletionHooks(srvCtx context.Context, decoderCo
 		Logger:         f.logger,
 	}
 
+	// There is no point in searching the Algolia index when offline
 	credentials, ok := algolia.CredentialsFromContext(srvCtx)
-	if ok {
+	if ok && !f.registryClient.Offline {
 		h.AlgoliaClient = search.NewClient(credentials.AppID, credentials.APIKey)
 	}
 
@@ -301,3 +340,95 @@ func (f *ModulesFeature) MetadataReady(dir document.DirHandle) (<-chan struct{},
 func (s *ModulesFeature) LocalModuleMeta(modPath string) (*tfmod.Meta, error) {
 	return s.Store.LocalModuleMeta(modPath)
 }
+
+// ReferenceTargetsForModule returns reference targets collected
+// from the module, such as resources, data sources or module calls
+func (f *ModulesFeature) ReferenceTargetsForModule(modPath string) (reference.Targets, error) {
+	mod, err := f.Store.ModuleRecordByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return mod.RefTargets, nil
+}
+
+// ParsedModuleFiles returns parsed configuration files
+// of all modules in the workspace, keyed by module path.
+// Modules which were discovered but not parsed yet are parsed first.
+func (f *ModulesFeature) ParsedModuleFiles(ctx context.Context) (map[string]map[string]*hcl.File, error) {
+	records, err := f.Store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(job.IDs, 0)
+	for _, record := range records {
+		if record.ParsedModuleFiles != nil {
+			continue
+		}
+		path := record.Path()
+		id, err := f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+			Dir: document.DirHandleFromPath(path),
+			Func: func(ctx context.Context) error {
+				return jobs.ParseModuleConfiguration(ctx, f.fs, f.Store, path)
+			},
+			Type: op.OpTypeParseModuleConfiguration.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) > 0 {
+		err = f.stateStore.JobStore.WaitForJobs(ctx, ids...)
+		if err != nil {
+			return nil, err
+		}
+		records, err = f.Store.List()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	modules := make(map[string]map[string]*hcl.File, len(records))
+	for _, record := range records {
+		if record.ParsedModuleFiles == nil {
+			continue
+		}
+		modules[record.Path()] = record.ParsedModuleFiles.AsMap()
+	}
+
+	return modules, nil
+}
+
+// Evaluator returns an evaluator of expressions within the given module,
+// which resolves variables from the given variable files and uses
+// functions of the Terraform version used by the module
+func (f *ModulesFeature) Evaluator(modPath string, varsFiles map[string]*hcl.File) (*eval.Evaluator, error) {
+	mod, err := f.Store.ModuleRecordByPath(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tfVersion := tfschema.ResolveVersion(f.rootFeature.TerraformVersion(modPath), mod.Meta.CoreRequirements)
+
+	return eval.NewEvaluator(mod.ParsedModuleFiles.AsMap(), varsFiles, tfVersion), nil
+}
+
+// UpdatePolicyPreviewResults replaces diagnostics from an earlier
+// policy preview of the given module with the provided ones
+func (f *ModulesFeature) UpdatePolicyPreviewResults(modPath string, diags map[string]hcl.Diagnostics) error {
+	return f.Store.UpdateModuleDiagnostics(modPath, globalAst.PolicyPreviewSource, ast.ModDiagsFromMap(diags))
+}
+
+// UpdatePlanAnnotations replaces diagnostics describing changes
+// of an earlier annotated plan of the given module with the provided ones
+func (f *ModulesFeature) UpdatePlanAnnotations(modPath string, diags map[string]hcl.Diagnostics) error {
+	return f.Store.UpdateModuleDiagnostics(modPath, globalAst.PlanSource, ast.ModDiagsFromMap(diags))
+}
+
+// UpdateProviderUpgradeReport replaces diagnostics from an earlier
+// provider upgrade report of the given module with the provided ones
+func (f *ModulesFeature) UpdateProviderUpgradeReport(modPath string, diags map[string]hcl.Diagnostics) error {
+	return f.Store.UpdateModuleDiagnostics(modPath, globalAst.ProviderUpgradeSource, ast.ModDiagsFromMap(diags))
+}
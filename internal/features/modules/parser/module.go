@@ -8,10 +8,13 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"github.com/hashicorp/terraform-ls/internal/terraform/parser"
 )
 
-func ParseModuleFiles(fs parser.FS, modPath string) (ast.ModFiles, ast.ModDiags, error) {
+// ParseModuleFiles parses all files of the module which the given engine
+// loads. For engine.Auto the engine is detected from the filenames.
+func ParseModuleFiles(fs parser.FS, modPath string, eng engine.Engine) (ast.ModFiles, ast.ModDiags, error) {
 	files := make(ast.ModFiles, 0)
 	diags := make(ast.ModDiags, 0)
 
@@ -20,6 +23,17 @@ func ParseModuleFiles(fs parser.FS, modPath string) (ast.ModFiles, ast.ModDiags,
 		return nil, nil, err
 	}
 
+	names := make(map[string]struct{}, len(infos))
+	filenames := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		names[info.Name()] = struct{}{}
+		filenames = append(filenames, info.Name())
+	}
+	eng = engine.FromFilenames(eng, filenames)
+
 	for _, info := range infos {
 		if info.IsDir() {
 			// We only care about files
@@ -27,10 +41,18 @@ func ParseModuleFiles(fs parser.FS, modPath string) (ast.ModFiles, ast.ModDiags,
 		}
 
 		name := info.Name()
-		if !ast.IsModuleFilename(name) {
+		if !ast.IsEngineModuleFilename(name, eng) {
 			continue
 		}
 
+		// OpenTofu loads e.g. main.tofu instead of main.tf
+		// when both files are present
+		if tofuName, ok := ast.OpenTofuOverrideFilename(name); ok && eng == engine.OpenTofu {
+			if _, exists := names[tofuName]; exists {
+				continue
+			}
+		}
+
 		// TODO: overrides
 
 		fullPath := filepath.Join(modPath, name)
@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 )
 
 func TestParseModuleFiles(t *testing.T) {
@@ -48,6 +49,17 @@ func TestParseModuleFiles(t *testing.T) {
 				"main.tf":    nil,
 			},
 		},
+		{
+			"valid-tofu-mod-files",
+			map[string]struct{}{
+				"main.tofu":    {},
+				"variables.tf": {},
+			},
+			map[string]hcl.Diagnostics{
+				"main.tofu":    nil,
+				"variables.tf": nil,
+			},
+		},
 		{
 			"invalid-mod-files",
 			map[string]struct{}{
@@ -103,7 +115,7 @@ func TestParseModuleFiles(t *testing.T) {
 		t.Run(fmt.Sprintf("%d-%s", i, tc.dirName), func(t *testing.T) {
 			modPath := filepath.Join("testdata", tc.dirName)
 
-			files, diags, err := ParseModuleFiles(fs, modPath)
+			files, diags, err := ParseModuleFiles(fs, modPath, engine.Auto)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -120,6 +132,49 @@ func TestParseModuleFiles(t *testing.T) {
 	}
 }
 
+func TestParseModuleFiles_engine(t *testing.T) {
+	testCases := []struct {
+		engine            engine.Engine
+		expectedFileNames map[string]struct{}
+	}{
+		{
+			engine.Auto,
+			map[string]struct{}{
+				"main.tofu":    {},
+				"variables.tf": {},
+			},
+		},
+		{
+			engine.OpenTofu,
+			map[string]struct{}{
+				"main.tofu":    {},
+				"variables.tf": {},
+			},
+		},
+		{
+			engine.Terraform,
+			map[string]struct{}{
+				"main.tf":      {},
+				"variables.tf": {},
+			},
+		},
+	}
+
+	modPath := filepath.Join("testdata", "valid-tofu-mod-files")
+	for _, tc := range testCases {
+		t.Run(tc.engine.String(), func(t *testing.T) {
+			files, _, err := ParseModuleFiles(osFs{}, modPath, tc.engine)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedFileNames, mapKeys(files)); diff != "" {
+				t.Fatalf("unexpected file names: %s", diff)
+			}
+		})
+	}
+}
+
 func mapKeys(mf ast.ModFiles) map[string]struct{} {
 	m := make(map[string]struct{}, len(mf))
 	for name := range mf {
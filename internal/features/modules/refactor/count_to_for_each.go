@@ -0,0 +1,345 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// CountConversion describes the outcome of [ConvertCountToForEach]
+type CountConversion struct {
+	// Address is the address of the converted resource or module
+	Address string
+	// Reasons describe why some references could not be rewritten
+	// or why moved blocks could not be generated
+	Reasons []string
+
+	Changes
+}
+
+// ConvertCountToForEach rewrites count of the resource or module block of
+// the given file at rng into for_each.
+//
+// For count = length(X), the instances are keyed by the elements of X
+// and X[count.index] becomes each.value. Any other count is turned into
+// a map keyed by the index. References to instances elsewhere in the
+// module are rewritten to the new keys and splat expressions to values().
+//
+// Moved blocks are only generated if the keys can be derived statically,
+// i.e. from literals and local values which are literals. Otherwise,
+// the reasons are left in a comment after the block.
+func ConvertCountToForEach(pathCtx *decoder.PathContext, filename string, rng hcl.Range) (CountConversion, bool) {
+	cc := CountConversion{
+		Reasons: make([]string, 0),
+		Changes: newChanges(),
+	}
+
+	f, ok := pathCtx.Files[filename]
+	if !ok {
+		return cc, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return cc, false
+	}
+
+	var block *hclsyntax.Block
+	for _, b := range body.Blocks {
+		if b.Type != "resource" && b.Type != "module" {
+			continue
+		}
+		if _, ok := b.Body.Attributes["count"]; ok && overlaps(b.Range(), rng) {
+			block = b
+			break
+		}
+	}
+	if block == nil {
+		return cc, false
+	}
+	if _, ok := block.Body.Attributes["for_each"]; ok {
+		return cc, false
+	}
+	blockAddr, ok := blockAddress(block)
+	if !ok {
+		return cc, false
+	}
+	cc.Address = blockAddr.String()
+
+	countAttr := block.Body.Attributes["count"]
+	countText := rangeText(pathCtx.Files, countAttr.Expr.Range())
+
+	// count = length(X) is keyed by the elements of X
+	var collection hclsyntax.Expression
+	if call, ok := countAttr.Expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == "length" && len(call.Args) == 1 {
+		collection = call.Args[0]
+	}
+
+	collectionIndexes := make([]hcl.Range, 0)
+	indexes := make([]hcl.Range, 0)
+	for _, info := range expressions(block.Body) {
+		if !isCountIndex(info.expr) {
+			continue
+		}
+		if idx, ok := info.parent.(*hclsyntax.IndexExpr); ok && collection != nil && idx.Key == info.expr &&
+			normalizedText(f.Bytes, idx.Collection.Range()) == normalizedText(f.Bytes, collection.Range()) {
+			collectionIndexes = append(collectionIndexes, idx.Range())
+			continue
+		}
+		indexes = append(indexes, info.expr.Range())
+	}
+
+	keys, reason, ok := instanceKeys(pathCtx.Files, countAttr.Expr, collection)
+	if !ok {
+		// count is invalid, so there is nothing to convert
+		return cc, false
+	}
+	if reason != "" {
+		cc.Reasons = append(cc.Reasons, reason)
+	}
+
+	var forEach, elemRef string
+	switch {
+	case collection != nil && len(indexes) == 0 && hasNonStringElements(pathCtx.Files, collection):
+		// for_each only accepts sets of strings
+		forEach = fmt.Sprintf("toset([for v in %s : tostring(v)])", rangeText(pathCtx.Files, collection.Range()))
+		elemRef = "each.value"
+	case collection != nil && len(indexes) == 0:
+		forEach = fmt.Sprintf("toset(%s)", rangeText(pathCtx.Files, collection.Range()))
+		elemRef = "each.value"
+	case collection != nil:
+		forEach = fmt.Sprintf("{ for i, v in %s : v => i }", rangeText(pathCtx.Files, collection.Range()))
+		elemRef = "each.key"
+	default:
+		forEach = fmt.Sprintf("{ for i in range(%s) : tostring(i) => i }", countText)
+	}
+
+	cc.addEdit(countAttr.SrcRange, "for_each = "+forEach)
+	for _, rng := range collectionIndexes {
+		cc.addEdit(rng, elemRef)
+	}
+	for _, rng := range indexes {
+		cc.addEdit(rng, "each.value")
+	}
+
+	infos := make(map[string][]exprInfo)
+	for _, origin := range localOrigins(pathCtx.ReferenceOrigins) {
+		if !hasPrefix(origin.Addr, blockAddr) || containsRange(block.Range(), origin.Range) {
+			continue
+		}
+
+		if _, ok := infos[origin.Range.Filename]; !ok {
+			if body, ok := pathCtx.Files[origin.Range.Filename].Body.(*hclsyntax.Body); ok {
+				infos[origin.Range.Filename] = expressions(body)
+			}
+		}
+		info, ok := traversalAt(infos[origin.Range.Filename], origin.Range)
+		if !ok {
+			continue
+		}
+		traversal := info.expr.(*hclsyntax.ScopeTraversalExpr).Traversal
+
+		if len(traversal) > len(blockAddr) {
+			index, ok := traversal[len(blockAddr)].(hcl.TraverseIndex)
+			if !ok {
+				continue
+			}
+			i, ok := numericIndex(index.Key)
+			if !ok {
+				continue
+			}
+			if keys == nil || i >= len(keys) {
+				cc.Reasons = append(cc.Reasons, fmt.Sprintf("%s at %s refers to an instance by index",
+					rangeText(pathCtx.Files, origin.Range), rangeString(origin.Range)))
+				continue
+			}
+			cc.addEdit(index.SrcRange, fmt.Sprintf("[%q]", keys[i]))
+			continue
+		}
+
+		switch parent := info.parent.(type) {
+		case *hclsyntax.SplatExpr:
+			if parent.Source == info.expr {
+				cc.addEdit(origin.Range, fmt.Sprintf("values(%s)", rangeText(pathCtx.Files, origin.Range)))
+			}
+		case *hclsyntax.IndexExpr:
+			if parent.Collection == info.expr {
+				cc.Reasons = append(cc.Reasons, fmt.Sprintf("%s at %s refers to an instance by a dynamic index",
+					rangeText(pathCtx.Files, parent.Range()), rangeString(parent.Range())))
+			}
+		}
+	}
+
+	var buf strings.Builder
+	if len(cc.Reasons) > 0 {
+		buf.WriteString("\n\n# TODO: Review references and existing instances, as the conversion is incomplete:")
+		for _, reason := range cc.Reasons {
+			fmt.Fprintf(&buf, "\n#  - %s", reason)
+		}
+	}
+	for i, key := range keys {
+		fmt.Fprintf(&buf, "\n\nmoved {\n  from = %s[%d]\n  to   = %s[%q]\n}", cc.Address, i, cc.Address, key)
+	}
+	if buf.Len() > 0 {
+		end := block.Range().End
+		cc.addEdit(hcl.Range{Filename: filename, Start: end, End: end}, buf.String())
+	}
+
+	return cc, true
+}
+
+// maxMovedInstances is the maximum number of instances
+// for which moved blocks are generated
+const maxMovedInstances = 100
+
+// instanceKeys returns the keys of instances in the order of their
+// indexes, or the reason why they are not known statically.
+// It returns false if count is known to be invalid, i.e. not
+// a whole non-negative number.
+func instanceKeys(files map[string]*hcl.File, count hcl.Expression, collection hclsyntax.Expression) ([]string, string, bool) {
+	if collection == nil {
+		val, ok := staticValue(files, count)
+		if !ok {
+			return nil, "the number of instances is not known statically, so no moved blocks were generated", true
+		}
+		val, err := convert.Convert(val, cty.Number)
+		if err != nil || val.IsNull() {
+			return nil, "the number of instances is not known statically, so no moved blocks were generated", true
+		}
+		bf := val.AsBigFloat()
+		if bf.Sign() < 0 || !bf.IsInt() {
+			return nil, "", false
+		}
+		if bf.Cmp(big.NewFloat(maxMovedInstances)) > 0 {
+			return nil, fmt.Sprintf("there are more than %d instances, so no moved blocks were generated", maxMovedInstances), true
+		}
+		n, _ := bf.Int64()
+		keys := make([]string, 0, n)
+		for i := int64(0); i < n; i++ {
+			keys = append(keys, fmt.Sprintf("%d", i))
+		}
+		return keys, "", true
+	}
+
+	collText := compactText(files, collection.Range())
+	val, ok := staticValue(files, collection)
+	if !ok || !(val.Type().IsTupleType() || val.Type().IsListType()) {
+		return nil, fmt.Sprintf("the elements of %s are not known statically, so no moved blocks were generated", collText), true
+	}
+	if val.LengthInt() > maxMovedInstances {
+		return nil, fmt.Sprintf("%s has more than %d elements, so no moved blocks were generated", collText, maxMovedInstances), true
+	}
+
+	keys := make([]string, 0, val.LengthInt())
+	seen := make(map[string]bool)
+	for it := val.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		key, err := convert.Convert(elem, cty.String)
+		if err != nil || key.IsNull() {
+			return nil, fmt.Sprintf("the elements of %s are not strings, so they cannot be used as keys", collText), true
+		}
+		if seen[key.AsString()] {
+			return nil, fmt.Sprintf("%s contains %q more than once, so instances cannot be moved to unique keys", collText, key.AsString()), true
+		}
+		seen[key.AsString()] = true
+		keys = append(keys, key.AsString())
+	}
+	return keys, "", true
+}
+
+// hasNonStringElements reports whether the collection is known
+// statically to contain elements other than strings
+func hasNonStringElements(files map[string]*hcl.File, collection hclsyntax.Expression) bool {
+	val, ok := staticValue(files, collection)
+	if !ok || !val.CanIterateElements() {
+		return false
+	}
+	for it := val.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		if !elem.Type().Equals(cty.String) {
+			return true
+		}
+	}
+	return false
+}
+
+// staticValue evaluates the expression if it only consists
+// of literals and local values which are literals
+func staticValue(files map[string]*hcl.File, expr hcl.Expression) (cty.Value, bool) {
+	locals := make(map[string]cty.Value)
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			return cty.NilVal, false
+		}
+		step, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return cty.NilVal, false
+		}
+		attr, _, ok := localDeclaration(files, step.Name)
+		if !ok {
+			return cty.NilVal, false
+		}
+		val, ok := literalValue(attr.Expr)
+		if !ok {
+			return cty.NilVal, false
+		}
+		locals[step.Name] = val
+	}
+
+	val, diags := expr.Value(&hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"local": cty.ObjectVal(locals),
+		},
+	})
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return cty.NilVal, false
+	}
+	return val, true
+}
+
+func isCountIndex(expr hclsyntax.Expression) bool {
+	traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(traversal.Traversal) != 2 || traversal.Traversal.RootName() != "count" {
+		return false
+	}
+	step, ok := traversal.Traversal[1].(hcl.TraverseAttr)
+	return ok && step.Name == "index"
+}
+
+// traversalAt returns the traversal expression with the given range
+func traversalAt(infos []exprInfo, rng hcl.Range) (exprInfo, bool) {
+	for _, info := range infos {
+		if _, ok := info.expr.(*hclsyntax.ScopeTraversalExpr); ok && info.expr.Range() == rng {
+			return info, true
+		}
+	}
+	return exprInfo{}, false
+}
+
+func numericIndex(key cty.Value) (int, bool) {
+	if !key.IsKnown() || key.IsNull() || key.Type() != cty.Number {
+		return 0, false
+	}
+	i, acc := key.AsBigFloat().Int64()
+	if acc != big.Exact || i < 0 {
+		return 0, false
+	}
+	return int(i), true
+}
+
+func rangeString(rng hcl.Range) string {
+	return fmt.Sprintf("%s:%d", rng.Filename, rng.Start.Line)
+}
+
+// compactText returns the source text within the range on a single line
+func compactText(files map[string]*hcl.File, rng hcl.Range) string {
+	return strings.Join(strings.Fields(rangeText(files, rng)), " ")
+}
@@ -0,0 +1,200 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertCountToForEach(t *testing.T) {
+	cfg := `locals {
+  names = ["web", "db"]
+}
+
+resource "aws_instance" "server" {
+  count = length(local.names)
+  tags = {
+    Name = local.names[count.index]
+  }
+}
+
+output "web_ip" {
+  value = aws_instance.server[0].private_ip
+}
+
+output "ids" {
+  value = aws_instance.server[*].id
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	cc, ok := ConvertCountToForEach(pathCtx, "main.tf", selection(cfg, "server"))
+	if !ok {
+		t.Fatal("expected count to be converted")
+	}
+	if len(cc.Reasons) > 0 {
+		t.Fatalf("unexpected reasons: %q", cc.Reasons)
+	}
+
+	expectedCfg := `locals {
+  names = ["web", "db"]
+}
+
+resource "aws_instance" "server" {
+  for_each = toset(local.names)
+  tags = {
+    Name = each.value
+  }
+}
+
+moved {
+  from = aws_instance.server[0]
+  to   = aws_instance.server["web"]
+}
+
+moved {
+  from = aws_instance.server[1]
+  to   = aws_instance.server["db"]
+}
+
+output "web_ip" {
+  value = aws_instance.server["web"].private_ip
+}
+
+output "ids" {
+  value = values(aws_instance.server)[*].id
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), cc.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
+
+func TestConvertCountToForEach_numberElements(t *testing.T) {
+	cfg := `locals {
+  ports = [80, 443]
+}
+
+resource "aws_security_group_rule" "ingress" {
+  count     = length(local.ports)
+  from_port = local.ports[count.index]
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	cc, ok := ConvertCountToForEach(pathCtx, "main.tf", selection(cfg, "ingress"))
+	if !ok {
+		t.Fatal("expected count to be converted")
+	}
+	if len(cc.Reasons) > 0 {
+		t.Fatalf("unexpected reasons: %q", cc.Reasons)
+	}
+
+	expectedCfg := `locals {
+  ports = [80, 443]
+}
+
+resource "aws_security_group_rule" "ingress" {
+  for_each = toset([for v in local.ports : tostring(v)])
+  from_port = each.value
+}
+
+moved {
+  from = aws_security_group_rule.ingress[0]
+  to   = aws_security_group_rule.ingress["80"]
+}
+
+moved {
+  from = aws_security_group_rule.ingress[1]
+  to   = aws_security_group_rule.ingress["443"]
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), cc.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
+
+func TestConvertCountToForEach_unknownKeys(t *testing.T) {
+	cfg := `module "vpc" {
+  source = "./vpc"
+  count  = var.create ? 1 : 0
+  name   = "vpc-${count.index}"
+}
+
+output "id" {
+  value = module.vpc[0].id
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	cc, ok := ConvertCountToForEach(pathCtx, "main.tf", selection(cfg, "vpc"))
+	if !ok {
+		t.Fatal("expected count to be converted")
+	}
+
+	expectedCfg := `module "vpc" {
+  source = "./vpc"
+  for_each = { for i in range(var.create ? 1 : 0) : tostring(i) => i }
+  name   = "vpc-${each.value}"
+}
+
+# TODO: Review references and existing instances, as the conversion is incomplete:
+#  - the number of instances is not known statically, so no moved blocks were generated
+#  - module.vpc[0].id at main.tf:8 refers to an instance by index
+
+output "id" {
+  value = module.vpc[0].id
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), cc.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
+
+func TestConvertCountToForEach_invalidCount(t *testing.T) {
+	for _, count := range []string{"-1", "1.5", "local.negative"} {
+		t.Run(count, func(t *testing.T) {
+			cfg := `locals {
+  negative = -3
+}
+
+resource "aws_instance" "web" {
+  count = ` + count + `
+}
+`
+			pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+			_, ok := ConvertCountToForEach(pathCtx, "main.tf", selection(cfg, "web"))
+			if ok {
+				t.Fatal("expected invalid count not to be converted")
+			}
+		})
+	}
+}
+
+func TestConvertCountToForEach_largeCount(t *testing.T) {
+	cfg := `resource "aws_instance" "web" {
+  count = 1e+100
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	cc, ok := ConvertCountToForEach(pathCtx, "main.tf", selection(cfg, "web"))
+	if !ok {
+		t.Fatal("expected count to be converted")
+	}
+
+	expectedCfg := `resource "aws_instance" "web" {
+  for_each = { for i in range(1e+100) : tostring(i) => i }
+}
+
+# TODO: Review references and existing instances, as the conversion is incomplete:
+#  - there are more than 100 instances, so no moved blocks were generated
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), cc.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
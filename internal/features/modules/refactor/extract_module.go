@@ -0,0 +1,302 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// nonValueRoots are roots of references which are only valid
+// within the block they are used in and never become module inputs
+var nonValueRoots = map[string]bool{
+	"count":     true,
+	"each":      true,
+	"self":      true,
+	"path":      true,
+	"terraform": true,
+}
+
+// ExtractedModule describes a module created by [ExtractModule]
+type ExtractedModule struct {
+	Name string
+	// Dir is the directory of the new module relative to the module
+	Dir string
+	// Blocks are addresses of the blocks moved into the new module
+	Blocks []string
+
+	Changes
+}
+
+// ExtractModule moves resource and data blocks of the given file which
+// overlap with rng into a new module under modules/ and replaces them
+// with a module block calling it.
+//
+// References within the blocks to anything outside of them become
+// input variables and references from outside to the blocks become
+// outputs. Moved blocks are generated for all resources, so that
+// they are not recreated.
+//
+// Meta-arguments referring to other objects, i.e. provider, depends_on
+// and lifecycle, are left as they are and may need manual changes.
+func ExtractModule(pathCtx *decoder.PathContext, filename string, rng hcl.Range, takenNames map[string]bool) (ExtractedModule, bool) {
+	em := ExtractedModule{
+		Blocks:  make([]string, 0),
+		Changes: newChanges(),
+	}
+
+	f, ok := pathCtx.Files[filename]
+	if !ok {
+		return em, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return em, false
+	}
+
+	selected := make([]*hclsyntax.Block, 0)
+	addrs := make([]lang.Address, 0)
+	for _, block := range body.Blocks {
+		if block.Type != "resource" && block.Type != "data" {
+			continue
+		}
+		addr, ok := blockAddress(block)
+		if !ok || !overlaps(block.Range(), rng) {
+			continue
+		}
+		selected = append(selected, block)
+		addrs = append(addrs, addr)
+		em.Blocks = append(em.Blocks, addr.String())
+	}
+	if len(selected) == 0 {
+		return em, false
+	}
+
+	taken := declaredModuleNames(pathCtx.Files)
+	for name := range takenNames {
+		taken[name] = true
+	}
+	em.Name = uniqueName(sanitizeIdentifier(selected[0].Labels[1]), taken)
+	em.Dir = path.Join("modules", em.Name)
+
+	isSelected := func(rng hcl.Range) bool {
+		for _, block := range selected {
+			if containsRange(block.Range(), rng) {
+				return true
+			}
+		}
+		return false
+	}
+	pointsToSelected := func(addr lang.Address) bool {
+		for _, blockAddr := range addrs {
+			if hasPrefix(addr, blockAddr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	metaArgs := make([]hcl.Range, 0)
+	for _, block := range selected {
+		metaArgs = append(metaArgs, metaArgumentRanges(block)...)
+	}
+	dependsOn := make([]hcl.Range, 0)
+	lifecycle := make([]hcl.Range, 0)
+	for _, f := range pathCtx.Files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if attr, ok := block.Body.Attributes["depends_on"]; ok {
+				dependsOn = append(dependsOn, attr.SrcRange)
+			}
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "lifecycle" {
+					lifecycle = append(lifecycle, nested.Range())
+				}
+			}
+		}
+	}
+
+	inputs := make(map[string]string)
+	inputNames := make(map[string]string)
+	outputs := make(map[string]string)
+	outputNames := make(map[string]string)
+	innerEdits := make([]Edit, 0)
+
+	for _, origin := range localOrigins(pathCtx.ReferenceOrigins) {
+		inside := isSelected(origin.Range)
+		toSelected := pointsToSelected(origin.Addr)
+
+		switch {
+		case inside && !toSelected:
+			if nonValueRoots[origin.Addr[0].String()] || withinAny(metaArgs, origin.Range) {
+				continue
+			}
+			text := rangeText(pathCtx.Files, origin.Range)
+			name, ok := inputNames[text]
+			if !ok {
+				name = uniqueName(inputName(origin.Addr), mapKeys(inputs))
+				inputNames[text] = name
+				inputs[name] = text
+			}
+			innerEdits = append(innerEdits, Edit{
+				Range:   origin.Range,
+				NewText: "var." + name,
+			})
+		case !inside && toSelected:
+			if withinAny(dependsOn, origin.Range) {
+				em.addEdit(origin.Range, "module."+em.Name)
+				continue
+			}
+			if withinAny(lifecycle, origin.Range) {
+				continue
+			}
+			text := rangeText(pathCtx.Files, origin.Range)
+			name, ok := outputNames[text]
+			if !ok {
+				name = uniqueName(identifier(origin.Addr), mapKeys(outputs))
+				outputNames[text] = name
+				outputs[name] = text
+			}
+			em.addEdit(origin.Range, fmt.Sprintf("module.%s.%s", em.Name, name))
+		}
+	}
+
+	blockTexts := make([]string, 0, len(selected))
+	for _, block := range selected {
+		blockTexts = append(blockTexts, applyEdits(f.Bytes, block.Range(), innerEdits))
+	}
+	em.NewFiles[path.Join(em.Dir, "main.tf")] = []byte(strings.Join(blockTexts, "\n\n") + "\n")
+	em.NewFiles[path.Join(em.Dir, "variables.tf")] = variablesConfig(inputs)
+	em.NewFiles[path.Join(em.Dir, "outputs.tf")] = outputsConfig(outputs)
+
+	em.addEdit(selected[0].Range(), moduleCallConfig(em.Name, "./"+em.Dir, inputs, addrs))
+	for _, block := range selected[1:] {
+		em.addEdit(removalRange(f.Bytes, block.Range()), "")
+	}
+
+	return em, true
+}
+
+// moduleCallConfig returns the module block calling the extracted module,
+// followed by moved blocks for all extracted resources
+func moduleCallConfig(name, source string, inputs map[string]string, addrs []lang.Address) string {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "module %q {\n  source = %q\n", name, source)
+	if len(inputs) > 0 {
+		buf.WriteString("\n")
+		for _, input := range sortedKeys(inputs) {
+			fmt.Fprintf(&buf, "  %s = %s\n", input, inputs[input])
+		}
+	}
+	buf.WriteString("}")
+
+	for _, addr := range addrs {
+		if addr[0].String() == "data" {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n\nmoved {\n  from = %s\n  to = module.%s.%s\n}", addr, name, addr)
+	}
+
+	return string(hclwrite.Format(buf.Bytes()))
+}
+
+func variablesConfig(inputs map[string]string) []byte {
+	blocks := make([]string, 0, len(inputs))
+	for _, name := range sortedKeys(inputs) {
+		blocks = append(blocks, fmt.Sprintf("variable %q {}\n", name))
+	}
+	return []byte(strings.Join(blocks, "\n"))
+}
+
+func outputsConfig(outputs map[string]string) []byte {
+	blocks := make([]string, 0, len(outputs))
+	for _, name := range sortedKeys(outputs) {
+		blocks = append(blocks, fmt.Sprintf("output %q {\n  value = %s\n}\n", name, outputs[name]))
+	}
+	return []byte(strings.Join(blocks, "\n"))
+}
+
+// inputName returns the name of the variable replacing the reference,
+// e.g. vpc_id for var.vpc_id or aws_vpc_main_id for aws_vpc.main.id
+func inputName(addr lang.Address) string {
+	switch addr[0].String() {
+	case "var", "local":
+		if len(addr) > 1 {
+			return identifier(addr[1:])
+		}
+	}
+	return identifier(addr)
+}
+
+// metaArgumentRanges returns ranges of meta-arguments of the block
+// which refer to other objects by address rather than by value
+func metaArgumentRanges(block *hclsyntax.Block) []hcl.Range {
+	ranges := make([]hcl.Range, 0)
+	for _, name := range []string{"provider", "depends_on"} {
+		if attr, ok := block.Body.Attributes[name]; ok {
+			ranges = append(ranges, attr.SrcRange)
+		}
+	}
+	for _, nested := range block.Body.Blocks {
+		if nested.Type == "lifecycle" {
+			ranges = append(ranges, nested.Range())
+		}
+	}
+	return ranges
+}
+
+func declaredModuleNames(files map[string]*hcl.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, f := range files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type == "module" && len(block.Labels) == 1 {
+				names[block.Labels[0]] = true
+			}
+		}
+	}
+	return names
+}
+
+func withinAny(ranges []hcl.Range, rng hcl.Range) bool {
+	for _, r := range ranges {
+		if containsRange(r, rng) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapKeys(m map[string]string) map[string]bool {
+	keys := make(map[string]bool, len(m))
+	for key := range m {
+		keys[key] = true
+	}
+	return keys
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
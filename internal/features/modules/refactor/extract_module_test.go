@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestExtractModule(t *testing.T) {
+	cfg := `variable "ami" {}
+
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_instance" "web" {
+  ami       = var.ami
+  subnet_id = aws_vpc.main.default_subnet_id
+  tags = {
+    Name = "web-${var.ami}"
+  }
+}
+
+resource "aws_eip" "web" {
+  instance = aws_instance.web.id
+}
+
+output "ip" {
+  value = aws_eip.web.public_ip
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	em, ok := ExtractModule(pathCtx, "main.tf", hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 9, Column: 1, Byte: 100},
+		End:      hcl.Pos{Line: 16, Column: 1, Byte: 230},
+	}, map[string]bool{})
+	if !ok {
+		t.Fatal("expected blocks to be extracted")
+	}
+
+	if em.Name != "web" || em.Dir != "modules/web" {
+		t.Fatalf("unexpected module: %q in %q", em.Name, em.Dir)
+	}
+	if diff := cmp.Diff([]string{"aws_instance.web", "aws_eip.web"}, em.Blocks); diff != "" {
+		t.Fatalf("unexpected blocks: %s", diff)
+	}
+
+	expectedFiles := map[string]string{
+		"modules/web/main.tf": `resource "aws_instance" "web" {
+  ami       = var.ami
+  subnet_id = var.aws_vpc_main_default_subnet_id
+  tags = {
+    Name = "web-${var.ami}"
+  }
+}
+
+resource "aws_eip" "web" {
+  instance = aws_instance.web.id
+}
+`,
+		"modules/web/variables.tf": `variable "ami" {}
+
+variable "aws_vpc_main_default_subnet_id" {}
+`,
+		"modules/web/outputs.tf": `output "aws_eip_web_public_ip" {
+  value = aws_eip.web.public_ip
+}
+`,
+	}
+	files := make(map[string]string)
+	for name, src := range em.NewFiles {
+		files[name] = string(src)
+	}
+	if diff := cmp.Diff(expectedFiles, files); diff != "" {
+		t.Fatalf("unexpected files: %s", diff)
+	}
+
+	src := []byte(cfg)
+	edits := em.Edits["main.tf"]
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Range.Start.Byte > edits[j].Range.Start.Byte
+	})
+	for _, edit := range edits {
+		src = append(src[:edit.Range.Start.Byte:edit.Range.Start.Byte],
+			append([]byte(edit.NewText), src[edit.Range.End.Byte:]...)...)
+	}
+
+	expectedCfg := `variable "ami" {}
+
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+
+module "web" {
+  source = "./modules/web"
+
+  ami                            = var.ami
+  aws_vpc_main_default_subnet_id = aws_vpc.main.default_subnet_id
+}
+
+moved {
+  from = aws_instance.web
+  to   = module.web.aws_instance.web
+}
+
+moved {
+  from = aws_eip.web
+  to   = module.web.aws_eip.web
+}
+
+output "ip" {
+  value = module.web.aws_eip_web_public_ip
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(src)); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
+
+func TestExtractModule_noBlocks(t *testing.T) {
+	pathCtx := testPathContext(t, map[string]string{"main.tf": `variable "ami" {}
+`})
+
+	_, ok := ExtractModule(pathCtx, "main.tf", hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.InitialPos,
+		End:      hcl.Pos{Line: 1, Column: 5, Byte: 4},
+	}, map[string]bool{})
+	if ok {
+		t.Fatal("expected no blocks to be extracted")
+	}
+}
+
+// testPathContext parses the files and turns all traversals
+// into reference origins, similar to the decoder
+func testPathContext(t *testing.T, cfgs map[string]string) *decoder.PathContext {
+	t.Helper()
+
+	pathCtx := &decoder.PathContext{
+		Files:            make(map[string]*hcl.File),
+		ReferenceOrigins: make(reference.Origins, 0),
+	}
+	for filename, cfg := range cfgs {
+		f, diags := hclsyntax.ParseConfig([]byte(cfg), filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		pathCtx.Files[filename] = f
+
+		hclsyntax.VisitAll(f.Body.(*hclsyntax.Body), func(node hclsyntax.Node) hcl.Diagnostics {
+			expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+			if !ok {
+				return nil
+			}
+			addr, err := lang.TraversalToAddress(expr.Traversal)
+			if err != nil {
+				return nil
+			}
+			pathCtx.ReferenceOrigins = append(pathCtx.ReferenceOrigins, reference.LocalOrigin{
+				Addr:  addr,
+				Range: expr.SrcRange,
+			})
+			return nil
+		})
+	}
+	return pathCtx
+}
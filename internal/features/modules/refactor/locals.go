@@ -0,0 +1,297 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ExtractLocal turns the innermost expression of the given file
+// containing rng into a new local value, named after the attribute
+// the expression is assigned to, and replaces the expression and all
+// identical expressions in the module with a reference to it.
+//
+// Expressions referring to values which only exist within their block,
+// such as count, each, self or iterators, cannot be extracted. Neither
+// can expressions in contexts where Terraform doesn't allow references,
+// such as variable defaults, module sources or lifecycle arguments.
+func ExtractLocal(pathCtx *decoder.PathContext, filename string, rng hcl.Range) (Changes, string, bool) {
+	changes := newChanges()
+
+	f, ok := pathCtx.Files[filename]
+	if !ok {
+		return changes, "", false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return changes, "", false
+	}
+
+	var selected *exprInfo
+	for _, info := range expressions(body) {
+		if !containsRange(info.expr.Range(), rng) || info.block == nil {
+			continue
+		}
+		if _, ok := info.parent.(*hclsyntax.TemplateExpr); ok {
+			// parts of a template, such as its literal strings,
+			// are not expressions on their own
+			continue
+		}
+		info := info
+		selected = &info
+	}
+	if selected == nil || selected.static || selected.referencesScoped() || isLocalReference(selected.expr) {
+		return changes, "", false
+	}
+
+	existing := localNames(pathCtx.Files)
+	baseName := selected.attrName
+	if baseName == "" {
+		baseName = "value"
+	}
+	name := uniqueName(sanitizeIdentifier(baseName), existing)
+
+	text := rangeText(pathCtx.Files, selected.expr.Range())
+	wanted := normalizedText(f.Bytes, selected.expr.Range())
+
+	for _, filename := range sortedFilenames(pathCtx.Files) {
+		f := pathCtx.Files[filename]
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		replaced := make([]hcl.Range, 0)
+		for _, info := range expressions(body) {
+			exprRng := info.expr.Range()
+			if info.block == nil || info.static || withinAny(replaced, exprRng) {
+				continue
+			}
+			if _, ok := info.parent.(*hclsyntax.TemplateExpr); ok {
+				continue
+			}
+			if normalizedText(f.Bytes, exprRng) != wanted || info.referencesScoped() {
+				continue
+			}
+			replaced = append(replaced, exprRng)
+			changes.addEdit(exprRng, "local."+name)
+		}
+	}
+
+	changes.addEdit(localInsertion(body, selected.block, name, text))
+
+	return changes, name, true
+}
+
+// localInsertion returns where and how to declare a new local value
+// in the file, i.e. at the end of its first locals block, or in a new
+// locals block preceding the given block
+func localInsertion(body *hclsyntax.Body, before *hclsyntax.Block, name, text string) (hcl.Range, string) {
+	for _, block := range body.Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+
+		attrs := sortedAttributes(block.Body)
+		if len(attrs) == 0 {
+			pos := block.OpenBraceRange.End
+			return hcl.Range{Filename: block.OpenBraceRange.Filename, Start: pos, End: pos},
+				fmt.Sprintf("\n  %s = %s", name, text)
+		}
+
+		last := attrs[len(attrs)-1].SrcRange
+		indent := strings.Repeat(" ", last.Start.Column-1)
+		return hcl.Range{Filename: last.Filename, Start: last.End, End: last.End},
+			fmt.Sprintf("\n%s%s = %s", indent, name, text)
+	}
+
+	start := before.Range().Start
+	return hcl.Range{Filename: before.Range().Filename, Start: start, End: start},
+		fmt.Sprintf("locals {\n  %s = %s\n}\n\n", name, text)
+}
+
+// InlineLocal replaces all references to the local value declared or
+// referenced at the given range with its expression and removes
+// the declaration
+func InlineLocal(pathCtx *decoder.PathContext, filename string, rng hcl.Range) (Changes, string, bool) {
+	changes := newChanges()
+
+	name, ok := localAt(pathCtx, filename, rng)
+	if !ok {
+		return changes, "", false
+	}
+
+	attr, block, ok := localDeclaration(pathCtx.Files, name)
+	if !ok {
+		return changes, "", false
+	}
+	for _, traversal := range attr.Expr.Variables() {
+		if traversal.RootName() == "local" && len(traversal) > 1 {
+			if step, ok := traversal[1].(hcl.TraverseAttr); ok && step.Name == name {
+				// self-referencing local values are invalid anyway
+				return changes, "", false
+			}
+		}
+	}
+
+	text := rangeText(pathCtx.Files, attr.Expr.Range())
+	prefix := "local." + name
+	localAddr := lang.Address{
+		lang.RootStep{Name: "local"},
+		lang.AttrStep{Name: name},
+	}
+
+	for _, origin := range localOrigins(pathCtx.ReferenceOrigins) {
+		if !hasPrefix(origin.Addr, localAddr) {
+			continue
+		}
+		originText := rangeText(pathCtx.Files, origin.Range)
+		if !strings.HasPrefix(originText, prefix) {
+			continue
+		}
+
+		replacement := text
+		if needsParentheses(attr.Expr, len(originText) > len(prefix)) {
+			replacement = "(" + text + ")"
+		}
+
+		end := origin.Range.Start
+		end.Byte += len(prefix)
+		end.Column += len(prefix)
+		changes.addEdit(hcl.Range{
+			Filename: origin.Range.Filename,
+			Start:    origin.Range.Start,
+			End:      end,
+		}, replacement)
+	}
+
+	src := pathCtx.Files[block.Range().Filename].Bytes
+	if len(block.Body.Attributes) == 1 {
+		changes.addEdit(removalRange(src, block.Range()), "")
+	} else {
+		changes.addEdit(removalRange(src, lineRange(src, attr.SrcRange)), "")
+	}
+
+	return changes, name, true
+}
+
+// localAt returns the name of the local value referenced
+// or declared at the given range
+func localAt(pathCtx *decoder.PathContext, filename string, rng hcl.Range) (string, bool) {
+	for _, origin := range localOrigins(pathCtx.ReferenceOrigins) {
+		if origin.Range.Filename != filename || !overlaps(origin.Range, rng) {
+			continue
+		}
+		if len(origin.Addr) > 1 && origin.Addr[0].String() == "local" {
+			if step, ok := origin.Addr[1].(lang.AttrStep); ok {
+				return step.Name, true
+			}
+		}
+	}
+
+	f, ok := pathCtx.Files[filename]
+	if !ok {
+		return "", false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return "", false
+	}
+	for _, block := range body.Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+		for _, attr := range block.Body.Attributes {
+			if overlaps(attr.NameRange, rng) {
+				return attr.Name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func localDeclaration(files map[string]*hcl.File, name string) (*hclsyntax.Attribute, *hclsyntax.Block, bool) {
+	for _, f := range files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+			if attr, ok := block.Body.Attributes[name]; ok {
+				return attr, block, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+func localNames(files map[string]*hcl.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, f := range files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "locals" {
+				continue
+			}
+			for name := range block.Body.Attributes {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+func isLocalReference(expr hclsyntax.Expression) bool {
+	traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	return ok && traversal.Traversal.RootName() == "local"
+}
+
+// needsParentheses reports whether the expression has to be wrapped
+// in parentheses to keep its meaning when replacing a reference,
+// which may be followed by further traversal steps
+func needsParentheses(expr hclsyntax.Expression, traversed bool) bool {
+	switch expr.(type) {
+	case *hclsyntax.BinaryOpExpr, *hclsyntax.UnaryOpExpr, *hclsyntax.ConditionalExpr:
+		return true
+	case *hclsyntax.ScopeTraversalExpr, *hclsyntax.RelativeTraversalExpr, *hclsyntax.IndexExpr,
+		*hclsyntax.FunctionCallExpr, *hclsyntax.TupleConsExpr, *hclsyntax.ObjectConsExpr,
+		*hclsyntax.ParenthesesExpr, *hclsyntax.LiteralValueExpr, *hclsyntax.TemplateExpr:
+		return false
+	}
+	return traversed
+}
+
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+	return attrs
+}
+
+func sortedFilenames(files map[string]*hcl.File) []string {
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
@@ -0,0 +1,380 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestExtractLocal(t *testing.T) {
+	cfg := `resource "aws_instance" "web" {
+  ami  = var.ami
+  tags = merge(var.tags, { Name = "web" })
+}
+
+resource "aws_instance" "db" {
+  count = 2
+  tags  = merge(var.tags,{Name="web"})
+  name  = "db-${count.index}"
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	changes, name, ok := ExtractLocal(pathCtx, "main.tf", selection(cfg, `merge(var.tags, { Name = "web" })`))
+	if !ok {
+		t.Fatal("expected expression to be extracted")
+	}
+	if name != "tags" {
+		t.Fatalf("unexpected name: %q", name)
+	}
+
+	expectedCfg := `locals {
+  tags = merge(var.tags, { Name = "web" })
+}
+
+resource "aws_instance" "web" {
+  ami  = var.ami
+  tags = local.tags
+}
+
+resource "aws_instance" "db" {
+  count = 2
+  tags  = local.tags
+  name  = "db-${count.index}"
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), changes.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+
+	_, _, ok = ExtractLocal(pathCtx, "main.tf", selection(cfg, "count.index"))
+	if ok {
+		t.Fatal("expected expression referring to count not to be extracted")
+	}
+}
+
+func TestExtractLocal_existingLocals(t *testing.T) {
+	cfg := `locals {
+  name = "web"
+}
+
+output "ip" {
+  value = cidrhost("10.0.0.0/16", 5)
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	changes, name, ok := ExtractLocal(pathCtx, "main.tf", selection(cfg, "cidrhost"))
+	if !ok {
+		t.Fatal("expected expression to be extracted")
+	}
+	if name != "value" {
+		t.Fatalf("unexpected name: %q", name)
+	}
+
+	expectedCfg := `locals {
+  name = "web"
+  value = cidrhost("10.0.0.0/16", 5)
+}
+
+output "ip" {
+  value = local.value
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), changes.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
+
+// staticContextTestCases are configurations where Terraform doesn't
+// allow references, along with a value within that context
+var staticContextTestCases = []struct {
+	name  string
+	cfg   string
+	value string
+}{
+	{
+		"variable default",
+		`variable "region" {
+  default = "us-east-1"
+}
+`,
+		`"us-east-1"`,
+	},
+	{
+		"variable type",
+		`variable "tags" {
+  type = map(string)
+}
+`,
+		`map(string)`,
+	},
+	{
+		"variable validation",
+		`variable "region" {
+  validation {
+    condition     = var.region != "us-east-1"
+    error_message = "Unsupported region."
+  }
+}
+`,
+		`"us-east-1"`,
+	},
+	{
+		"required_version",
+		`terraform {
+  required_version = ">= 1.5.0"
+}
+`,
+		`">= 1.5.0"`,
+	},
+	{
+		"required_providers",
+		`terraform {
+  required_providers {
+    aws = {
+      version = "~> 5.0"
+    }
+  }
+}
+`,
+		`"~> 5.0"`,
+	},
+	{
+		"backend",
+		`terraform {
+  backend "s3" {
+    region = "us-east-1"
+  }
+}
+`,
+		`"us-east-1"`,
+	},
+	{
+		"cloud",
+		`terraform {
+  cloud {
+    workspaces {
+      name = "prod"
+    }
+  }
+}
+`,
+		`"prod"`,
+	},
+	{
+		"module source",
+		`module "vpc" {
+  source = "./vpc"
+}
+`,
+		`"./vpc"`,
+	},
+	{
+		"module version",
+		`module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 5.0"
+}
+`,
+		`"~> 5.0"`,
+	},
+	{
+		"module providers",
+		`module "vpc" {
+  source = "./vpc"
+  providers = {
+    aws = aws.west
+  }
+}
+`,
+		`aws.west`,
+	},
+	{
+		"depends_on",
+		`output "id" {
+  value      = "id"
+  depends_on = [aws_vpc.main]
+}
+`,
+		`[aws_vpc.main]`,
+	},
+	{
+		"lifecycle",
+		`resource "aws_instance" "web" {
+  lifecycle {
+    ignore_changes = ["tags"]
+  }
+}
+`,
+		`["tags"]`,
+	},
+	{
+		"moved",
+		`moved {
+  from = aws_instance.old
+  to   = aws_instance.new
+}
+`,
+		`aws_instance.old`,
+	},
+	{
+		"import",
+		`import {
+  to = aws_instance.imported
+  id = "i-123"
+}
+`,
+		`aws_instance.imported`,
+	},
+	{
+		"removed",
+		`removed {
+  from = aws_instance.old
+}
+`,
+		`aws_instance.old`,
+	},
+}
+
+func TestExtractLocal_staticContexts(t *testing.T) {
+	for _, tc := range staticContextTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the same value assigned to a resource argument
+			// follows the static context
+			cfg := fmt.Sprintf("%s\nresource \"aws_instance\" \"other\" {\n  other = %s\n}\n", tc.cfg, tc.value)
+			pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+			_, _, ok := ExtractLocal(pathCtx, "main.tf", selection(cfg, tc.value))
+			if ok {
+				t.Fatalf("expected %s not to be extracted", tc.value)
+			}
+
+			changes, _, ok := ExtractLocal(pathCtx, "main.tf", lastSelection(cfg, tc.value))
+			if !ok {
+				t.Fatalf("expected %s to be extracted from resource", tc.value)
+			}
+			given := string(ApplyEdits([]byte(cfg), changes.Edits["main.tf"]))
+			if !strings.Contains(given, tc.cfg) {
+				t.Fatalf("expected static context to be left intact, given:\n%s", given)
+			}
+			if !strings.Contains(given, "other = local.other") {
+				t.Fatalf("expected resource argument to be replaced, given:\n%s", given)
+			}
+		})
+	}
+}
+
+func TestInlineLocal(t *testing.T) {
+	cfg := `locals {
+  prefix = "app"
+  size   = var.base * 2
+}
+
+resource "aws_instance" "web" {
+  name = "${local.prefix}-web"
+  size = local.size
+}
+
+output "size" {
+  value = local.size + 1
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	changes, name, ok := InlineLocal(pathCtx, "main.tf", selection(cfg, "size   ="))
+	if !ok {
+		t.Fatal("expected local to be inlined")
+	}
+	if name != "size" {
+		t.Fatalf("unexpected name: %q", name)
+	}
+
+	expectedCfg := `locals {
+  prefix = "app"
+}
+
+resource "aws_instance" "web" {
+  name = "${local.prefix}-web"
+  size = (var.base * 2)
+}
+
+output "size" {
+  value = (var.base * 2) + 1
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), changes.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+
+	changes, _, ok = InlineLocal(pathCtx, "main.tf", selection(cfg, "local.prefix"))
+	if !ok {
+		t.Fatal("expected local to be inlined")
+	}
+
+	expectedCfg = `locals {
+  size   = var.base * 2
+}
+
+resource "aws_instance" "web" {
+  name = "${"app"}-web"
+  size = local.size
+}
+
+output "size" {
+  value = local.size + 1
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), changes.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
+
+// selection returns the range of the first occurrence of text in cfg
+// lastSelection returns the range of the last occurrence of text in cfg
+func lastSelection(cfg, text string) hcl.Range {
+	idx := strings.LastIndex(cfg, text)
+	start := hcl.Pos{
+		Line:   strings.Count(cfg[:idx], "\n") + 1,
+		Column: idx - strings.LastIndex(cfg[:idx], "\n"),
+		Byte:   idx,
+	}
+	end := start
+	end.Byte += len(text)
+	end.Column += len(text)
+	return hcl.Range{
+		Filename: "main.tf",
+		Start:    start,
+		End:      end,
+	}
+}
+
+func selection(cfg, text string) hcl.Range {
+	start := hcl.InitialPos
+	for i := 0; i < len(cfg); i++ {
+		if cfg[i:min(i+len(text), len(cfg))] == text {
+			break
+		}
+		if cfg[i] == '\n' {
+			start.Line++
+			start.Column = 1
+		} else {
+			start.Column++
+		}
+		start.Byte++
+	}
+	end := start
+	end.Byte += len(text)
+	end.Column += len(text)
+	return hcl.Range{
+		Filename: "main.tf",
+		Start:    start,
+		End:      end,
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfregistry "github.com/hashicorp/terraform-schema/registry"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// moduleMetaArguments are arguments of a module block,
+// which are not passed to the module as inputs
+var moduleMetaArguments = map[string]bool{
+	"source":     true,
+	"version":    true,
+	"count":      true,
+	"for_each":   true,
+	"providers":  true,
+	"depends_on": true,
+}
+
+// RegistryModuleCall describes a call of a registry module
+// with a version constraint, as found by [RegistryModuleCallAt]
+type RegistryModuleCall struct {
+	Name    string
+	Source  tfaddr.Module
+	Version version.Constraints
+	// Inputs are names of the arguments passed to the module
+	Inputs []string
+	// Outputs are names of the outputs of the module
+	// referenced elsewhere in the calling module
+	Outputs []string
+
+	rawVersion  string
+	versionExpr hcl.Range
+}
+
+// RegistryModuleCallAt returns the call of a registry module
+// of the given file at rng, if the call declares a version
+func RegistryModuleCallAt(pathCtx *decoder.PathContext, filename string, rng hcl.Range) (RegistryModuleCall, bool) {
+	f, ok := pathCtx.Files[filename]
+	if !ok {
+		return RegistryModuleCall{}, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return RegistryModuleCall{}, false
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "module" || len(block.Labels) != 1 || !overlaps(block.Range(), rng) {
+			continue
+		}
+
+		source, ok := literalString(block.Body.Attributes["source"])
+		if !ok {
+			return RegistryModuleCall{}, false
+		}
+		addr, err := tfaddr.ParseModuleSource(source)
+		if err != nil {
+			return RegistryModuleCall{}, false
+		}
+		rawVersion, ok := literalString(block.Body.Attributes["version"])
+		if !ok {
+			return RegistryModuleCall{}, false
+		}
+		cons, err := version.NewConstraint(rawVersion)
+		if err != nil {
+			return RegistryModuleCall{}, false
+		}
+
+		inputs := make([]string, 0)
+		for name := range block.Body.Attributes {
+			if !moduleMetaArguments[name] {
+				inputs = append(inputs, name)
+			}
+		}
+		sort.Strings(inputs)
+
+		return RegistryModuleCall{
+			Name:        block.Labels[0],
+			Source:      addr,
+			Version:     cons,
+			Inputs:      inputs,
+			Outputs:     referencedOutputs(pathCtx, block.Labels[0]),
+			rawVersion:  rawVersion,
+			versionExpr: block.Body.Attributes["version"].Expr.Range(),
+		}, true
+	}
+
+	return RegistryModuleCall{}, false
+}
+
+// referencedOutputs returns sorted names of outputs
+// of the named module call referenced in the module
+func referencedOutputs(pathCtx *decoder.PathContext, name string) []string {
+	callAddr := lang.Address{
+		lang.RootStep{Name: "module"},
+		lang.AttrStep{Name: name},
+	}
+
+	seen := make(map[string]bool)
+	outputs := make([]string, 0)
+	for _, origin := range localOrigins(pathCtx.ReferenceOrigins) {
+		if !hasPrefix(origin.Addr, callAddr) {
+			continue
+		}
+		for _, step := range origin.Addr[len(callAddr):] {
+			if _, ok := step.(lang.IndexStep); ok {
+				// skip instance keys of count and for_each
+				continue
+			}
+			if attr, ok := step.(lang.AttrStep); ok && !seen[attr.Name] {
+				seen[attr.Name] = true
+				outputs = append(outputs, attr.Name)
+			}
+			break
+		}
+	}
+	sort.Strings(outputs)
+	return outputs
+}
+
+// BumpModuleVersion changes the version constraint of the module call
+// to allow the given version. A single constraint keeps its operator
+// and precision, e.g. ~> 5.1 becomes ~> 6.2 for 6.2.0, any other
+// constraint is replaced by the exact version.
+func BumpModuleVersion(mc RegistryModuleCall, v *version.Version) Changes {
+	changes := newChanges()
+
+	newVersion := v.String()
+	if len(mc.Version) == 1 {
+		raw := strings.TrimSpace(mc.rawVersion)
+		rawVersion := strings.TrimLeft(raw, "=!<>~ ")
+		operator := strings.TrimSpace(raw[:len(raw)-len(rawVersion)])
+		switch operator {
+		case "", "=", "~>", ">=":
+			newVersion = versionWithPrecision(v, rawVersion)
+			if operator != "" {
+				newVersion = operator + " " + newVersion
+			}
+		}
+	}
+
+	changes.addEdit(mc.versionExpr, fmt.Sprintf("%q", newVersion))
+	return changes
+}
+
+// versionWithPrecision formats the version with the same
+// number of segments as the original version string
+func versionWithPrecision(v *version.Version, original string) string {
+	segments := v.Segments()
+	precision := len(strings.Split(strings.SplitN(original, "-", 2)[0], "."))
+	if precision >= len(segments) {
+		return v.String()
+	}
+	parts := make([]string, 0, precision)
+	for _, s := range segments[:precision] {
+		parts = append(parts, fmt.Sprintf("%d", s))
+	}
+	return strings.Join(parts, ".")
+}
+
+// RemovedModuleInterface returns inputs passed by the module call
+// and outputs referenced by the calling module, which are declared
+// by the current version of the module (oldData), but no longer
+// declared by the new version (newData)
+func RemovedModuleInterface(mc RegistryModuleCall, oldData, newData *tfregistry.ModuleData) ([]string, []string) {
+	oldInputs := make(map[string]bool, len(oldData.Inputs))
+	for _, input := range oldData.Inputs {
+		oldInputs[input.Name] = true
+	}
+	newInputs := make(map[string]bool, len(newData.Inputs))
+	for _, input := range newData.Inputs {
+		newInputs[input.Name] = true
+	}
+	oldOutputs := make(map[string]bool, len(oldData.Outputs))
+	for _, output := range oldData.Outputs {
+		oldOutputs[output.Name] = true
+	}
+	newOutputs := make(map[string]bool, len(newData.Outputs))
+	for _, output := range newData.Outputs {
+		newOutputs[output.Name] = true
+	}
+
+	removedInputs := make([]string, 0)
+	for _, name := range mc.Inputs {
+		if oldInputs[name] && !newInputs[name] {
+			removedInputs = append(removedInputs, name)
+		}
+	}
+	removedOutputs := make([]string, 0)
+	for _, name := range mc.Outputs {
+		if oldOutputs[name] && !newOutputs[name] {
+			removedOutputs = append(removedOutputs, name)
+		}
+	}
+
+	return removedInputs, removedOutputs
+}
+
+func literalString(attr *hclsyntax.Attribute) (string, bool) {
+	if attr == nil {
+		return "", false
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+		return "", false
+	}
+	return val.AsString(), true
+}
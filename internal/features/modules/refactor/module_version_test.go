@@ -0,0 +1,126 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	tfregistry "github.com/hashicorp/terraform-schema/registry"
+)
+
+func TestRegistryModuleCallAt(t *testing.T) {
+	cfg := `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 5.1"
+  count   = 2
+
+  name = "main"
+  cidr = "10.0.0.0/16"
+}
+
+module "local" {
+  source = "./local"
+}
+
+output "ids" {
+  value = [module.vpc[0].vpc_id, module.vpc[1].private_subnets, module.vpc[0].vpc_id]
+}
+`
+	pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+
+	mc, ok := RegistryModuleCallAt(pathCtx, "main.tf", selection(cfg, "name"))
+	if !ok {
+		t.Fatal("expected registry module call to be found")
+	}
+	if mc.Name != "vpc" || mc.Source.String() != "registry.terraform.io/terraform-aws-modules/vpc/aws" || mc.Version.String() != "~> 5.1" {
+		t.Fatalf("unexpected module call: %s %s %s", mc.Name, mc.Source, mc.Version)
+	}
+	if diff := cmp.Diff([]string{"cidr", "name"}, mc.Inputs); diff != "" {
+		t.Fatalf("unexpected inputs: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"private_subnets", "vpc_id"}, mc.Outputs); diff != "" {
+		t.Fatalf("unexpected outputs: %s", diff)
+	}
+
+	_, ok = RegistryModuleCallAt(pathCtx, "main.tf", selection(cfg, `"./local"`))
+	if ok {
+		t.Fatal("expected local module call not to be found")
+	}
+}
+
+func TestBumpModuleVersion(t *testing.T) {
+	testCases := []struct {
+		constraint     string
+		expectedConfig string
+	}{
+		{"~> 5.1", `"~> 6.2"`},
+		{"~> 5.1.0", `"~> 6.2.0"`},
+		{"5.1.3", `"6.2.0"`},
+		{"= 5.1.3", `"= 6.2.0"`},
+		{">= 5.0", `">= 6.2"`},
+		{">= 5.0, < 6.0", `"6.2.0"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			cfg := `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "` + tc.constraint + `"
+}
+`
+			pathCtx := testPathContext(t, map[string]string{"main.tf": cfg})
+			mc, ok := RegistryModuleCallAt(pathCtx, "main.tf", selection(cfg, "source"))
+			if !ok {
+				t.Fatal("expected registry module call to be found")
+			}
+
+			changes := BumpModuleVersion(mc, version.Must(version.NewVersion("6.2.0")))
+			expectedCfg := `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = ` + tc.expectedConfig + `
+}
+`
+			if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), changes.Edits["main.tf"]))); diff != "" {
+				t.Fatalf("unexpected config: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRemovedModuleInterface(t *testing.T) {
+	mc := RegistryModuleCall{
+		Inputs:  []string{"cidr", "enable_classiclink", "name"},
+		Outputs: []string{"vpc_id", "vpc_classiclink"},
+	}
+	oldData := &tfregistry.ModuleData{
+		Inputs: []tfregistry.Input{
+			{Name: "cidr"},
+			{Name: "enable_classiclink"},
+			{Name: "name"},
+		},
+		Outputs: []tfregistry.Output{
+			{Name: "vpc_id"},
+			{Name: "vpc_classiclink"},
+		},
+	}
+	newData := &tfregistry.ModuleData{
+		Inputs: []tfregistry.Input{
+			{Name: "cidr"},
+			{Name: "name"},
+		},
+		Outputs: []tfregistry.Output{
+			{Name: "vpc_id"},
+		},
+	}
+
+	inputs, outputs := RemovedModuleInterface(mc, oldData, newData)
+	if diff := cmp.Diff([]string{"enable_classiclink"}, inputs); diff != "" {
+		t.Fatalf("unexpected removed inputs: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"vpc_classiclink"}, outputs); diff != "" {
+		t.Fatalf("unexpected removed outputs: %s", diff)
+	}
+}
@@ -0,0 +1,442 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package refactor implements refactorings of modules based on
+// the decoded reference origins and targets, producing edits
+// which keep references intact.
+package refactor
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Edit represents a replacement of the text within Range
+// of the file named by Range.Filename
+type Edit struct {
+	Range   hcl.Range
+	NewText string
+}
+
+// Changes represents the outcome of a refactoring
+type Changes struct {
+	// Edits are edits of existing files of the module, keyed by filename
+	Edits map[string][]Edit
+	// NewFiles are files to create, keyed by path relative to the module
+	NewFiles map[string][]byte
+}
+
+func newChanges() Changes {
+	return Changes{
+		Edits:    make(map[string][]Edit),
+		NewFiles: make(map[string][]byte),
+	}
+}
+
+func (c Changes) addEdit(rng hcl.Range, newText string) {
+	c.Edits[rng.Filename] = append(c.Edits[rng.Filename], Edit{
+		Range:   rng,
+		NewText: newText,
+	})
+}
+
+// localOrigins returns local reference origins ordered by their position
+func localOrigins(origins reference.Origins) []reference.LocalOrigin {
+	local := make([]reference.LocalOrigin, 0)
+	seen := make(map[hcl.Range]bool)
+	for _, origin := range origins {
+		lo, ok := origin.(reference.LocalOrigin)
+		if !ok || len(lo.Addr) == 0 || seen[lo.Range] {
+			continue
+		}
+		seen[lo.Range] = true
+		local = append(local, lo)
+	}
+	sort.SliceStable(local, func(i, j int) bool {
+		if local[i].Range.Filename != local[j].Range.Filename {
+			return local[i].Range.Filename < local[j].Range.Filename
+		}
+		return local[i].Range.Start.Byte < local[j].Range.Start.Byte
+	})
+	return local
+}
+
+// hasPrefix reports whether addr starts with all steps of prefix
+func hasPrefix(addr, prefix lang.Address) bool {
+	if len(prefix) == 0 || len(addr) < len(prefix) {
+		return false
+	}
+	return addr.FirstSteps(uint(len(prefix))).Equals(prefix)
+}
+
+// blockAddress returns the address of a resource, data or module block
+func blockAddress(block *hclsyntax.Block) (lang.Address, bool) {
+	switch {
+	case block.Type == "resource" && len(block.Labels) == 2:
+		return lang.Address{
+			lang.RootStep{Name: block.Labels[0]},
+			lang.AttrStep{Name: block.Labels[1]},
+		}, true
+	case block.Type == "data" && len(block.Labels) == 2:
+		return lang.Address{
+			lang.RootStep{Name: "data"},
+			lang.AttrStep{Name: block.Labels[0]},
+			lang.AttrStep{Name: block.Labels[1]},
+		}, true
+	case block.Type == "module" && len(block.Labels) == 1:
+		return lang.Address{
+			lang.RootStep{Name: "module"},
+			lang.AttrStep{Name: block.Labels[0]},
+		}, true
+	}
+	return nil, false
+}
+
+// identifier turns the steps of the address into a valid identifier,
+// e.g. aws_instance_web_0_id for aws_instance.web[0].id
+func identifier(addr lang.Address) string {
+	parts := make([]string, 0, len(addr))
+	for _, step := range addr {
+		switch s := step.(type) {
+		case lang.RootStep:
+			parts = append(parts, s.Name)
+		case lang.AttrStep:
+			parts = append(parts, s.Name)
+		case lang.IndexStep:
+			parts = append(parts, indexKey(s.Key))
+		}
+	}
+	return sanitizeIdentifier(strings.Join(parts, "_"))
+}
+
+func indexKey(key cty.Value) string {
+	if !key.IsKnown() || key.IsNull() {
+		return ""
+	}
+	switch key.Type() {
+	case cty.String:
+		return key.AsString()
+	case cty.Number:
+		return key.AsBigFloat().Text('f', -1)
+	}
+	return ""
+}
+
+func sanitizeIdentifier(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	name := strings.Trim(sb.String(), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
+
+// uniqueName returns name, or name with a numeric suffix
+// if it is already taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	return unique
+}
+
+// rangeText returns the source text within the range
+func rangeText(files map[string]*hcl.File, rng hcl.Range) string {
+	f, ok := files[rng.Filename]
+	if !ok || rng.End.Byte > len(f.Bytes) || rng.Start.Byte > rng.End.Byte {
+		return ""
+	}
+	return string(f.Bytes[rng.Start.Byte:rng.End.Byte])
+}
+
+// applyEdits applies edits within rng to the source text of rng
+func applyEdits(src []byte, rng hcl.Range, edits []Edit) string {
+	text := string(src[rng.Start.Byte:rng.End.Byte])
+	sorted := make([]Edit, len(edits))
+	copy(sorted, edits)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte > sorted[j].Range.Start.Byte
+	})
+	for _, edit := range sorted {
+		if !containsRange(rng, edit.Range) {
+			continue
+		}
+		start := edit.Range.Start.Byte - rng.Start.Byte
+		end := edit.Range.End.Byte - rng.Start.Byte
+		text = text[:start] + edit.NewText + text[end:]
+	}
+	return text
+}
+
+func containsRange(outer, inner hcl.Range) bool {
+	return outer.Filename == inner.Filename &&
+		inner.Start.Byte >= outer.Start.Byte &&
+		inner.End.Byte <= outer.End.Byte
+}
+
+func overlaps(a, b hcl.Range) bool {
+	return a.Filename == b.Filename &&
+		a.Start.Byte <= b.End.Byte &&
+		b.Start.Byte <= a.End.Byte
+}
+
+// removalRange extends the range of a block to also cover any
+// empty lines following it, so that removing it leaves no gap
+func removalRange(src []byte, rng hcl.Range) hcl.Range {
+	end := rng.End
+scan:
+	for i := end.Byte; i < len(src); i++ {
+		switch src[i] {
+		case ' ', '\t', '\r':
+		case '\n':
+			end = hcl.Pos{
+				Line:   end.Line + 1,
+				Column: 1,
+				Byte:   i + 1,
+			}
+		default:
+			break scan
+		}
+	}
+	rng.End = end
+	return rng
+}
+
+// ApplyEdits applies the edits to src
+func ApplyEdits(src []byte, edits []Edit) []byte {
+	rng := hcl.Range{
+		Start: hcl.InitialPos,
+		End:   hcl.Pos{Byte: len(src)},
+	}
+	if len(edits) > 0 {
+		rng.Filename = edits[0].Range.Filename
+	}
+	return []byte(applyEdits(src, rng, edits))
+}
+
+// exprInfo describes an expression along with its context
+type exprInfo struct {
+	expr   hclsyntax.Expression
+	parent hclsyntax.Node
+	// attrName is the name of the attribute containing the expression
+	attrName string
+	// block is the top-level block containing the expression
+	block *hclsyntax.Block
+	// static reports whether the expression is in a context where
+	// Terraform doesn't allow any references, such as a module source
+	static bool
+	// iterators are names of for expression and dynamic block
+	// iterators in scope, which shadow any references
+	iterators map[string]bool
+}
+
+// expressions returns all expressions within the body,
+// with outer expressions before the ones nested in them
+func expressions(body *hclsyntax.Body) []exprInfo {
+	w := &exprWalker{
+		infos:     make([]exprInfo, 0),
+		stack:     make([]hclsyntax.Node, 0),
+		iterators: make([]string, 0),
+	}
+	hclsyntax.Walk(body, w)
+
+	sort.SliceStable(w.infos, func(i, j int) bool {
+		a, b := w.infos[i].expr.Range(), w.infos[j].expr.Range()
+		if a.Start.Byte != b.Start.Byte {
+			return a.Start.Byte < b.Start.Byte
+		}
+		return a.End.Byte > b.End.Byte
+	})
+	return w.infos
+}
+
+type exprWalker struct {
+	infos     []exprInfo
+	stack     []hclsyntax.Node
+	iterators []string
+}
+
+func (w *exprWalker) Enter(node hclsyntax.Node) hcl.Diagnostics {
+	if expr, ok := node.(hclsyntax.Expression); ok {
+		info := exprInfo{
+			expr:      expr,
+			iterators: make(map[string]bool, len(w.iterators)),
+		}
+		if len(w.stack) > 0 {
+			info.parent = w.stack[len(w.stack)-1]
+		}
+		blocks := make([]*hclsyntax.Block, 0)
+		for _, n := range w.stack {
+			switch n := n.(type) {
+			case *hclsyntax.Block:
+				if info.block == nil {
+					info.block = n
+				}
+				blocks = append(blocks, n)
+			case *hclsyntax.Attribute:
+				info.attrName = n.Name
+			}
+		}
+		info.static = referencesForbidden(blocks, info.attrName)
+		for _, name := range w.iterators {
+			info.iterators[name] = true
+		}
+		w.infos = append(w.infos, info)
+	}
+
+	switch n := node.(type) {
+	case *hclsyntax.ForExpr:
+		w.iterators = append(w.iterators, n.KeyVar, n.ValVar)
+	case *hclsyntax.Block:
+		if n.Type == "dynamic" && len(n.Labels) == 1 {
+			w.iterators = append(w.iterators, dynamicIterator(n))
+		}
+	}
+
+	w.stack = append(w.stack, node)
+	return nil
+}
+
+func (w *exprWalker) Exit(node hclsyntax.Node) hcl.Diagnostics {
+	w.stack = w.stack[:len(w.stack)-1]
+
+	switch n := node.(type) {
+	case *hclsyntax.ForExpr:
+		w.iterators = w.iterators[:len(w.iterators)-2]
+	case *hclsyntax.Block:
+		if n.Type == "dynamic" && len(n.Labels) == 1 {
+			w.iterators = w.iterators[:len(w.iterators)-1]
+		}
+	}
+	return nil
+}
+
+// referencesForbidden reports whether Terraform forbids references
+// in the given attribute of the innermost of the given nested blocks,
+// i.e. whether the attribute has to be a static value or address
+func referencesForbidden(blocks []*hclsyntax.Block, attrName string) bool {
+	if len(blocks) == 0 {
+		return false
+	}
+
+	switch blocks[0].Type {
+	case "terraform", "variable", "moved", "removed":
+		return true
+	case "import":
+		if len(blocks) == 1 && (attrName == "to" || attrName == "provider") {
+			return true
+		}
+	case "module":
+		if len(blocks) == 1 && (attrName == "source" || attrName == "version") {
+			return true
+		}
+	}
+
+	inner := blocks[len(blocks)-1]
+	switch inner.Type {
+	case "lifecycle":
+		// preconditions and postconditions are nested blocks
+		// of their own and may refer to anything
+		return true
+	case "resource", "data", "ephemeral", "module":
+		if attrName == "provider" || attrName == "providers" {
+			return true
+		}
+	}
+	return attrName == "depends_on"
+}
+
+func dynamicIterator(block *hclsyntax.Block) string {
+	if attr, ok := block.Body.Attributes["iterator"]; ok {
+		if name := hcl.ExprAsKeyword(attr.Expr); name != "" {
+			return name
+		}
+	}
+	return block.Labels[0]
+}
+
+// referencesScoped reports whether the expression refers to any
+// values which only exist within the scope of a block or expression
+func (info exprInfo) referencesScoped() bool {
+	for _, traversal := range info.expr.Variables() {
+		root := traversal.RootName()
+		if info.iterators[root] || root == "count" || root == "each" || root == "self" {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizedText returns the source text of the expression
+// without any whitespace and comments, to compare expressions
+func normalizedText(src []byte, rng hcl.Range) string {
+	tokens, _ := hclsyntax.LexExpression(src[rng.Start.Byte:rng.End.Byte], rng.Filename, rng.Start)
+	var sb strings.Builder
+	for _, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenNewline, hclsyntax.TokenComment, hclsyntax.TokenEOF:
+			continue
+		}
+		sb.Write(token.Bytes)
+	}
+	return sb.String()
+}
+
+// endOfFile returns the position at the end of src
+func endOfFile(filename string, src []byte) hcl.Range {
+	lastLine := src[bytes.LastIndexByte(src, '\n')+1:]
+	pos := hcl.Pos{
+		Line:   bytes.Count(src, []byte("\n")) + 1,
+		Column: len([]rune(string(lastLine))) + 1,
+		Byte:   len(src),
+	}
+	return hcl.Range{
+		Filename: filename,
+		Start:    pos,
+		End:      pos,
+	}
+}
+
+// appendText returns text to append to src, separated
+// from any existing content by an empty line
+func appendText(src []byte, text string) string {
+	switch {
+	case len(src) == 0:
+		return text
+	case src[len(src)-1] == '\n':
+		return "\n" + text
+	}
+	return "\n\n" + text
+}
+
+// lineRange extends the range to the start of its line,
+// if it is only preceded by whitespace
+func lineRange(src []byte, rng hcl.Range) hcl.Range {
+	start := rng.Start.Byte - (rng.Start.Column - 1)
+	if start < 0 || len(bytes.TrimSpace(src[start:rng.Start.Byte])) > 0 {
+		return rng
+	}
+	rng.Start = hcl.Pos{
+		Line:   rng.Start.Line,
+		Column: 1,
+		Byte:   start,
+	}
+	return rng
+}
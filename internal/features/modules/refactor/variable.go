@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExtractVariable turns the innermost literal of the given file containing
+// rng into a new input variable, named after the attribute the literal is
+// assigned to, with the literal as its default. The type of the variable
+// is inferred from the literal. Literals in contexts where Terraform
+// doesn't allow references, such as module sources, are not extracted.
+//
+// The variable is declared in variables.tf, if the module has one,
+// or at the end of the given file otherwise.
+func ExtractVariable(pathCtx *decoder.PathContext, filename string, rng hcl.Range) (Changes, string, bool) {
+	changes := newChanges()
+
+	f, ok := pathCtx.Files[filename]
+	if !ok {
+		return changes, "", false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return changes, "", false
+	}
+
+	var selected *exprInfo
+	var val cty.Value
+	for _, info := range expressions(body) {
+		if !containsRange(info.expr.Range(), rng) || info.block == nil {
+			continue
+		}
+		if _, ok := info.parent.(*hclsyntax.TemplateExpr); ok {
+			continue
+		}
+		literal, ok := literalValue(info.expr)
+		if !ok {
+			continue
+		}
+		info := info
+		selected = &info
+		val = literal
+	}
+	if selected == nil || selected.static {
+		return changes, "", false
+	}
+
+	baseName := selected.attrName
+	if baseName == "" {
+		baseName = "value"
+	}
+	name := uniqueName(sanitizeIdentifier(baseName), variableNames(pathCtx.Files))
+
+	changes.addEdit(selected.expr.Range(), "var."+name)
+
+	declaration := fmt.Sprintf("variable %q {\n  type    = %s\n  default = %s\n}\n",
+		name, typeString(val.Type()), rangeText(pathCtx.Files, selected.expr.Range()))
+
+	target := filename
+	if _, ok := pathCtx.Files["variables.tf"]; ok {
+		target = "variables.tf"
+	}
+	src := pathCtx.Files[target].Bytes
+	changes.addEdit(endOfFile(target, src), appendText(src, declaration))
+
+	return changes, name, true
+}
+
+// literalValue returns the value of expressions which
+// do not refer to anything and call no functions
+func literalValue(expr hclsyntax.Expression) (cty.Value, bool) {
+	if len(expr.Variables()) > 0 {
+		return cty.NilVal, false
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return cty.NilVal, false
+	}
+	return val, true
+}
+
+// typeString returns the type constraint for a literal value, turning
+// tuples and objects with elements of a single type into lists and maps
+func typeString(ty cty.Type) string {
+	switch {
+	case ty.IsTupleType():
+		if elemTy, ok := commonType(ty.TupleElementTypes()); ok {
+			return typeexpr.TypeString(cty.List(elemTy))
+		}
+	case ty.IsObjectType():
+		attrTypes := make([]cty.Type, 0, len(ty.AttributeTypes()))
+		for _, attrTy := range ty.AttributeTypes() {
+			attrTypes = append(attrTypes, attrTy)
+		}
+		if elemTy, ok := commonType(attrTypes); ok {
+			return typeexpr.TypeString(cty.Map(elemTy))
+		}
+	}
+	return typeexpr.TypeString(ty)
+}
+
+func commonType(types []cty.Type) (cty.Type, bool) {
+	if len(types) == 0 {
+		return cty.DynamicPseudoType, true
+	}
+	for _, ty := range types[1:] {
+		if !ty.Equals(types[0]) {
+			return cty.NilType, false
+		}
+	}
+	if !types[0].IsPrimitiveType() {
+		return cty.NilType, false
+	}
+	return types[0], true
+}
+
+func variableNames(files map[string]*hcl.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, f := range files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type == "variable" && len(block.Labels) == 1 {
+				names[block.Labels[0]] = true
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,87 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package refactor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractVariable(t *testing.T) {
+	cfg := `resource "aws_instance" "web" {
+  instance_type = "t3.micro"
+  zones         = ["a", "b"]
+  name          = "web-${var.env}"
+}
+`
+	variablesCfg := `variable "env" {}
+`
+	pathCtx := testPathContext(t, map[string]string{
+		"main.tf":      cfg,
+		"variables.tf": variablesCfg,
+	})
+
+	changes, name, ok := ExtractVariable(pathCtx, "main.tf", selection(cfg, "t3"))
+	if !ok {
+		t.Fatal("expected literal to be extracted")
+	}
+	if name != "instance_type" {
+		t.Fatalf("unexpected name: %q", name)
+	}
+
+	expectedCfg := `resource "aws_instance" "web" {
+  instance_type = var.instance_type
+  zones         = ["a", "b"]
+  name          = "web-${var.env}"
+}
+`
+	if diff := cmp.Diff(expectedCfg, string(ApplyEdits([]byte(cfg), changes.Edits["main.tf"]))); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+
+	expectedVariablesCfg := `variable "env" {}
+
+variable "instance_type" {
+  type    = string
+  default = "t3.micro"
+}
+`
+	if diff := cmp.Diff(expectedVariablesCfg, string(ApplyEdits([]byte(variablesCfg), changes.Edits["variables.tf"]))); diff != "" {
+		t.Fatalf("unexpected variables: %s", diff)
+	}
+
+	changes, _, ok = ExtractVariable(pathCtx, "main.tf", selection(cfg, `", "`))
+	if !ok {
+		t.Fatal("expected literal to be extracted")
+	}
+	expectedVariablesCfg = `variable "env" {}
+
+variable "zones" {
+  type    = list(string)
+  default = ["a", "b"]
+}
+`
+	if diff := cmp.Diff(expectedVariablesCfg, string(ApplyEdits([]byte(variablesCfg), changes.Edits["variables.tf"]))); diff != "" {
+		t.Fatalf("unexpected variables: %s", diff)
+	}
+
+	_, _, ok = ExtractVariable(pathCtx, "main.tf", selection(cfg, "var.env"))
+	if ok {
+		t.Fatal("expected reference not to be extracted")
+	}
+}
+
+func TestExtractVariable_staticContexts(t *testing.T) {
+	for _, tc := range staticContextTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pathCtx := testPathContext(t, map[string]string{"main.tf": tc.cfg})
+
+			_, _, ok := ExtractVariable(pathCtx, "main.tf", selection(tc.cfg, tc.value))
+			if ok {
+				t.Fatalf("expected %s not to be extracted", tc.value)
+			}
+		})
+	}
+}
@@ -115,6 +115,9 @@ func newModule(modPath string) *ModuleRecord {
 			globalAst.SchemaValidationSource:    op.OpStateUnknown,
 			globalAst.ReferenceValidationSource: op.OpStateUnknown,
 			globalAst.TerraformValidateSource:   op.OpStateUnknown,
+			globalAst.PolicyPreviewSource:       op.OpStateUnknown,
+			globalAst.PlanSource:                op.OpStateUnknown,
+			globalAst.ProviderUpgradeSource:     op.OpStateUnknown,
 		},
 	}
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/features/modules/ast"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
@@ -34,12 +35,41 @@ type ModuleStore struct {
 	providerSchemasStore *globalState.ProviderSchemaStore
 	registryModuleStore  *globalState.RegistryModuleStore
 	changeStore          *globalState.ChangeStore
+
+	// engine represents the engine configured for the session,
+	// which may be Auto, in which case it's detected per module
+	engine engine.Engine
 }
 
 func (s *ModuleStore) SetLogger(logger *log.Logger) {
 	s.logger = logger
 }
 
+func (s *ModuleStore) SetEngine(e engine.Engine) {
+	s.engine = e
+}
+
+// Engine returns the engine configured for the session
+func (s *ModuleStore) Engine() engine.Engine {
+	return s.engine
+}
+
+// ModuleEngine returns the engine (Terraform or OpenTofu)
+// which the module at the given path is written for
+func (s *ModuleStore) ModuleEngine(modPath string) engine.Engine {
+	mod, err := s.ModuleRecordByPath(modPath)
+	if err != nil {
+		return engine.FromFilenames(s.engine, nil)
+	}
+
+	filenames := make([]string, 0, len(mod.ParsedModuleFiles))
+	for name := range mod.ParsedModuleFiles {
+		filenames = append(filenames, name.String())
+	}
+
+	return engine.FromFilenames(s.engine, filenames)
+}
+
 func moduleByPath(txn *memdb.Txn, path string) (*ModuleRecord, error) {
 	obj, err := txn.First(moduleTableName, "id", path)
 	if err != nil {
@@ -613,6 +643,29 @@ func (s *ModuleStore) RegistryModuleMeta(addr tfaddr.Module, cons version.Constr
 	return s.registryModuleStore.RegistryModuleMeta(addr, cons)
 }
 
+// RegistryModuleVersions returns cached versions of the given
+// registry module, sorted from the newest to the oldest
+func (s *ModuleStore) RegistryModuleVersions(addr tfaddr.Module) (version.Collection, error) {
+	return s.registryModuleStore.ModuleVersions(addr)
+}
+
+// CacheRegistryModuleVersions caches versions of a registry module
+// called from the module at modPath and notifies about the change,
+// if any, so that clients can refresh related code lenses.
+func (s *ModuleStore) CacheRegistryModuleVersions(modPath string, addr tfaddr.Module, versions version.Collection) error {
+	changed, err := s.registryModuleStore.CacheVersions(addr, versions)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	return s.changeStore.QueueChange(document.DirHandleFromPath(modPath), globalState.Changes{
+		RegistryModuleVersions: true,
+	})
+}
+
 func (s *ModuleStore) ProviderSchema(modPath string, addr tfaddr.Provider, vc version.Constraints) (*tfschema.ProviderSchema, error) {
 	return s.providerSchemasStore.ProviderSchema(modPath, addr, vc)
 }
@@ -94,6 +94,9 @@ func TestModuleStore_ModuleByPath(t *testing.T) {
 			globalAst.SchemaValidationSource:    operation.OpStateUnknown,
 			globalAst.ReferenceValidationSource: operation.OpStateUnknown,
 			globalAst.TerraformValidateSource:   operation.OpStateUnknown,
+			globalAst.PolicyPreviewSource:       operation.OpStateUnknown,
+			globalAst.PlanSource:                operation.OpStateUnknown,
+			globalAst.ProviderUpgradeSource:     operation.OpStateUnknown,
 		},
 	}
 	if diff := cmp.Diff(expectedModule, mod, cmpOpts); diff != "" {
@@ -138,6 +141,9 @@ func TestModuleStore_List(t *testing.T) {
 				globalAst.SchemaValidationSource:    operation.OpStateUnknown,
 				globalAst.ReferenceValidationSource: operation.OpStateUnknown,
 				globalAst.TerraformValidateSource:   operation.OpStateUnknown,
+				globalAst.PolicyPreviewSource:       operation.OpStateUnknown,
+				globalAst.PlanSource:                operation.OpStateUnknown,
+				globalAst.ProviderUpgradeSource:     operation.OpStateUnknown,
 			},
 		},
 		{
@@ -147,6 +153,9 @@ func TestModuleStore_List(t *testing.T) {
 				globalAst.SchemaValidationSource:    operation.OpStateUnknown,
 				globalAst.ReferenceValidationSource: operation.OpStateUnknown,
 				globalAst.TerraformValidateSource:   operation.OpStateUnknown,
+				globalAst.PolicyPreviewSource:       operation.OpStateUnknown,
+				globalAst.PlanSource:                operation.OpStateUnknown,
+				globalAst.ProviderUpgradeSource:     operation.OpStateUnknown,
 			},
 		},
 		{
@@ -156,6 +165,9 @@ func TestModuleStore_List(t *testing.T) {
 				globalAst.SchemaValidationSource:    operation.OpStateUnknown,
 				globalAst.ReferenceValidationSource: operation.OpStateUnknown,
 				globalAst.TerraformValidateSource:   operation.OpStateUnknown,
+				globalAst.PolicyPreviewSource:       operation.OpStateUnknown,
+				globalAst.PlanSource:                operation.OpStateUnknown,
+				globalAst.ProviderUpgradeSource:     operation.OpStateUnknown,
 			},
 		},
 	}
@@ -224,6 +236,9 @@ func TestModuleStore_UpdateMetadata(t *testing.T) {
 			globalAst.SchemaValidationSource:    operation.OpStateUnknown,
 			globalAst.ReferenceValidationSource: operation.OpStateUnknown,
 			globalAst.TerraformValidateSource:   operation.OpStateUnknown,
+			globalAst.PolicyPreviewSource:       operation.OpStateUnknown,
+			globalAst.PlanSource:                operation.OpStateUnknown,
+			globalAst.ProviderUpgradeSource:     operation.OpStateUnknown,
 		},
 	}
 
@@ -0,0 +1,475 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package upgrade compares two versions of a provider schema
+// and finds blocks and attributes of a module which are affected
+// by upgrading the provider from one version to the other.
+package upgrade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+)
+
+// ImpactKind describes how a block or an attribute
+// is affected by an upgrade
+type ImpactKind string
+
+const (
+	// Removed means that the target version no longer supports
+	// the resource type, block or attribute
+	Removed ImpactKind = "removed"
+	// Renamed means that the resource type, block or attribute
+	// was removed, but the target version has a likely replacement
+	Renamed ImpactKind = "renamed"
+	// Deprecated means that the resource type, block or attribute
+	// is deprecated in the target version
+	Deprecated ImpactKind = "deprecated"
+	// Required means that the target version requires
+	// an attribute which is not set
+	Required ImpactKind = "required"
+)
+
+// Upgrade describes an upgrade of a provider between two versions
+type Upgrade struct {
+	Provider tfaddr.Provider
+
+	From          *version.Version
+	CurrentSchema *tfschema.ProviderSchema
+
+	To           *version.Version
+	TargetSchema *tfschema.ProviderSchema
+}
+
+// Impact describes a block or an attribute affected by an upgrade
+type Impact struct {
+	Kind ImpactKind
+	// Address is the address of the affected resource,
+	// data source or provider configuration, e.g. aws_instance.web
+	Address string
+	// Path is the path to the affected attribute or nested block
+	// within the block at Address, e.g. root_block_device.iops,
+	// or empty if the whole block is affected
+	Path string
+	// Replacement is the likely replacement of a renamed
+	// resource type, block or attribute
+	Replacement string
+
+	Summary string
+	Detail  string
+	Range   hcl.Range
+}
+
+// Diagnostic returns a diagnostic describing the impact. Deprecations
+// are reported with the information severity, everything else,
+// which would break the configuration, as warnings.
+func (i Impact) Diagnostic() *hcl.Diagnostic {
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  i.Summary,
+		Detail:   i.Detail,
+		Subject:  i.Range.Ptr(),
+	}
+	if i.Kind == Deprecated {
+		diag.Extra = ihcl.Informational{}
+	}
+	return diag
+}
+
+var providerBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "provider"},
+	},
+}
+
+// Impacts returns blocks and attributes of the given module files
+// affected by the upgrade, ordered by file and block. Blocks are
+// matched with the provider via refs, falling back to the default
+// namespace for undeclared local names, as Terraform does.
+func (u Upgrade) Impacts(files map[string]*hcl.File, refs map[tfmod.ProviderRef]tfaddr.Provider) []Impact {
+	ic := impactCollector{
+		upgrade:     u,
+		impacts:     make([]Impact, 0),
+		typeRenames: make(map[string]map[string]string),
+	}
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		body, ok := files[filename].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "provider":
+				if len(block.Labels) != 1 || !u.isProvider(refs, block.Labels[0]) {
+					continue
+				}
+				ic.checkBody("provider."+block.Labels[0], "", block.Body, block.DefRange(),
+					u.CurrentSchema.Provider, u.TargetSchema.Provider)
+			case "resource":
+				ic.checkResource(block, refs, "", u.CurrentSchema.Resources, u.TargetSchema.Resources)
+			case "data":
+				ic.checkResource(block, refs, "data.", u.CurrentSchema.DataSources, u.TargetSchema.DataSources)
+			case "ephemeral":
+				ic.checkResource(block, refs, "ephemeral.", u.CurrentSchema.EphemeralResources, u.TargetSchema.EphemeralResources)
+			}
+		}
+	}
+
+	return ic.impacts
+}
+
+// isProvider reports whether the local provider name refers to the provider
+func (u Upgrade) isProvider(refs map[tfmod.ProviderRef]tfaddr.Provider, localName string) bool {
+	pAddr, ok := refs[tfmod.ProviderRef{LocalName: localName}]
+	if !ok {
+		pAddr = tfaddr.NewProvider(tfaddr.DefaultProviderRegistryHost, "hashicorp", localName)
+	}
+	return pAddr.Equals(u.Provider)
+}
+
+type impactCollector struct {
+	upgrade Upgrade
+	impacts []Impact
+
+	// typeRenames holds renames of resource types, data sources
+	// and ephemeral resource types, keyed by the address prefix
+	typeRenames map[string]map[string]string
+}
+
+func (ic *impactCollector) add(impact Impact) {
+	ic.impacts = append(ic.impacts, impact)
+}
+
+// resourceTypeRenames returns renames of resource types with the given
+// address prefix, which are only paired once for all blocks
+func (ic *impactCollector) resourceTypeRenames(prefix string, current, target map[string]*schema.BodySchema) map[string]string {
+	if pairs, ok := ic.typeRenames[prefix]; ok {
+		return pairs
+	}
+	pairs := renames(bodyDescriptions(current), bodyNames(target))
+	ic.typeRenames[prefix] = pairs
+	return pairs
+}
+
+func (ic *impactCollector) providerVersion() string {
+	return fmt.Sprintf("%s %s", ic.upgrade.Provider.ForDisplay(), ic.upgrade.To)
+}
+
+func (ic *impactCollector) checkResource(block *hclsyntax.Block, refs map[tfmod.ProviderRef]tfaddr.Provider, prefix string, current, target map[string]*schema.BodySchema) {
+	if len(block.Labels) != 2 {
+		return
+	}
+	resourceType := block.Labels[0]
+
+	localName, _, _ := strings.Cut(resourceType, "_")
+	content, _, _ := block.Body.PartialContent(providerBlockSchema)
+	if attr, ok := content.Attributes["provider"]; ok {
+		traversal, diags := hcl.AbsTraversalForExpr(attr.Expr)
+		if !diags.HasErrors() {
+			localName = traversal.RootName()
+		}
+	}
+	if !ic.upgrade.isProvider(refs, localName) {
+		return
+	}
+
+	curBody, ok := current[resourceType]
+	if !ok {
+		// unknown to the current version, so we can't tell what changed
+		return
+	}
+	address := fmt.Sprintf("%s%s.%s", prefix, resourceType, block.Labels[1])
+	kind := "Resource type"
+	switch prefix {
+	case "data.":
+		kind = "Data source"
+	case "ephemeral.":
+		kind = "Ephemeral resource type"
+	}
+
+	tgtBody, ok := target[resourceType]
+	if !ok {
+		impact := Impact{
+			Kind:    Removed,
+			Address: address,
+			Summary: fmt.Sprintf("%s removed in %s", kind, ic.providerVersion()),
+			Detail:  fmt.Sprintf("%s %q is not supported by %s.", kind, resourceType, ic.providerVersion()),
+			Range:   block.LabelRanges[0],
+		}
+		if replacement, ok := ic.resourceTypeRenames(prefix, current, target)[resourceType]; ok {
+			impact.Kind = Renamed
+			impact.Replacement = replacement
+			impact.Summary = fmt.Sprintf("%s renamed in %s", kind, ic.providerVersion())
+			impact.Detail = fmt.Sprintf("%s %q is not supported by %s, it is likely replaced by %q.",
+				kind, resourceType, ic.providerVersion(), replacement)
+		}
+		ic.add(impact)
+		return
+	}
+
+	if tgtBody.IsDeprecated {
+		ic.add(Impact{
+			Kind:    Deprecated,
+			Address: address,
+			Summary: fmt.Sprintf("%s deprecated in %s", kind, ic.providerVersion()),
+			Detail:  deprecationDetail(fmt.Sprintf("%s %q", kind, resourceType), ic.providerVersion(), tgtBody.Description.Value),
+			Range:   block.LabelRanges[0],
+		})
+	}
+
+	ic.checkBody(address, "", block.Body, block.DefRange(), curBody, tgtBody)
+}
+
+// checkBody checks attributes and nested blocks of the body,
+// which are declared by the current schema, against the target schema
+func (ic *impactCollector) checkBody(address, path string, body *hclsyntax.Body, defRange hcl.Range, current, target *schema.BodySchema) {
+	if current == nil || target == nil {
+		return
+	}
+
+	attrRenames := renames(attributeDescriptions(current), attributeNames(target))
+	names := make([]string, 0, len(body.Attributes))
+	for name := range body.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := current.Attributes[name]; !ok {
+			continue
+		}
+		attr := body.Attributes[name]
+		attrPath := joinPath(path, name)
+
+		tgtAttr, ok := target.Attributes[name]
+		if !ok {
+			ic.add(removedImpact(Removed, "Argument", address, attrPath, attrRenames[name], ic.providerVersion(), attr.NameRange))
+			continue
+		}
+		if tgtAttr.IsDeprecated {
+			ic.add(Impact{
+				Kind:    Deprecated,
+				Address: address,
+				Path:    attrPath,
+				Summary: fmt.Sprintf("Argument deprecated in %s", ic.providerVersion()),
+				Detail:  deprecationDetail(fmt.Sprintf("Argument %q", attrPath), ic.providerVersion(), tgtAttr.Description.Value),
+				Range:   attr.NameRange,
+			})
+		}
+	}
+
+	requiredNames := make([]string, 0)
+	for name, tgtAttr := range target.Attributes {
+		if !tgtAttr.IsRequired {
+			continue
+		}
+		if _, ok := body.Attributes[name]; ok {
+			continue
+		}
+		if curAttr, ok := current.Attributes[name]; ok && curAttr.IsRequired {
+			// already missing, which is reported by validation
+			continue
+		}
+		requiredNames = append(requiredNames, name)
+	}
+	sort.Strings(requiredNames)
+	for _, name := range requiredNames {
+		attrPath := joinPath(path, name)
+		ic.add(Impact{
+			Kind:    Required,
+			Address: address,
+			Path:    attrPath,
+			Summary: fmt.Sprintf("Argument required by %s", ic.providerVersion()),
+			Detail:  fmt.Sprintf("The argument %q is required by %s, but no definition was found.", attrPath, ic.providerVersion()),
+			Range:   defRange,
+		})
+	}
+
+	blockRenames := renames(blockDescriptions(current), blockNames(target))
+	for _, nested := range body.Blocks {
+		curBlock, ok := current.Blocks[nested.Type]
+		if !ok {
+			continue
+		}
+		blockPath := joinPath(path, nested.Type)
+
+		tgtBlock, ok := target.Blocks[nested.Type]
+		if !ok {
+			ic.add(removedImpact(Removed, "Block", address, blockPath, blockRenames[nested.Type], ic.providerVersion(), nested.TypeRange))
+			continue
+		}
+		if tgtBlock.IsDeprecated {
+			ic.add(Impact{
+				Kind:    Deprecated,
+				Address: address,
+				Path:    blockPath,
+				Summary: fmt.Sprintf("Block deprecated in %s", ic.providerVersion()),
+				Detail:  deprecationDetail(fmt.Sprintf("Block %q", blockPath), ic.providerVersion(), tgtBlock.Description.Value),
+				Range:   nested.TypeRange,
+			})
+			continue
+		}
+
+		ic.checkBody(address, blockPath, nested.Body, nested.DefRange(), curBlock.Body, tgtBlock.Body)
+	}
+}
+
+func removedImpact(kind ImpactKind, what, address, path, replacement, providerVersion string, rng hcl.Range) Impact {
+	impact := Impact{
+		Kind:    kind,
+		Address: address,
+		Path:    path,
+		Summary: fmt.Sprintf("%s removed in %s", what, providerVersion),
+		Detail:  fmt.Sprintf("%s %q of %s is not supported by %s.", what, path, address, providerVersion),
+		Range:   rng,
+	}
+	if replacement != "" {
+		impact.Kind = Renamed
+		impact.Replacement = replacement
+		impact.Summary = fmt.Sprintf("%s renamed in %s", what, providerVersion)
+		impact.Detail = fmt.Sprintf("%s %q of %s is not supported by %s, it is likely replaced by %q.",
+			what, path, address, providerVersion, replacement)
+	}
+	return impact
+}
+
+func deprecationDetail(subject, providerVersion, description string) string {
+	detail := fmt.Sprintf("%s is deprecated in %s.", subject, providerVersion)
+	if description != "" {
+		detail += "\n\n" + description
+	}
+	return detail
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// renames pairs names which are no longer present in the target
+// schema with names which were added. A removed name is considered
+// renamed if its description in the current schema mentions an added
+// name, or if it is the only name removed and there is only one added.
+func renames(currentDescriptions map[string]string, targetNames map[string]bool) map[string]string {
+	removed := make([]string, 0)
+	for name := range currentDescriptions {
+		if !targetNames[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	added := make([]string, 0)
+	for name := range targetNames {
+		if _, ok := currentDescriptions[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(added)
+
+	pairs := make(map[string]string)
+	for _, name := range removed {
+		for _, candidate := range added {
+			if mentions(currentDescriptions[name], candidate) {
+				pairs[name] = candidate
+				break
+			}
+		}
+	}
+	if len(removed) == 1 && len(added) == 1 {
+		pairs[removed[0]] = added[0]
+	}
+	return pairs
+}
+
+// mentions reports whether the text contains the name as a whole word
+func mentions(text, name string) bool {
+	if text == "" || name == "" {
+		return false
+	}
+	for i := 0; i < len(text); {
+		j := strings.Index(text[i:], name)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(name)
+		if (start == 0 || !isNameChar(text[start-1])) && (end == len(text) || !isNameChar(text[end])) {
+			return true
+		}
+		i = start + 1
+	}
+	return false
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func bodyDescriptions(bodies map[string]*schema.BodySchema) map[string]string {
+	descriptions := make(map[string]string, len(bodies))
+	for name, body := range bodies {
+		descriptions[name] = ""
+		if body != nil {
+			descriptions[name] = body.Description.Value
+		}
+	}
+	return descriptions
+}
+
+func bodyNames(bodies map[string]*schema.BodySchema) map[string]bool {
+	names := make(map[string]bool, len(bodies))
+	for name := range bodies {
+		names[name] = true
+	}
+	return names
+}
+
+func attributeDescriptions(body *schema.BodySchema) map[string]string {
+	descriptions := make(map[string]string, len(body.Attributes))
+	for name, attr := range body.Attributes {
+		descriptions[name] = attr.Description.Value
+	}
+	return descriptions
+}
+
+func attributeNames(body *schema.BodySchema) map[string]bool {
+	names := make(map[string]bool, len(body.Attributes))
+	for name := range body.Attributes {
+		names[name] = true
+	}
+	return names
+}
+
+func blockDescriptions(body *schema.BodySchema) map[string]string {
+	descriptions := make(map[string]string, len(body.Blocks))
+	for name, block := range body.Blocks {
+		descriptions[name] = block.Description.Value
+	}
+	return descriptions
+}
+
+func blockNames(body *schema.BodySchema) map[string]bool {
+	names := make(map[string]bool, len(body.Blocks))
+	for name := range body.Blocks {
+		names[name] = true
+	}
+	return names
+}
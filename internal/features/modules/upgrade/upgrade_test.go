@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+)
+
+func TestUpgrade_Impacts(t *testing.T) {
+	cfg := `resource "aws_instance" "web" {
+  ami               = "ami-123"
+  cpu_core_count    = 2
+  security_groups   = ["default"]
+
+  ebs_block_device {
+    device_name = "/dev/sdb"
+    iops        = 100
+  }
+}
+
+resource "aws_s3_bucket_object" "index" {
+  key = "index.html"
+}
+
+resource "aws_instance" "other" {
+  provider = aws.west
+  ami      = "ami-456"
+}
+
+resource "google_compute_instance" "web" {
+  name = "web"
+}
+
+data "aws_ami" "ubuntu" {
+  owners = ["self"]
+}
+
+provider "aws" {
+  profile = "default"
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	current := &tfschema.ProviderSchema{
+		Provider: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"region":  {IsOptional: true},
+				"profile": {IsOptional: true},
+			},
+		},
+		Resources: map[string]*schema.BodySchema{
+			"aws_instance": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"ami":            {IsRequired: true},
+					"cpu_core_count": {IsOptional: true},
+					"security_groups": {
+						IsOptional:  true,
+						Description: lang.PlainText("Use vpc_security_group_ids instead."),
+					},
+				},
+				Blocks: map[string]*schema.BlockSchema{
+					"ebs_block_device": {
+						Body: &schema.BodySchema{
+							Attributes: map[string]*schema.AttributeSchema{
+								"device_name": {IsRequired: true},
+								"iops":        {IsOptional: true},
+							},
+						},
+					},
+				},
+			},
+			"aws_s3_bucket_object": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"key": {IsRequired: true},
+				},
+			},
+		},
+		DataSources: map[string]*schema.BodySchema{
+			"aws_ami": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"owners": {IsOptional: true},
+				},
+			},
+		},
+	}
+	target := &tfschema.ProviderSchema{
+		Provider: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"region":  {IsRequired: true},
+				"profile": {IsOptional: true},
+			},
+		},
+		Resources: map[string]*schema.BodySchema{
+			"aws_instance": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"ami":                    {IsRequired: true},
+					"cpu_options":            {IsOptional: true},
+					"vpc_security_group_ids": {IsOptional: true},
+					"instance_type":          {IsRequired: true},
+				},
+				Blocks: map[string]*schema.BlockSchema{
+					"ebs_block_device": {
+						Body: &schema.BodySchema{
+							Attributes: map[string]*schema.AttributeSchema{
+								"device_name": {IsRequired: true},
+								"iops": {
+									IsOptional:   true,
+									IsDeprecated: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"aws_s3_object": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"key": {IsRequired: true},
+				},
+			},
+		},
+		DataSources: map[string]*schema.BodySchema{
+			"aws_ami": {
+				IsDeprecated: true,
+				Description:  lang.PlainText("Use aws_ami_ids instead."),
+				Attributes: map[string]*schema.AttributeSchema{
+					"owners": {IsOptional: true},
+				},
+			},
+		},
+	}
+
+	awsAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	u := Upgrade{
+		Provider:      awsAddr,
+		From:          version.Must(version.NewVersion("4.67.0")),
+		CurrentSchema: current,
+		To:            version.Must(version.NewVersion("5.0.0")),
+		TargetSchema:  target,
+	}
+	refs := map[tfmod.ProviderRef]tfaddr.Provider{
+		{LocalName: "aws"}:                awsAddr,
+		{LocalName: "aws", Alias: "west"}: awsAddr,
+	}
+
+	impacts := u.Impacts(map[string]*hcl.File{"main.tf": f}, refs)
+
+	type impact struct {
+		Kind        ImpactKind
+		Address     string
+		Path        string
+		Replacement string
+		Line        int
+	}
+	given := make([]impact, 0, len(impacts))
+	for _, i := range impacts {
+		given = append(given, impact{i.Kind, i.Address, i.Path, i.Replacement, i.Range.Start.Line})
+	}
+	expected := []impact{
+		{Removed, "aws_instance.web", "cpu_core_count", "", 3},
+		{Renamed, "aws_instance.web", "security_groups", "vpc_security_group_ids", 4},
+		{Required, "aws_instance.web", "instance_type", "", 1},
+		{Deprecated, "aws_instance.web", "ebs_block_device.iops", "", 8},
+		{Renamed, "aws_s3_bucket_object.index", "", "aws_s3_object", 12},
+		{Required, "aws_instance.other", "instance_type", "", 16},
+		{Deprecated, "data.aws_ami.ubuntu", "", "", 25},
+		{Required, "provider.aws", "region", "", 29},
+	}
+	if diff := cmp.Diff(expected, given); diff != "" {
+		t.Fatalf("unexpected impacts: %s", diff)
+	}
+}
+
+func TestImpact_Diagnostic(t *testing.T) {
+	deprecated := Impact{Kind: Deprecated, Summary: "Argument deprecated"}.Diagnostic()
+	if _, ok := deprecated.Extra.(ihcl.Informational); !ok || deprecated.Severity != hcl.DiagWarning {
+		t.Fatalf("expected informational diagnostic, given %#v", deprecated)
+	}
+
+	removed := Impact{Kind: Removed, Summary: "Argument removed"}.Diagnostic()
+	if removed.Extra != nil || removed.Severity != hcl.DiagWarning {
+		t.Fatalf("expected warning, given %#v", removed)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		text string
+		name string
+		want bool
+	}{
+		{"Use aws_s3_bucket_acl instead.", "aws_s3_bucket_acl", true},
+		{"aws_s3_bucket_acl", "aws_s3_bucket_acl", true},
+		{"Use `acl` instead.", "acl", true},
+		{"Use aws_s3_bucket_acl instead.", "aws_s3_bucket", false},
+		{"Use aws_s3_bucket_acl instead.", "s3_bucket_acl", false},
+		{"See acls and then acl.", "acl", true},
+		{"", "acl", false},
+	}
+	for _, tt := range tests {
+		if got := mentions(tt.text, tt.name); got != tt.want {
+			t.Errorf("mentions(%q, %q): expected %t, given %t", tt.text, tt.name, tt.want, got)
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/eventbus"
 	fdecoder "github.com/hashicorp/terraform-ls/internal/features/policy/decoder"
 	"github.com/hashicorp/terraform-ls/internal/features/policy/jobs"
+	"github.com/hashicorp/terraform-ls/internal/features/policy/preview"
 	"github.com/hashicorp/terraform-ls/internal/features/policy/state"
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
@@ -190,3 +191,15 @@ func (f *PolicyFeature) MetadataReady(dir document.DirHandle) (<-chan struct{},
 func (s *PolicyFeature) LocalPolicyMeta(policyPath string) (*tfpolicy.Meta, error) {
 	return s.Store.LocalPolicyMeta(policyPath)
 }
+
+// Preview evaluates resource policies of the given policy directory
+// against resources of the given modules, using only values which
+// are known statically.
+func (f *PolicyFeature) Preview(policyPath string, modules []preview.Module) ([]preview.Result, error) {
+	policy, err := f.Store.PolicyRecordByPath(policyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return preview.Evaluate(policy.ParsedPolicyFiles.AsMap(), modules), nil
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package preview
+
+import (
+	"github.com/hashicorp/terraform-ls/internal/features/modules/eval"
+)
+
+// functions is the subset of functions available to policies
+// whose implementation matches the one of the policy runtime.
+// Calls to any other function make the policy not evaluable.
+var functions = eval.Implementations(
+	"abs",
+	"ceil",
+	"chomp",
+	"coalesce",
+	"coalescelist",
+	"compact",
+	"concat",
+	"contains",
+	"distinct",
+	"element",
+	"flatten",
+	"floor",
+	"format",
+	"formatlist",
+	"indent",
+	"join",
+	"jsondecode",
+	"jsonencode",
+	"keys",
+	"length",
+	"log",
+	"lower",
+	"max",
+	"merge",
+	"min",
+	"parseint",
+	"pow",
+	"range",
+	"regex",
+	"regexall",
+	"reverse",
+	"setintersection",
+	"setproduct",
+	"setsubtract",
+	"setunion",
+	"signum",
+	"slice",
+	"sort",
+	"split",
+	"strrev",
+	"substr",
+	"title",
+	"trim",
+	"trimprefix",
+	"trimspace",
+	"trimsuffix",
+	"upper",
+	"values",
+	"zipmap",
+)
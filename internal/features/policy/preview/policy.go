@@ -0,0 +1,445 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package preview
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+var policyFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource_policy", LabelNames: []string{"resource_type", "name"}},
+		{Type: "provider_policy", LabelNames: []string{"provider_type", "name"}},
+		{Type: "module_policy", LabelNames: []string{"source", "name"}},
+		{Type: "locals"},
+	},
+}
+
+var policySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "filter"},
+		{Name: "enforcement_level"},
+		{Name: "operations"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "enforce"},
+		{Type: "locals"},
+	},
+}
+
+var enforceSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "condition"},
+		{Name: "error_message"},
+	},
+}
+
+// policy represents a resource_policy, provider_policy
+// or module_policy block
+type policy struct {
+	blockType   string
+	subjectType string
+	name        string
+
+	filter           hcl.Expression
+	enforcementLevel hcl.Expression
+	operations       hcl.Expression
+	enforce          []enforce
+	locals           map[string]hcl.Expression
+}
+
+func (p policy) address() string {
+	return fmt.Sprintf("%s.%s.%s", p.blockType, p.subjectType, p.name)
+}
+
+type enforce struct {
+	condition    hcl.Expression
+	errorMessage hcl.Expression
+}
+
+// evaluation represents the outcome of a policy
+// evaluated against a single subject
+type evaluation struct {
+	locals   map[string]hcl.Expression
+	severity hcl.DiagnosticSeverity
+	checks   []check
+}
+
+// check represents the outcome of a single enforce condition,
+// or of the filter if it could not be evaluated
+type check struct {
+	expr    hcl.Expression
+	status  Status
+	message string
+}
+
+// status returns the status of the first failed check, or the first
+// check which could not be evaluated if none failed
+func (e evaluation) status() (Status, string) {
+	status, message := StatusPass, ""
+	for _, c := range e.checks {
+		switch c.status {
+		case StatusFail:
+			return c.status, c.message
+		case StatusNotEvaluable:
+			if status == StatusPass {
+				status, message = c.status, c.message
+			}
+		}
+	}
+	return status, message
+}
+
+// Subject represents a resource, provider or module, e.g. one mocked
+// by a policy test case, which policies are evaluated against
+type Subject struct {
+	// BlockType is the type of the policy block
+	// which applies to the subject, e.g. resource_policy
+	BlockType string
+	// Type is the resource type, provider type or module source
+	Type string
+
+	Attrs      cty.Value
+	PriorAttrs cty.Value
+	Meta       cty.Value
+}
+
+// EvaluateSubject evaluates all policies declared in the given policy files
+// which apply to the subject and returns the combined status along with
+// the error message of the first failed policy, or the reason why
+// a policy could not be evaluated.
+func EvaluateSubject(policyFiles map[string]*hcl.File, subject Subject) (Status, string) {
+	policies, globalLocals := decodePolicies(policyFiles)
+
+	vars := map[string]cty.Value{
+		"attrs":       valueOrUnknown(subject.Attrs),
+		"prior_attrs": valueOrUnknown(subject.PriorAttrs),
+		"meta":        valueOrUnknown(subject.Meta),
+	}
+
+	status, message := StatusPass, ""
+	for _, p := range policies {
+		if p.blockType != subject.BlockType || p.subjectType != subject.Type {
+			continue
+		}
+		eval, ok := evaluatePolicy(p, globalLocals, vars)
+		if !ok {
+			continue
+		}
+		policyStatus, policyMessage := eval.status()
+		switch policyStatus {
+		case StatusFail:
+			return policyStatus, policyMessage
+		case StatusNotEvaluable:
+			if status == StatusPass {
+				status, message = policyStatus, policyMessage
+			}
+		}
+	}
+
+	return status, message
+}
+
+func valueOrUnknown(val cty.Value) cty.Value {
+	if val == cty.NilVal {
+		return cty.DynamicVal
+	}
+	return val
+}
+
+func decodePolicies(files map[string]*hcl.File) ([]policy, map[string]hcl.Expression) {
+	policies := make([]policy, 0)
+	locals := make(map[string]hcl.Expression)
+
+	for _, filename := range sortedFilenames(files) {
+		content, _, _ := files[filename].Body.PartialContent(policyFileSchema)
+
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "locals":
+				addLocals(locals, block.Body)
+			default:
+				policies = append(policies, decodePolicy(block))
+			}
+		}
+	}
+
+	return policies, locals
+}
+
+func decodePolicy(block *hcl.Block) policy {
+	p := policy{
+		blockType:   block.Type,
+		subjectType: block.Labels[0],
+		name:        block.Labels[1],
+		enforce:     make([]enforce, 0),
+		locals:      make(map[string]hcl.Expression),
+	}
+
+	content, _, _ := block.Body.PartialContent(policySchema)
+	if attr, ok := content.Attributes["filter"]; ok {
+		p.filter = attr.Expr
+	}
+	if attr, ok := content.Attributes["enforcement_level"]; ok {
+		p.enforcementLevel = attr.Expr
+	}
+	if attr, ok := content.Attributes["operations"]; ok {
+		p.operations = attr.Expr
+	}
+
+	for _, nested := range content.Blocks {
+		switch nested.Type {
+		case "locals":
+			addLocals(p.locals, nested.Body)
+		case "enforce":
+			enforceContent, _, _ := nested.Body.PartialContent(enforceSchema)
+			condition, ok := enforceContent.Attributes["condition"]
+			if !ok {
+				continue
+			}
+			e := enforce{condition: condition.Expr}
+			if attr, ok := enforceContent.Attributes["error_message"]; ok {
+				e.errorMessage = attr.Expr
+			}
+			p.enforce = append(p.enforce, e)
+		}
+	}
+
+	return p
+}
+
+func addLocals(locals map[string]hcl.Expression, body hcl.Body) {
+	attrs, _ := body.JustAttributes()
+	for name, attr := range attrs {
+		locals[name] = attr.Expr
+	}
+}
+
+// evaluatePolicy evaluates the policy with the given variables,
+// i.e. attrs, prior_attrs and meta, and reports whether it applies
+func evaluatePolicy(p policy, globalLocals map[string]hcl.Expression, vars map[string]cty.Value) (evaluation, bool) {
+	eval := evaluation{
+		locals:   make(map[string]hcl.Expression, len(globalLocals)+len(p.locals)),
+		severity: hcl.DiagError,
+		checks:   make([]check, 0),
+	}
+	for name, expr := range globalLocals {
+		eval.locals[name] = expr
+	}
+	for name, expr := range p.locals {
+		eval.locals[name] = expr
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: make(map[string]cty.Value, len(vars)+1),
+		Functions: functions,
+	}
+	for name, val := range vars {
+		ctx.Variables[name] = val
+	}
+	ctx.Variables["local"] = localsValue(eval.locals, ctx)
+
+	if !appliesToOperation(p.operations, vars["meta"]) {
+		return eval, false
+	}
+
+	if p.filter != nil {
+		applies, reason := evaluateBool(p.filter, ctx)
+		if reason != "" {
+			eval.checks = append(eval.checks, check{
+				expr:    p.filter,
+				status:  StatusNotEvaluable,
+				message: reason,
+			})
+			return eval, true
+		}
+		if !applies {
+			return eval, false
+		}
+	}
+
+	if level, ok := staticString(p.enforcementLevel, ctx); ok && level == "advisory" {
+		eval.severity = hcl.DiagWarning
+	}
+
+	for _, e := range p.enforce {
+		passed, reason := evaluateBool(e.condition, ctx)
+		if reason != "" {
+			eval.checks = append(eval.checks, check{
+				expr:    e.condition,
+				status:  StatusNotEvaluable,
+				message: reason,
+			})
+			continue
+		}
+		if passed {
+			eval.checks = append(eval.checks, check{
+				expr:   e.condition,
+				status: StatusPass,
+			})
+			continue
+		}
+
+		message, ok := staticString(e.errorMessage, ctx)
+		if !ok || message == "" {
+			message = "The enforce condition evaluated to false"
+		}
+		eval.checks = append(eval.checks, check{
+			expr:    e.condition,
+			status:  StatusFail,
+			message: message,
+		})
+	}
+
+	return eval, true
+}
+
+// evaluateBool evaluates the given condition and returns the reason
+// why it could not be evaluated, if any
+func evaluateBool(expr hcl.Expression, ctx *hcl.EvalContext) (bool, string) {
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return false, errorReason(diags)
+	}
+	if !val.IsWhollyKnown() {
+		return false, "The condition depends on values which are not known statically"
+	}
+	val, err := convert.Convert(val, cty.Bool)
+	if err != nil || val.IsNull() {
+		return false, "The condition does not evaluate to a boolean"
+	}
+	return val.True(), ""
+}
+
+// errorReason describes the first error of the given diagnostics
+func errorReason(diags hcl.Diagnostics) string {
+	diag := diags.Errs()[0].(*hcl.Diagnostic)
+	if diag.Detail != "" {
+		return diag.Detail
+	}
+	return diag.Summary
+}
+
+func staticString(expr hcl.Expression, ctx *hcl.EvalContext) (string, bool) {
+	if expr == nil {
+		return "", false
+	}
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return "", false
+	}
+	val, err := convert.Convert(val, cty.String)
+	if err != nil || val.IsNull() {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+// appliesToOperation checks whether the policy applies to the operation
+// in meta, or to create or update if the operation is not known
+func appliesToOperation(operations hcl.Expression, meta cty.Value) bool {
+	operation := ""
+	if meta.IsKnown() && !meta.IsNull() && meta.Type().IsObjectType() && meta.Type().HasAttribute("operation") {
+		op := meta.GetAttr("operation")
+		if op.IsKnown() && !op.IsNull() && op.Type() == cty.String {
+			operation = op.AsString()
+		}
+	}
+
+	if operations == nil {
+		return operation == "" || operation == "create" || operation == "update"
+	}
+	val := staticValue(operations)
+	if !val.IsWhollyKnown() || val.IsNull() || !val.CanIterateElements() {
+		return true
+	}
+	for it := val.ElementIterator(); it.Next(); {
+		_, op := it.Element()
+		if op.Type() != cty.String {
+			return true
+		}
+		switch op.AsString() {
+		case operation:
+			return true
+		case "create", "update":
+			if operation == "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// localsValue evaluates locals in order of their dependencies.
+// Locals which cannot be evaluated are unknown.
+func localsValue(locals map[string]hcl.Expression, ctx *hcl.EvalContext) cty.Value {
+	vals := make(map[string]cty.Value, len(locals))
+	pending := make(map[string]hcl.Expression, len(locals))
+	for name, expr := range locals {
+		pending[name] = expr
+	}
+
+	for progress := true; progress && len(pending) > 0; {
+		progress = false
+		for _, name := range sortedKeys(pending) {
+			expr := pending[name]
+			ready := true
+			for _, dep := range localReferences(expr) {
+				if _, isPending := pending[dep]; isPending && dep != name {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+
+			localCtx := ctx.NewChild()
+			localCtx.Variables = map[string]cty.Value{
+				"local": cty.ObjectVal(vals),
+			}
+			val, diags := expr.Value(localCtx)
+			if diags.HasErrors() {
+				val = cty.DynamicVal
+			}
+			vals[name] = val
+			delete(pending, name)
+			progress = true
+		}
+	}
+
+	// anything left over is part of a cycle
+	for name := range pending {
+		vals[name] = cty.DynamicVal
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+func localReferences(expr hcl.Expression) []string {
+	names := make([]string, 0)
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		if step, ok := traversal[1].(hcl.TraverseAttr); ok {
+			names = append(names, step.Name)
+		}
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
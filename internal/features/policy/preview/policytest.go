@@ -0,0 +1,220 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package preview
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var policyTestFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "policytest"},
+		{Type: "locals"},
+		{Type: "resource", LabelNames: []string{"resource_type", "test_case_name"}},
+		{Type: "provider", LabelNames: []string{"provider_type", "test_case_name"}},
+		{Type: "module", LabelNames: []string{"module_source", "test_case_name"}},
+	},
+}
+
+var policyTestBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "targets"},
+	},
+}
+
+var testCaseSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "attrs"},
+		{Name: "prior_attrs"},
+		{Name: "meta"},
+		{Name: "expect_failure"},
+		{Name: "skip"},
+	},
+}
+
+// TestCaseResult describes the outcome of a single test case
+// of a policy test file
+type TestCaseResult struct {
+	// Name is the address of the test case, e.g. resource.aws_instance.web
+	Name string
+	// Status is one of pass, fail or error,
+	// following the terminology of terraform test
+	Status  string
+	Message string
+	Range   hcl.Range
+}
+
+// RunTestCases evaluates resource, provider and module test cases
+// declared in the given policy test file against the policies it targets.
+//
+// Test cases which set skip are only used as dependencies by the policy
+// runtime, so they are not evaluated. Test cases which depend on values
+// that are not known statically are reported as errors.
+func RunTestCases(testFile *hcl.File, policyFiles map[string]*hcl.File) []TestCaseResult {
+	results := make([]TestCaseResult, 0)
+
+	content, _, _ := testFile.Body.PartialContent(policyTestFileSchema)
+
+	locals := make(map[string]hcl.Expression)
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "locals":
+			addLocals(locals, block.Body)
+		case "policytest":
+			policyFiles = targetedPolicyFiles(block, policyFiles)
+		}
+	}
+
+	ctx := &hcl.EvalContext{
+		Functions: functions,
+	}
+	ctx.Variables = map[string]cty.Value{
+		"local": localsValue(locals, ctx),
+	}
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "resource", "provider", "module":
+		default:
+			continue
+		}
+
+		caseContent, _, _ := block.Body.PartialContent(testCaseSchema)
+		if skip, ok := caseBool(caseContent, "skip", ctx); ok && skip {
+			continue
+		}
+
+		result := TestCaseResult{
+			Name:  strings.Join(append([]string{block.Type}, block.Labels...), "."),
+			Range: block.DefRange,
+		}
+
+		subject, err := testCaseSubject(block, caseContent, ctx)
+		if err != "" {
+			result.Status = "error"
+			result.Message = err
+			results = append(results, result)
+			continue
+		}
+
+		status, message := EvaluateSubject(policyFiles, subject)
+		expectFailure, _ := caseBool(caseContent, "expect_failure", ctx)
+
+		switch {
+		case status == StatusNotEvaluable:
+			result.Status = "error"
+			result.Message = message
+		case status == StatusFail && !expectFailure:
+			result.Status = "fail"
+			result.Message = message
+		case status == StatusPass && expectFailure:
+			result.Status = "fail"
+			result.Message = "Expected the test case to be rejected by a policy, but it was accepted"
+		default:
+			result.Status = "pass"
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// targetedPolicyFiles returns the policy files listed in targets
+// of the policytest block, or all of them if targets are not set
+func targetedPolicyFiles(block *hcl.Block, policyFiles map[string]*hcl.File) map[string]*hcl.File {
+	content, _, _ := block.Body.PartialContent(policyTestBlockSchema)
+	attr, ok := content.Attributes["targets"]
+	if !ok {
+		return policyFiles
+	}
+	val := staticValue(attr.Expr)
+	if !val.IsWhollyKnown() || val.IsNull() || !val.CanIterateElements() {
+		return policyFiles
+	}
+
+	targets := make(map[string]bool)
+	for it := val.ElementIterator(); it.Next(); {
+		_, target := it.Element()
+		if target.Type() != cty.String {
+			continue
+		}
+		targets[filepath.Clean(filepath.FromSlash(target.AsString()))] = true
+	}
+
+	targeted := make(map[string]*hcl.File)
+	for name, file := range policyFiles {
+		if targets[filepath.Clean(name)] || targets[filepath.Base(name)] {
+			targeted[name] = file
+		}
+	}
+	return targeted
+}
+
+func testCaseSubject(block *hcl.Block, content *hcl.BodyContent, ctx *hcl.EvalContext) (Subject, string) {
+	subject := Subject{
+		BlockType: block.Type + "_policy",
+		Type:      block.Labels[0],
+		Attrs:     cty.EmptyObjectVal,
+	}
+
+	defaultMeta := map[string]cty.Value{}
+	switch block.Type {
+	case "resource":
+		providerType, _, _ := strings.Cut(block.Labels[0], "_")
+		defaultMeta["type"] = cty.StringVal(block.Labels[0])
+		defaultMeta["provider_type"] = cty.StringVal(providerType)
+		defaultMeta["operation"] = cty.StringVal("create")
+		defaultMeta["module_path"] = cty.StringVal("")
+		subject.PriorAttrs = cty.NullVal(cty.DynamicPseudoType)
+	case "provider":
+		defaultMeta["type"] = cty.StringVal(block.Labels[0])
+	case "module":
+		defaultMeta["source"] = cty.StringVal(block.Labels[0])
+	}
+
+	for name, target := range map[string]*cty.Value{
+		"attrs":       &subject.Attrs,
+		"prior_attrs": &subject.PriorAttrs,
+		"meta":        &subject.Meta,
+	} {
+		attr, ok := content.Attributes[name]
+		if !ok {
+			continue
+		}
+		val, diags := attr.Expr.Value(ctx)
+		if diags.HasErrors() {
+			return subject, fmt.Sprintf("Invalid %s: %s", name, errorReason(diags))
+		}
+		*target = val
+	}
+
+	meta := defaultMeta
+	if subject.Meta != cty.NilVal && subject.Meta.IsKnown() && !subject.Meta.IsNull() && subject.Meta.CanIterateElements() {
+		for it := subject.Meta.ElementIterator(); it.Next(); {
+			key, val := it.Element()
+			meta[key.AsString()] = val
+		}
+	}
+	subject.Meta = cty.ObjectVal(meta)
+
+	return subject, ""
+}
+
+func caseBool(content *hcl.BodyContent, name string, ctx *hcl.EvalContext) (bool, bool) {
+	attr, ok := content.Attributes[name]
+	if !ok {
+		return false, false
+	}
+	val, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.Bool {
+		return false, false
+	}
+	return val.True(), true
+}
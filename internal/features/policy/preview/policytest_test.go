@@ -0,0 +1,143 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package preview
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const testCasesPolicy = `resource_policy "aws_instance" "type" {
+  enforce {
+    condition     = attrs.instance_type == "t2.micro"
+    error_message = "Only t2.micro instances are allowed"
+  }
+}
+
+provider_policy "aws" "region" {
+  enforce {
+    condition = attrs.region == "eu-west-1"
+  }
+}
+`
+
+const otherPolicy = `resource_policy "aws_instance" "never" {
+  enforce {
+    condition = false
+  }
+}
+`
+
+const testCases = `policytest {
+  targets = ["main.policy.hcl"]
+}
+
+locals {
+  instance_type = "t2.micro"
+}
+
+resource "aws_instance" "valid" {
+  attrs = {
+    instance_type = local.instance_type
+  }
+}
+
+resource "aws_instance" "invalid" {
+  expect_failure = true
+  attrs = {
+    instance_type = "m5.large"
+  }
+}
+
+resource "aws_instance" "unexpected" {
+  attrs = {
+    instance_type = "m5.large"
+  }
+}
+
+resource "aws_instance" "dependency" {
+  skip = true
+}
+
+resource "aws_instance" "deleted" {
+  meta = {
+    operation = "delete"
+  }
+}
+
+provider "aws" "missing" {
+  expect_failure = true
+  attrs = {
+    region = "eu-west-1"
+  }
+}
+
+provider "aws" "unknown" {
+  attrs = {
+    region = var.region
+  }
+}
+`
+
+func TestRunTestCases(t *testing.T) {
+	parse := func(src, filename string) *hcl.File {
+		f, diags := hclsyntax.ParseConfig([]byte(src), filename, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		return f
+	}
+
+	results := RunTestCases(parse(testCases, "main.policytest.hcl"), map[string]*hcl.File{
+		"main.policy.hcl":  parse(testCasesPolicy, "main.policy.hcl"),
+		"other.policy.hcl": parse(otherPolicy, "other.policy.hcl"),
+	})
+
+	type result struct {
+		Name    string
+		Status  string
+		Message string
+		Line    int
+	}
+	expectedResults := []result{
+		{Name: "resource.aws_instance.valid", Status: "pass", Line: 9},
+		{Name: "resource.aws_instance.invalid", Status: "pass", Line: 15},
+		{
+			Name:    "resource.aws_instance.unexpected",
+			Status:  "fail",
+			Message: "Only t2.micro instances are allowed",
+			Line:    22,
+		},
+		{Name: "resource.aws_instance.deleted", Status: "pass", Line: 32},
+		{
+			Name:    "provider.aws.missing",
+			Status:  "fail",
+			Message: "Expected the test case to be rejected by a policy, but it was accepted",
+			Line:    38,
+		},
+		{
+			Name:    "provider.aws.unknown",
+			Status:  "error",
+			Message: `Invalid attrs: There is no variable named "var".`,
+			Line:    45,
+		},
+	}
+
+	givenResults := make([]result, 0, len(results))
+	for _, r := range results {
+		givenResults = append(givenResults, result{
+			Name:    r.Name,
+			Status:  r.Status,
+			Message: r.Message,
+			Line:    r.Range.Start.Line,
+		})
+	}
+
+	if diff := cmp.Diff(expectedResults, givenResults); diff != "" {
+		t.Fatalf("unexpected results: %s", diff)
+	}
+}
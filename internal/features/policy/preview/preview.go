@@ -0,0 +1,352 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package preview evaluates policies without a plan, using only values
+// known statically, against module configuration or mocked test cases.
+package preview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Module represents configuration of a single module
+// which policies are evaluated against
+type Module struct {
+	Path  string
+	Files map[string]*hcl.File
+}
+
+type Status string
+
+const (
+	StatusPass         Status = "pass"
+	StatusFail         Status = "fail"
+	StatusNotEvaluable Status = "not_evaluable"
+)
+
+// Result describes the outcome of a single resource policy
+// evaluated against a single resource
+type Result struct {
+	Policy     string
+	Resource   string
+	ModulePath string
+	Status     Status
+	Message    string
+
+	// Diagnostics point to the resource within the module
+	// and are only present for failed or non-evaluable policies
+	Diagnostics hcl.Diagnostics
+}
+
+var moduleFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+	},
+}
+
+// metaArguments are resource attributes interpreted by Terraform
+// rather than the provider, so they are not part of attrs
+var metaArguments = map[string]bool{
+	"count":      true,
+	"depends_on": true,
+	"for_each":   true,
+	"provider":   true,
+}
+
+var metaBlocks = map[string]bool{
+	"connection":  true,
+	"lifecycle":   true,
+	"provisioner": true,
+}
+
+type resource struct {
+	resourceType string
+	name         string
+	providerType string
+	defRange     hcl.Range
+
+	attrs      cty.Value
+	attrRanges map[string]hcl.Range
+}
+
+func (r resource) address() string {
+	return fmt.Sprintf("%s.%s", r.resourceType, r.name)
+}
+
+func (r resource) vars() map[string]cty.Value {
+	return map[string]cty.Value{
+		"attrs":       r.attrs,
+		"prior_attrs": cty.DynamicVal,
+		"meta": cty.ObjectVal(map[string]cty.Value{
+			"module_path":   cty.UnknownVal(cty.String),
+			"operation":     cty.UnknownVal(cty.String),
+			"provider_type": cty.StringVal(r.providerType),
+			"type":          cty.StringVal(r.resourceType),
+		}),
+	}
+}
+
+// Evaluate evaluates resource policies declared in the given policy files
+// against resources of matching type declared in the given modules.
+//
+// Only resource attributes with values known statically are available
+// to the policies. Policies which depend on any other values, such as
+// references, attributes computed by providers or functions provided by
+// the policy runtime, are reported as not evaluable.
+func Evaluate(policyFiles map[string]*hcl.File, modules []Module) []Result {
+	policies, globalLocals := decodePolicies(policyFiles)
+
+	results := make([]Result, 0)
+	if len(policies) == 0 {
+		return results
+	}
+
+	sort.SliceStable(modules, func(i, j int) bool {
+		return modules[i].Path < modules[j].Path
+	})
+
+	for _, mod := range modules {
+		for _, res := range decodeResources(mod.Files) {
+			for _, p := range policies {
+				if p.blockType != "resource_policy" || p.subjectType != res.resourceType {
+					continue
+				}
+				eval, ok := evaluatePolicy(p, globalLocals, res.vars())
+				if !ok {
+					continue
+				}
+				result := resourceResult(p, res, eval)
+				result.ModulePath = mod.Path
+				results = append(results, result)
+			}
+		}
+	}
+
+	return results
+}
+
+func decodeResources(files map[string]*hcl.File) []resource {
+	resources := make([]resource, 0)
+
+	for _, filename := range sortedFilenames(files) {
+		content, _, _ := files[filename].Body.PartialContent(moduleFileSchema)
+
+		for _, block := range content.Blocks {
+			res := resource{
+				resourceType: block.Labels[0],
+				name:         block.Labels[1],
+				defRange:     block.DefRange,
+				attrRanges:   make(map[string]hcl.Range),
+			}
+			res.providerType, _, _ = strings.Cut(res.resourceType, "_")
+
+			body, ok := block.Body.(*hclsyntax.Body)
+			if !ok {
+				res.attrs = justAttributesValue(block.Body, res.attrRanges)
+				resources = append(resources, res)
+				continue
+			}
+
+			if attr, ok := body.Attributes["provider"]; ok {
+				if traversal, diags := hcl.AbsTraversalForExpr(attr.Expr); !diags.HasErrors() {
+					res.providerType = traversal.RootName()
+				}
+			}
+			res.attrs = bodyValue(body, true)
+			for name, attr := range body.Attributes {
+				if !metaArguments[name] {
+					res.attrRanges[name] = attr.SrcRange
+				}
+			}
+			for _, nested := range body.Blocks {
+				name := nested.Type
+				if name == "dynamic" && len(nested.Labels) > 0 {
+					name = nested.Labels[0]
+				}
+				if _, ok := res.attrRanges[name]; !ok && !metaBlocks[name] {
+					res.attrRanges[name] = nested.DefRange()
+				}
+			}
+
+			resources = append(resources, res)
+		}
+	}
+
+	return resources
+}
+
+// bodyValue represents the given resource body as an object, where
+// each value which is not known statically is unknown
+func bodyValue(body *hclsyntax.Body, topLevel bool) cty.Value {
+	vals := make(map[string]cty.Value)
+
+	for name, attr := range body.Attributes {
+		if topLevel && metaArguments[name] {
+			continue
+		}
+		vals[name] = staticValue(attr.Expr)
+	}
+
+	blocks := make(map[string][]cty.Value)
+	for _, block := range body.Blocks {
+		if topLevel && metaBlocks[block.Type] {
+			continue
+		}
+		if block.Type == "dynamic" {
+			if len(block.Labels) > 0 {
+				vals[block.Labels[0]] = cty.DynamicVal
+			}
+			continue
+		}
+		blocks[block.Type] = append(blocks[block.Type], bodyValue(block.Body, false))
+	}
+	for name, blockVals := range blocks {
+		if _, ok := vals[name]; ok {
+			continue
+		}
+		vals[name] = cty.TupleVal(blockVals)
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+func justAttributesValue(body hcl.Body, ranges map[string]hcl.Range) cty.Value {
+	vals := make(map[string]cty.Value)
+
+	attrs, _ := body.JustAttributes()
+	for name, attr := range attrs {
+		if metaArguments[name] {
+			continue
+		}
+		vals[name] = staticValue(attr.Expr)
+		ranges[name] = attr.Range
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+// staticValue returns value of the expression if it can be evaluated
+// without any references, or unknown value otherwise
+func staticValue(expr hcl.Expression) cty.Value {
+	if len(expr.Variables()) > 0 {
+		return cty.DynamicVal
+	}
+	val, diags := expr.Value(&hcl.EvalContext{
+		Functions: functions,
+	})
+	if diags.HasErrors() {
+		return cty.DynamicVal
+	}
+	return val
+}
+
+// resourceResult reports failed checks on the resource attributes
+// referenced by the condition and checks which could not be evaluated
+// on those referenced attributes which are not known
+func resourceResult(p policy, res resource, eval evaluation) Result {
+	result := Result{
+		Policy:      p.address(),
+		Resource:    res.address(),
+		Diagnostics: make(hcl.Diagnostics, 0),
+	}
+	result.Status, result.Message = eval.status()
+
+	isUnknown := func(val cty.Value) bool {
+		return !val.IsWhollyKnown()
+	}
+	for _, c := range eval.checks {
+		refs := attrReferences(c.expr, eval.locals)
+		switch c.status {
+		case StatusFail:
+			for _, rng := range subjectRanges(res, refs, nil) {
+				result.Diagnostics = append(result.Diagnostics, &hcl.Diagnostic{
+					Severity: eval.severity,
+					Summary:  fmt.Sprintf("Policy %s violated", p.address()),
+					Detail:   c.message,
+					Subject:  rng.Ptr(),
+				})
+			}
+		case StatusNotEvaluable:
+			for _, rng := range subjectRanges(res, refs, isUnknown) {
+				result.Diagnostics = append(result.Diagnostics, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  fmt.Sprintf("Policy %s not evaluable", p.address()),
+					Detail:   c.message,
+					Subject:  rng.Ptr(),
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// attrReferences returns names of resource attributes referenced
+// by the expression, including via any locals
+func attrReferences(expr hcl.Expression, locals map[string]hcl.Expression) []string {
+	seen := make(map[string]bool)
+	visited := make(map[string]bool)
+
+	var walk func(expr hcl.Expression)
+	walk = func(expr hcl.Expression) {
+		for _, traversal := range expr.Variables() {
+			if len(traversal) < 2 {
+				continue
+			}
+			step, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				continue
+			}
+			switch traversal.RootName() {
+			case "attrs":
+				seen[step.Name] = true
+			case "local":
+				if visited[step.Name] {
+					continue
+				}
+				visited[step.Name] = true
+				if localExpr, ok := locals[step.Name]; ok {
+					walk(localExpr)
+				}
+			}
+		}
+	}
+	walk(expr)
+
+	return sortedKeys(seen)
+}
+
+// subjectRanges returns ranges of the given resource attributes which
+// match the filter, or range of the resource block if there are none
+func subjectRanges(res resource, attrNames []string, filter func(cty.Value) bool) []hcl.Range {
+	ranges := make([]hcl.Range, 0)
+	for _, name := range attrNames {
+		rng, ok := res.attrRanges[name]
+		if !ok {
+			continue
+		}
+		if filter != nil && !filter(res.attrs.GetAttr(name)) {
+			continue
+		}
+		ranges = append(ranges, rng)
+	}
+	if len(ranges) == 0 {
+		ranges = append(ranges, res.defRange)
+	}
+	return ranges
+}
+
+func sortedFilenames(files map[string]*hcl.File) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
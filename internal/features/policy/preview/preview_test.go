@@ -0,0 +1,211 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package preview
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const previewPolicy = `locals {
+  allowed_acls = ["private", "authenticated-read"]
+}
+
+resource_policy "aws_s3_bucket" "acl" {
+  enforcement_level = "advisory"
+
+  enforce {
+    condition     = contains(local.allowed_acls, attrs.acl)
+    error_message = "Bucket ACL must be one of ${join(", ", local.allowed_acls)}"
+  }
+}
+
+resource_policy "aws_s3_bucket" "tags" {
+  filter = attrs.acl != "private"
+
+  enforce {
+    condition = attrs.tags.team != ""
+  }
+}
+
+resource_policy "aws_s3_bucket" "deletion" {
+  operations = ["delete"]
+
+  enforce {
+    condition = false
+  }
+}
+
+resource_policy "aws_instance" "type" {
+  enforce {
+    condition     = core::getresources("aws_instance", {}) == []
+    error_message = "Unexpected instance"
+  }
+}
+`
+
+const previewModule = `resource "aws_s3_bucket" "private" {
+  acl = "private"
+}
+
+resource "aws_s3_bucket" "public" {
+  acl = "public-read"
+  tags = {
+    team = ""
+  }
+}
+
+resource "aws_s3_bucket" "computed" {
+  acl = var.acl
+}
+
+resource "aws_instance" "web" {
+  instance_type = "t2.micro"
+}
+`
+
+func TestEvaluate(t *testing.T) {
+	policyFile, diags := hclsyntax.ParseConfig([]byte(previewPolicy), "main.policy.hcl", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	moduleFile, diags := hclsyntax.ParseConfig([]byte(previewModule), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	results := Evaluate(map[string]*hcl.File{
+		"main.policy.hcl": policyFile,
+	}, []Module{
+		{
+			Path: "/mod",
+			Files: map[string]*hcl.File{
+				"main.tf": moduleFile,
+			},
+		},
+	})
+
+	type diag struct {
+		Severity hcl.DiagnosticSeverity
+		Summary  string
+		Detail   string
+		Line     int
+	}
+	type result struct {
+		Policy   string
+		Resource string
+		Status   Status
+		Message  string
+		Diags    []diag
+	}
+
+	expectedResults := []result{
+		{
+			Policy:   "resource_policy.aws_s3_bucket.acl",
+			Resource: "aws_s3_bucket.private",
+			Status:   StatusPass,
+			Diags:    []diag{},
+		},
+		{
+			Policy:   "resource_policy.aws_s3_bucket.acl",
+			Resource: "aws_s3_bucket.public",
+			Status:   StatusFail,
+			Message:  "Bucket ACL must be one of private, authenticated-read",
+			Diags: []diag{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Policy resource_policy.aws_s3_bucket.acl violated",
+					Detail:   "Bucket ACL must be one of private, authenticated-read",
+					Line:     6,
+				},
+			},
+		},
+		{
+			Policy:   "resource_policy.aws_s3_bucket.tags",
+			Resource: "aws_s3_bucket.public",
+			Status:   StatusFail,
+			Message:  "The enforce condition evaluated to false",
+			Diags: []diag{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Policy resource_policy.aws_s3_bucket.tags violated",
+					Detail:   "The enforce condition evaluated to false",
+					Line:     7,
+				},
+			},
+		},
+		{
+			Policy:   "resource_policy.aws_s3_bucket.acl",
+			Resource: "aws_s3_bucket.computed",
+			Status:   StatusNotEvaluable,
+			Message:  "The condition depends on values which are not known statically",
+			Diags: []diag{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Policy resource_policy.aws_s3_bucket.acl not evaluable",
+					Detail:   "The condition depends on values which are not known statically",
+					Line:     13,
+				},
+			},
+		},
+		{
+			Policy:   "resource_policy.aws_s3_bucket.tags",
+			Resource: "aws_s3_bucket.computed",
+			Status:   StatusNotEvaluable,
+			Message:  "The condition depends on values which are not known statically",
+			Diags: []diag{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Policy resource_policy.aws_s3_bucket.tags not evaluable",
+					Detail:   "The condition depends on values which are not known statically",
+					Line:     13,
+				},
+			},
+		},
+		{
+			Policy:   "resource_policy.aws_instance.type",
+			Resource: "aws_instance.web",
+			Status:   StatusNotEvaluable,
+			Message:  `There are no functions in namespace "core::".`,
+			Diags: []diag{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Policy resource_policy.aws_instance.type not evaluable",
+					Detail:   `There are no functions in namespace "core::".`,
+					Line:     16,
+				},
+			},
+		},
+	}
+
+	givenResults := make([]result, 0, len(results))
+	for _, r := range results {
+		if r.ModulePath != "/mod" {
+			t.Fatalf("unexpected module path: %q", r.ModulePath)
+		}
+		diags := make([]diag, 0, len(r.Diagnostics))
+		for _, d := range r.Diagnostics {
+			diags = append(diags, diag{
+				Severity: d.Severity,
+				Summary:  d.Summary,
+				Detail:   d.Detail,
+				Line:     d.Subject.Start.Line,
+			})
+		}
+		givenResults = append(givenResults, result{
+			Policy:   r.Policy,
+			Resource: r.Resource,
+			Status:   r.Status,
+			Message:  r.Message,
+			Diags:    diags,
+		})
+	}
+
+	if diff := cmp.Diff(expectedResults, givenResults); diff != "" {
+		t.Fatalf("unexpected results: %s", diff)
+	}
+}
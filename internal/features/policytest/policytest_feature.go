@@ -8,11 +8,17 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"path/filepath"
 
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/eventbus"
+	policyAst "github.com/hashicorp/terraform-ls/internal/features/policy/ast"
+	policyParser "github.com/hashicorp/terraform-ls/internal/features/policy/parser"
+	"github.com/hashicorp/terraform-ls/internal/features/policy/preview"
+	"github.com/hashicorp/terraform-ls/internal/features/policytest/ast"
 	fdecoder "github.com/hashicorp/terraform-ls/internal/features/policytest/decoder"
 	"github.com/hashicorp/terraform-ls/internal/features/policytest/jobs"
 	"github.com/hashicorp/terraform-ls/internal/features/policytest/state"
@@ -20,6 +26,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
 	"github.com/hashicorp/terraform-ls/internal/registry"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
 
 	tfpolicytest "github.com/hashicorp/terraform-schema/policytest"
 )
@@ -176,3 +183,56 @@ func (f *PolicyTestFeature) MetadataReady(dir document.DirHandle) (<-chan struct
 func (s *PolicyTestFeature) LocalPolicyTestMeta(policytestPath string) (*tfpolicytest.Meta, error) {
 	return s.Store.LocalPolicyTestMeta(policytestPath)
 }
+
+// PolicyPath returns the path of the policies which policy tests
+// in the given directory are run against, i.e. the same directory,
+// or its parent if the tests are kept in a dedicated directory
+func (f *PolicyTestFeature) PolicyPath(policytestPath string) string {
+	if hasPolicyFiles(f.fs, policytestPath) {
+		return policytestPath
+	}
+	parentPath := filepath.Dir(policytestPath)
+	if hasPolicyFiles(f.fs, parentPath) {
+		return parentPath
+	}
+	return policytestPath
+}
+
+// RunTestCases evaluates test cases of the given policy test file
+// in-process, without the Terraform CLI
+func (f *PolicyTestFeature) RunTestCases(policytestPath, filename string) ([]preview.TestCaseResult, error) {
+	record, err := f.Store.PolicyTestRecordByPath(policytestPath)
+	if err != nil {
+		return nil, err
+	}
+	testFile, ok := record.ParsedPolicyTestFiles[ast.PolicyTestFilename(filename)]
+	if !ok {
+		return nil, fmt.Errorf("%s: policy test file %q not parsed", policytestPath, filename)
+	}
+
+	policyFiles, _, err := policyParser.ParsePolicyFiles(f.fs, f.PolicyPath(policytestPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return preview.RunTestCases(testFile, policyFiles.AsMap()), nil
+}
+
+// UpdateTestResults replaces diagnostics from an earlier
+// test run of the given file with the provided ones
+func (f *PolicyTestFeature) UpdateTestResults(policytestPath, filename string, diags hcl.Diagnostics) error {
+	return f.Store.UpdateFileDiagnostics(policytestPath, globalAst.TerraformTestSource, ast.PolicyTestFilename(filename), diags)
+}
+
+func hasPolicyFiles(fs jobs.ReadOnlyFS, path string) bool {
+	entries, err := fs.ReadDir(path)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && policyAst.IsPolicyFilename(entry.Name()) && !globalAst.IsIgnoredFile(entry.Name()) {
+			return true
+		}
+	}
+	return false
+}
@@ -99,6 +99,7 @@ func newPolicyTest(policytestPath string) *PolicyTestRecord {
 			globalAst.SchemaValidationSource:    op.OpStateUnknown,
 			globalAst.ReferenceValidationSource: op.OpStateUnknown,
 			globalAst.TerraformValidateSource:   op.OpStateUnknown,
+			globalAst.TerraformTestSource:       op.OpStateUnknown,
 		},
 	}
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/features/policytest/ast"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
@@ -302,6 +303,42 @@ func (s *PolicyTestStore) UpdatePolicyTestDiagnostics(path string, source global
 	return nil
 }
 
+// UpdateFileDiagnostics replaces diagnostics of a single file
+// from the given source, leaving other files untouched
+func (s *PolicyTestStore) UpdateFileDiagnostics(path string, source globalAst.DiagnosticSource, filename ast.PolicyTestFilename, diags hcl.Diagnostics) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetPolicyTestDiagnosticsState(path, source, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldPolicyTest, err := policytestByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	policytest := oldPolicyTest.Copy()
+	if policytest.PolicyTestDiagnostics == nil {
+		policytest.PolicyTestDiagnostics = make(ast.SourcePolicyTestDiags)
+	}
+	sourceDiags := policytest.PolicyTestDiagnostics[source].Copy()
+	sourceDiags[filename] = diags
+	policytest.PolicyTestDiagnostics[source] = sourceDiags
+
+	err = txn.Insert(s.tableName, policytest)
+	if err != nil {
+		return err
+	}
+
+	err = s.queueRecordChange(oldPolicyTest, policytest)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 func (s *PolicyTestStore) SetPolicyTestDiagnosticsState(path string, source globalAst.DiagnosticSource, state op.OpState) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -77,6 +77,7 @@ func TestPolicyStore_PolicyRecordByPath(t *testing.T) {
 			globalAst.HCLParsingSource:          op.OpStateUnknown,
 			globalAst.SchemaValidationSource:    op.OpStateUnknown,
 			globalAst.ReferenceValidationSource: op.OpStateUnknown,
+			globalAst.TerraformTestSource:       op.OpStateUnknown,
 			globalAst.TerraformValidateSource:   op.OpStateUnknown,
 		},
 	}
@@ -185,6 +186,7 @@ func TestPolicyStore_List(t *testing.T) {
 				globalAst.HCLParsingSource:          op.OpStateUnknown,
 				globalAst.SchemaValidationSource:    op.OpStateUnknown,
 				globalAst.ReferenceValidationSource: op.OpStateUnknown,
+				globalAst.TerraformTestSource:       op.OpStateUnknown,
 				globalAst.TerraformValidateSource:   op.OpStateUnknown,
 			},
 		},
@@ -197,6 +199,7 @@ func TestPolicyStore_List(t *testing.T) {
 				globalAst.HCLParsingSource:          op.OpStateUnknown,
 				globalAst.SchemaValidationSource:    op.OpStateUnknown,
 				globalAst.ReferenceValidationSource: op.OpStateUnknown,
+				globalAst.TerraformTestSource:       op.OpStateUnknown,
 				globalAst.TerraformValidateSource:   op.OpStateUnknown,
 			},
 		},
@@ -5,5 +5,6 @@ package ast
 
 func IsRootModuleFilename(name string) bool {
 	return (name == ".terraform.lock.hcl" ||
-		name == ".terraform-version")
+		name == ".terraform-version" ||
+		name == "terraform.tfstate")
 }
@@ -98,6 +98,18 @@ func (f *RootModulesFeature) didOpen(ctx context.Context, dir document.DirHandle
 	}
 	ids = append(ids, terraformSourcesId)
 
+	stateId, err := f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+		Dir: dir,
+		Func: func(ctx context.Context) error {
+			return jobs.ParseState(ctx, f.fs, f.Store, path)
+		},
+		Type: op.OpTypeParseState.String(),
+	})
+	if err != nil {
+		return ids, err
+	}
+	ids = append(ids, stateId)
+
 	pSchemaVerId, err := f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
 		Dir: dir,
 		Func: func(ctx context.Context) error {
@@ -168,6 +180,34 @@ func (f *RootModulesFeature) pluginLockChange(ctx context.Context, dir document.
 	return ids, nil
 }
 
+func (f *RootModulesFeature) stateChange(ctx context.Context, dir document.DirHandle, changeType protocol.FileChangeType) (job.IDs, error) {
+	ids := make(job.IDs, 0)
+	path := dir.Path()
+
+	// We might not have a record yet, so we add it
+	err := f.Store.AddIfNotExists(path)
+	if err != nil {
+		return ids, err
+	}
+
+	// The parser falls back to the JSON snapshot if the state file
+	// was deleted, so we parse the state regardless of the change type
+	stateId, err := f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+		Dir: dir,
+		Func: func(ctx context.Context) error {
+			return jobs.ParseState(ctx, f.fs, f.Store, path)
+		},
+		IgnoreState: true,
+		Type:        op.OpTypeParseState.String(),
+	})
+	if err != nil {
+		return ids, err
+	}
+	ids = append(ids, stateId)
+
+	return ids, nil
+}
+
 func (f *RootModulesFeature) manifestChange(ctx context.Context, dir document.DirHandle, changeType protocol.FileChangeType) (job.IDs, error) {
 	ids := make(job.IDs, 0)
 	path := dir.Path()
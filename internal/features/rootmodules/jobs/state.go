@@ -0,0 +1,57 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/rootmodules/state"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+)
+
+// ParseState parses the state of the local backend (terraform.tfstate)
+// or its JSON snapshot (terraform.tfstate.json), so that resource
+// instances can be presented alongside their configuration.
+// A missing state file is not considered an error.
+func ParseState(ctx context.Context, fs ReadOnlyFS, rootStore *state.RootStore, modPath string) error {
+	mod, err := rootStore.RootRecordByPath(modPath)
+	if err != nil {
+		return err
+	}
+
+	// Avoid parsing if it is already in progress or already known
+	if mod.StateState != op.OpStateUnknown && !job.IgnoreState(ctx) {
+		return job.StateNotChangedErr{Dir: document.DirHandleFromPath(modPath)}
+	}
+
+	err = rootStore.SetStateState(modPath, op.OpStateLoading)
+	if err != nil {
+		return err
+	}
+
+	st, err := datadir.ParseStateFile(fs, modPath)
+	if err != nil {
+		if isNotExist(err) {
+			return rootStore.UpdateState(modPath, nil, nil)
+		}
+		err = fmt.Errorf("failed to parse state: %w", err)
+	}
+
+	sErr := rootStore.UpdateState(modPath, st, err)
+	if sErr != nil {
+		return sErr
+	}
+
+	return err
+}
+
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/job"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/telemetry"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plan"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
 )
@@ -81,6 +83,9 @@ func (f *RootModulesFeature) Start(ctx context.Context) {
 	pluginLockChangeDone := make(chan job.IDs, 10)
 	pluginLockChange := f.eventbus.OnPluginLockChange("feature.rootmodules", pluginLockChangeDone)
 
+	stateChangeDone := make(chan job.IDs, 10)
+	stateChange := f.eventbus.OnStateChange("feature.rootmodules", stateChangeDone)
+
 	go func() {
 		for {
 			select {
@@ -100,6 +105,10 @@ func (f *RootModulesFeature) Start(ctx context.Context) {
 				// TODO? collect errors
 				spawnedIds, _ := f.pluginLockChange(pluginLockChange.Context, pluginLockChange.Dir)
 				pluginLockChangeDone <- spawnedIds
+			case stateChange := <-stateChange:
+				// TODO? collect errors
+				spawnedIds, _ := f.stateChange(stateChange.Context, stateChange.Dir, stateChange.ChangeType)
+				stateChangeDone <- spawnedIds
 
 			case <-ctx.Done():
 				return
@@ -151,6 +160,38 @@ func (f *RootModulesFeature) InstalledProviders(modPath string) (map[tfaddr.Prov
 	return record.InstalledProviders, nil
 }
 
+// State returns resource instances from the state of the given
+// root module, if the state is known
+func (f *RootModulesFeature) State(modPath string) (*datadir.State, bool) {
+	record, err := f.Store.RootRecordByPath(modPath)
+	if err != nil || record.State == nil {
+		return nil, false
+	}
+
+	return record.State, true
+}
+
+// Plan returns changes of a saved plan which the given
+// root module is annotated with, if any
+func (f *RootModulesFeature) Plan(modPath string) (*plan.Plan, bool) {
+	record, err := f.Store.RootRecordByPath(modPath)
+	if err != nil || record.Plan == nil {
+		return nil, false
+	}
+
+	return record.Plan, true
+}
+
+// UpdatePlan annotates the given root module with changes
+// of a saved plan, or clears the annotations if the plan is nil
+func (f *RootModulesFeature) UpdatePlan(modPath string, p *plan.Plan) error {
+	err := f.Store.AddIfNotExists(modPath)
+	if err != nil {
+		return err
+	}
+	return f.Store.UpdatePlan(modPath, p)
+}
+
 func (f *RootModulesFeature) CallersOfModule(modPath string) ([]string, error) {
 	return f.Store.CallersOfModule(modPath)
 }
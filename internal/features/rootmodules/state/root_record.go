@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plan"
 )
 
 // RootRecord contains all information about a module root path, like
@@ -38,6 +39,16 @@ type RootRecord struct {
 	InstalledProviders      InstalledProviders
 	InstalledProvidersErr   error
 	InstalledProvidersState op.OpState
+
+	// State contains resource instances from the state of the local
+	// backend or from its JSON snapshot
+	State      *datadir.State
+	StateErr   error
+	StateState op.OpState
+
+	// Plan contains changes of a saved plan which the configuration
+	// is annotated with, until the annotations are cleared
+	Plan *plan.Plan
 }
 
 func (m *RootRecord) Copy() *RootRecord {
@@ -65,6 +76,14 @@ func (m *RootRecord) Copy() *RootRecord {
 
 		InstalledProvidersErr:   m.InstalledProvidersErr,
 		InstalledProvidersState: m.InstalledProvidersState,
+
+		// State is practically immutable once parsed
+		State:      m.State,
+		StateErr:   m.StateErr,
+		StateState: m.StateState,
+
+		// Plan is practically immutable once parsed
+		Plan: m.Plan,
 	}
 
 	if m.InstalledProviders != nil {
@@ -97,6 +116,7 @@ func newRootRecord(path string) *RootRecord {
 		TerraformSourcesState:   op.OpStateUnknown,
 		TerraformVersionState:   op.OpStateUnknown,
 		InstalledProvidersState: op.OpStateUnknown,
+		StateState:              op.OpStateUnknown,
 	}
 }
 
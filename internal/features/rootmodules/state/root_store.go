@@ -13,6 +13,7 @@ import (
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plan"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
 )
@@ -333,6 +334,84 @@ func (s *RootStore) UpdateTerraformSources(path string, manifest *datadir.Terraf
 	return nil
 }
 
+func (s *RootStore) SetStateState(path string, state op.OpState) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	record, err := rootRecordCopyByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	record.StateState = state
+
+	err = txn.Insert(s.tableName, record)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+func (s *RootStore) UpdateState(path string, st *datadir.State, sErr error) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetStateState(path, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldRecord, err := rootRecordByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	record := oldRecord.Copy()
+	record.State = st
+	record.StateErr = sErr
+
+	err = txn.Insert(s.tableName, record)
+	if err != nil {
+		return err
+	}
+
+	err = s.queueRecordChange(oldRecord, record)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// UpdatePlan replaces changes of a saved plan of the given root
+// module, or clears them if the plan is nil
+func (s *RootStore) UpdatePlan(path string, p *plan.Plan) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	oldRecord, err := rootRecordByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	record := oldRecord.Copy()
+	record.Plan = p
+
+	err = txn.Insert(s.tableName, record)
+	if err != nil {
+		return err
+	}
+
+	err = s.queueRecordChange(oldRecord, record)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 func (s *RootStore) SetTerraformVersionState(path string, state op.OpState) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -469,6 +548,12 @@ func (s *RootStore) queueRecordChange(oldRecord, newRecord *RootRecord) error {
 		if len(newRecord.InstalledProviders) > 0 {
 			changes.InstalledProviders = true
 		}
+		if newRecord.State != nil {
+			changes.State = true
+		}
+		if newRecord.Plan != nil {
+			changes.Plan = true
+		}
 	// record removed
 	case oldRecord != nil && newRecord == nil:
 		changes.IsRemoval = true
@@ -479,6 +564,12 @@ func (s *RootStore) queueRecordChange(oldRecord, newRecord *RootRecord) error {
 		if len(oldRecord.InstalledProviders) > 0 {
 			changes.InstalledProviders = true
 		}
+		if oldRecord.State != nil {
+			changes.State = true
+		}
+		if oldRecord.Plan != nil {
+			changes.Plan = true
+		}
 	// record changed
 	default:
 		if !oldRecord.TerraformVersion.Equal(newRecord.TerraformVersion) {
@@ -487,6 +578,12 @@ func (s *RootStore) queueRecordChange(oldRecord, newRecord *RootRecord) error {
 		if !oldRecord.InstalledProviders.Equals(newRecord.InstalledProviders) {
 			changes.InstalledProviders = true
 		}
+		if oldRecord.State != newRecord.State {
+			changes.State = true
+		}
+		if oldRecord.Plan != newRecord.Plan {
+			changes.Plan = true
+		}
 	}
 
 	var dir document.DirHandle
@@ -0,0 +1,245 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package query processes results of terraform query and generates
+// configuration to bring the resources found under management.
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// List represents resources found by a single list block
+type List struct {
+	// Address is the address of the list block, e.g. list.aws_instance.web
+	Address      string
+	ResourceType string
+	Results      []tfjson.ListResourceFoundData
+}
+
+// Name returns the name of the list block
+func (l List) Name() string {
+	return l.Address[strings.LastIndex(l.Address, ".")+1:]
+}
+
+// ParseOutput parses the JSON output of terraform query and returns
+// results of each list block in the order in which they were started,
+// along with any diagnostics reported by Terraform
+func ParseOutput(output []byte) ([]List, []tfjson.Diagnostic, error) {
+	lists := make([]List, 0)
+	diags := make([]tfjson.Diagnostic, 0)
+
+	listIndex := func(address, resourceType string) int {
+		for i, list := range lists {
+			if list.Address == address {
+				return i
+			}
+		}
+		lists = append(lists, List{
+			Address:      address,
+			ResourceType: resourceType,
+			Results:      make([]tfjson.ListResourceFoundData, 0),
+		})
+		return len(lists) - 1
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		msg, err := tfjson.UnmarshalLogMessage(line)
+		if err != nil {
+			return lists, diags, fmt.Errorf("failed to parse query output: %w", err)
+		}
+
+		switch m := msg.(type) {
+		case tfjson.ListStartMessage:
+			listIndex(m.ListStart.Address, m.ListStart.ResourceType)
+		case tfjson.ListResourceFoundMessage:
+			i := listIndex(m.ListResourceFound.Address, m.ListResourceFound.ResourceType)
+			lists[i].Results = append(lists[i].Results, m.ListResourceFound)
+		case tfjson.DiagnosticLogMessage:
+			diags = append(diags, m.Diagnostic)
+		}
+	}
+
+	return lists, diags, scanner.Err()
+}
+
+// GenerateConfig generates an import block and a resource block
+// for each resource found by the list.
+//
+// Resources are imported by their identity. Attributes of the resource
+// blocks are filled from the resource objects found, if the list block
+// includes them, where the schema declares them as configurable.
+// Required attributes without a known value are set to null, to be filled
+// in by the user. Names of the resources are derived from display names
+// and are unique among each other and the given names taken already.
+func GenerateConfig(list List, resourceSchema *schema.BodySchema, takenNames map[string]bool) []byte {
+	f := hclwrite.NewEmptyFile()
+	rootBody := f.Body()
+
+	names := make(map[string]bool, len(takenNames))
+	for name := range takenNames {
+		names[name] = true
+	}
+
+	for i, result := range list.Results {
+		name := resourceName(result.DisplayName, fmt.Sprintf("%s_%d", list.Name(), i+1), names)
+		names[name] = true
+
+		if i > 0 {
+			rootBody.AppendNewline()
+		}
+
+		importBody := rootBody.AppendNewBlock("import", nil).Body()
+		importBody.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: list.ResourceType},
+			hcl.TraverseAttr{Name: name},
+		})
+		if identity, ok := valueFromJSON(result.Identity); ok {
+			importBody.SetAttributeValue("identity", identity)
+		}
+		rootBody.AppendNewline()
+
+		resourceBody := rootBody.AppendNewBlock("resource", []string{list.ResourceType, name}).Body()
+		if resourceSchema != nil {
+			object, ok := valueFromJSON(result.ResourceObject)
+			if !ok {
+				object = cty.EmptyObjectVal
+			}
+			writeBody(resourceBody, resourceSchema, object)
+		}
+	}
+
+	return f.Bytes()
+}
+
+// writeBody writes configurable attributes and blocks
+// declared by the schema with values taken from val
+func writeBody(body *hclwrite.Body, bodySchema *schema.BodySchema, val cty.Value) {
+	attrNames := make([]string, 0, len(bodySchema.Attributes))
+	for name := range bodySchema.Attributes {
+		attrNames = append(attrNames, name)
+	}
+	sort.Strings(attrNames)
+
+	for _, name := range attrNames {
+		attr := bodySchema.Attributes[name]
+		if !attr.IsRequired && !attr.IsOptional {
+			// computed only
+			continue
+		}
+		if attr.IsWriteOnly {
+			continue
+		}
+
+		attrVal := objectAttr(val, name)
+		if attr.IsSensitive || attrVal.IsNull() || !attrVal.IsWhollyKnown() {
+			if attr.IsRequired {
+				body.SetAttributeValue(name, cty.NullVal(cty.DynamicPseudoType))
+			}
+			continue
+		}
+		body.SetAttributeValue(name, attrVal)
+	}
+
+	blockNames := make([]string, 0, len(bodySchema.Blocks))
+	for name := range bodySchema.Blocks {
+		blockNames = append(blockNames, name)
+	}
+	sort.Strings(blockNames)
+
+	for _, name := range blockNames {
+		block := bodySchema.Blocks[name]
+		if block.Body == nil {
+			continue
+		}
+
+		blockVal := objectAttr(val, name)
+		if blockVal.IsNull() || !blockVal.IsKnown() {
+			continue
+		}
+
+		switch {
+		case blockVal.Type().IsObjectType():
+			writeBody(body.AppendNewBlock(name, nil).Body(), block.Body, blockVal)
+		case block.Type == schema.BlockTypeMap && blockVal.CanIterateElements():
+			for it := blockVal.ElementIterator(); it.Next(); {
+				key, elem := it.Element()
+				writeBody(body.AppendNewBlock(name, []string{key.AsString()}).Body(), block.Body, elem)
+			}
+		case blockVal.CanIterateElements():
+			for it := blockVal.ElementIterator(); it.Next(); {
+				_, elem := it.Element()
+				writeBody(body.AppendNewBlock(name, nil).Body(), block.Body, elem)
+			}
+		}
+	}
+}
+
+func objectAttr(val cty.Value, name string) cty.Value {
+	if val.IsNull() || !val.IsKnown() || !val.Type().IsObjectType() || !val.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return val.GetAttr(name)
+}
+
+func valueFromJSON(data map[string]any) (cty.Value, bool) {
+	if len(data) == 0 {
+		return cty.NilVal, false
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return cty.NilVal, false
+	}
+	ty, err := ctyjson.ImpliedType(b)
+	if err != nil {
+		return cty.NilVal, false
+	}
+	val, err := ctyjson.Unmarshal(b, ty)
+	if err != nil {
+		return cty.NilVal, false
+	}
+	return val, true
+}
+
+// resourceName turns the display name into a valid identifier,
+// falling back to the given name, and makes it unique
+func resourceName(displayName, fallback string, taken map[string]bool) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(displayName) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	name := strings.Trim(sb.String(), "_-")
+	if name == "" || !hclsyntax.ValidIdentifier(name) || (name[0] >= '0' && name[0] <= '9') {
+		name = fallback
+	}
+
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	return unique
+}
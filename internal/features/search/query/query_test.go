@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+)
+
+const queryOutput = `{"@level":"info","@message":"Terraform 1.14.0","@module":"terraform.ui","type":"version","terraform":"1.14.0","ui":"1.2"}
+{"@level":"info","@message":"list.aws_instance.web: Starting query...","@module":"terraform.ui","list_start":{"address":"list.aws_instance.web","resource_type":"aws_instance"},"type":"list_start"}
+{"@level":"info","@message":"list.aws_instance.web: Result found","@module":"terraform.ui","list_resource_found":{"address":"list.aws_instance.web","display_name":"Web Server","identity":{"id":"i-0123"},"identity_version":1,"resource_type":"aws_instance","resource_object":{"ami":"ami-123","arn":"arn:aws:ec2:i-0123","instance_type":"t3.micro","root_block_device":[{"volume_size":8}],"tags":{"Name":"Web Server"}}},"type":"list_resource_found"}
+{"@level":"info","@message":"list.aws_instance.web: Result found","@module":"terraform.ui","list_resource_found":{"address":"list.aws_instance.web","display_name":"","identity":{"id":"i-0456"},"identity_version":1,"resource_type":"aws_instance"},"type":"list_resource_found"}
+{"@level":"info","@message":"list.aws_instance.web: List complete","@module":"terraform.ui","list_complete":{"address":"list.aws_instance.web","resource_type":"aws_instance","total":2},"type":"list_complete"}
+{"@level":"warn","@message":"Warning: Deprecated attribute","@module":"terraform.ui","diagnostic":{"severity":"warning","summary":"Deprecated attribute","detail":""},"type":"diagnostic"}
+`
+
+func TestParseOutput(t *testing.T) {
+	lists, diags, err := ParseOutput([]byte(queryOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lists) != 1 {
+		t.Fatalf("expected 1 list, given %d", len(lists))
+	}
+	if lists[0].Address != "list.aws_instance.web" || lists[0].Name() != "web" {
+		t.Fatalf("unexpected list: %q", lists[0].Address)
+	}
+	if len(lists[0].Results) != 2 {
+		t.Fatalf("expected 2 results, given %d", len(lists[0].Results))
+	}
+	if len(diags) != 1 || diags[0].Summary != "Deprecated attribute" {
+		t.Fatalf("unexpected diagnostics: %#v", diags)
+	}
+}
+
+func TestGenerateConfig(t *testing.T) {
+	lists, _, err := ParseOutput([]byte(queryOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resourceSchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"ami":           {IsRequired: true},
+			"arn":           {IsComputed: true},
+			"instance_type": {IsOptional: true, IsComputed: true},
+			"password":      {IsOptional: true, IsSensitive: true},
+			"tags":          {IsOptional: true},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"root_block_device": {
+				Type: schema.BlockTypeList,
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"volume_id":   {IsComputed: true},
+						"volume_size": {IsOptional: true},
+					},
+				},
+			},
+		},
+	}
+
+	config := GenerateConfig(lists[0], resourceSchema, map[string]bool{
+		"web_2": true,
+	})
+
+	expectedConfig := `import {
+  to = aws_instance.web_server
+  identity = {
+    id = "i-0123"
+  }
+}
+
+resource "aws_instance" "web_server" {
+  ami           = "ami-123"
+  instance_type = "t3.micro"
+  tags = {
+    Name = "Web Server"
+  }
+  root_block_device {
+    volume_size = 8
+  }
+}
+
+import {
+  to = aws_instance.web_2_2
+  identity = {
+    id = "i-0456"
+  }
+}
+
+resource "aws_instance" "web_2_2" {
+  ami = null
+}
+`
+	if diff := cmp.Diff(expectedConfig, string(config)); diff != "" {
+		t.Fatalf("unexpected config: %s", diff)
+	}
+}
@@ -5,18 +5,27 @@ package search
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/terraform-ls/internal/eventbus"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/jobs"
+	"github.com/hashicorp/terraform-ls/internal/features/search/ast"
 	searchDecoder "github.com/hashicorp/terraform-ls/internal/features/search/decoder"
+	"github.com/hashicorp/terraform-ls/internal/features/search/query"
 	"github.com/hashicorp/terraform-ls/internal/features/search/state"
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfsearch "github.com/hashicorp/terraform-schema/search"
 )
 
 type SearchFeature struct {
@@ -143,3 +152,129 @@ func (f *SearchFeature) Telemetry(path string) map[string]interface{} {
 	properties["search"] = true
 	return properties
 }
+
+// ListBlocks returns ranges of list blocks declared
+// in the given search file, keyed by their address
+func (f *SearchFeature) ListBlocks(path, filename string) (map[string]hcl.Range, error) {
+	record, err := f.store.GetSearchRecordByPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make(map[string]hcl.Range)
+	file, ok := record.ParsedFiles[ast.SearchFilename(filename)]
+	if !ok {
+		return blocks, nil
+	}
+
+	content, _, _ := file.Body.PartialContent(listFileSchema)
+	for _, block := range content.Blocks {
+		address := strings.Join(append([]string{block.Type}, block.Labels...), ".")
+		rng := block.DefRange
+		if body, ok := block.Body.(*hclsyntax.Body); ok {
+			rng = hcl.RangeBetween(block.DefRange, body.SrcRange)
+		}
+		blocks[address] = rng
+	}
+
+	return blocks, nil
+}
+
+// UpdateQueryResults stores resources found by list blocks
+// during a run of terraform query
+func (f *SearchFeature) UpdateQueryResults(path string, lists []query.List) error {
+	return f.store.UpdateQueryResults(path, lists)
+}
+
+// QueryResults returns resources found by the given list block
+// during the last run of terraform query
+func (f *SearchFeature) QueryResults(path, listAddress string) (query.List, bool) {
+	record, err := f.store.GetSearchRecordByPath(path)
+	if err != nil {
+		return query.List{}, false
+	}
+
+	for _, list := range record.QueryResults {
+		if list.Address == listAddress {
+			return list, true
+		}
+	}
+	return query.List{}, false
+}
+
+// ImportConfig generates import and resource blocks for resources found
+// by the given list block, using the resource schema of the provider
+// which the list block uses
+func (f *SearchFeature) ImportConfig(path, listAddress string, takenNames map[string]bool) ([]byte, error) {
+	list, ok := f.QueryResults(path, listAddress)
+	if !ok {
+		return nil, fmt.Errorf("%s: no query results found for %q", path, listAddress)
+	}
+
+	record, err := f.store.GetSearchRecordByPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := listProviderRef(record, listAddress, list.ResourceType)
+	addr, ok := record.Meta.ProviderReferences[ref]
+	if !ok {
+		addr = tfaddr.NewProvider(tfaddr.DefaultProviderRegistryHost, "hashicorp", ref.LocalName)
+	}
+
+	var resourceSchema *schema.BodySchema
+	ps, err := f.store.ProviderSchema(path, addr, record.Meta.ProviderRequirements[addr])
+	if err != nil {
+		f.logger.Printf("%s: no schema for %q found, generating config without attributes: %s", path, addr, err)
+	} else if ps != nil {
+		resourceSchema = ps.Resources[list.ResourceType]
+	}
+
+	return query.GenerateConfig(list, resourceSchema, takenNames), nil
+}
+
+var listFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "list", LabelNames: []string{"type", "name"}},
+	},
+}
+
+var listBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "provider"},
+	},
+}
+
+// listProviderRef returns the provider referenced by the list block,
+// or the default provider for the resource type
+func listProviderRef(record *state.SearchRecord, listAddress, resourceType string) tfsearch.ProviderRef {
+	localName, _, _ := strings.Cut(resourceType, "_")
+	ref := tfsearch.ProviderRef{LocalName: localName}
+
+	for _, file := range record.ParsedFiles {
+		content, _, _ := file.Body.PartialContent(listFileSchema)
+		for _, block := range content.Blocks {
+			if strings.Join(append([]string{block.Type}, block.Labels...), ".") != listAddress {
+				continue
+			}
+			listContent, _, _ := block.Body.PartialContent(listBlockSchema)
+			attr, ok := listContent.Attributes["provider"]
+			if !ok {
+				return ref
+			}
+			traversal, diags := hcl.AbsTraversalForExpr(attr.Expr)
+			if diags.HasErrors() {
+				return ref
+			}
+			ref.LocalName = traversal.RootName()
+			if len(traversal) > 1 {
+				if step, ok := traversal[1].(hcl.TraverseAttr); ok {
+					ref.Alias = step.Name
+				}
+			}
+			return ref
+		}
+	}
+
+	return ref
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/features/search/ast"
+	"github.com/hashicorp/terraform-ls/internal/features/search/query"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 )
@@ -38,6 +39,10 @@ type SearchRecord struct {
 	RefOrigins      reference.Origins
 	RefOriginsErr   error
 	RefOriginsState operation.OpState
+
+	// QueryResults contains resources found by list blocks
+	// during the last run of terraform query
+	QueryResults []query.List
 }
 
 func (m *SearchRecord) Path() string {
@@ -69,6 +74,12 @@ func (m *SearchRecord) Copy() *SearchRecord {
 		RefOriginsState: m.RefOriginsState,
 	}
 
+	if m.QueryResults != nil {
+		// results are never mutated once stored
+		newRecord.QueryResults = make([]query.List, len(m.QueryResults))
+		copy(newRecord.QueryResults, m.QueryResults)
+	}
+
 	if m.ParsedFiles != nil {
 		newRecord.ParsedFiles = make(ast.Files, len(m.ParsedFiles))
 		for name, f := range m.ParsedFiles {
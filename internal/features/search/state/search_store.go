@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/features/search/ast"
+	"github.com/hashicorp/terraform-ls/internal/features/search/query"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
 	"github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
@@ -208,6 +209,26 @@ func (s *SearchStore) UpdateDiagnostics(path string, source globalAst.Diagnostic
 	return nil
 }
 
+func (s *SearchStore) UpdateQueryResults(path string, lists []query.List) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	search, err := searchCopyByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	search.QueryResults = lists
+
+	err = txn.Insert(s.tableName, search)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 func (s *SearchStore) SetMetaState(path string, state operation.OpState) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
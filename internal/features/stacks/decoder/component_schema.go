@@ -0,0 +1,466 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/features/stacks/ast"
+	"github.com/hashicorp/terraform-ls/internal/features/stacks/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/hashicorp/terraform-schema/registry"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	tfstack "github.com/hashicorp/terraform-schema/stack"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	componentScope = lang.ScopeId("component")
+	moduleScope    = lang.ScopeId("module")
+	outputScope    = lang.ScopeId("output")
+	providerScope  = lang.ScopeId("provider")
+	variableScope  = lang.ScopeId("variable")
+)
+
+var componentBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "component", LabelNames: []string{"name"}},
+	},
+}
+
+// componentModule provides data about the module
+// a component is sourced from
+type componentModule struct {
+	// path is only known for modules available on disk,
+	// as opposed to modules described by the Registry API
+	path      string
+	filenames []string
+
+	inputs  map[string]*schema.AttributeSchema
+	outputs map[string]componentOutput
+
+	// providers is nil unless provider references are known,
+	// which is only the case for modules on disk
+	providers map[string]*schema.AttributeSchema
+}
+
+type componentOutput struct {
+	description lang.MarkupContent
+	isSensitive bool
+	value       cty.Value
+}
+
+func (o componentOutput) typ() cty.Type {
+	if o.value == cty.NilVal || o.value.IsNull() {
+		return cty.DynamicPseudoType
+	}
+	return o.value.Type()
+}
+
+// patchComponentSchemas adds dependent bodies to component blocks, which
+// reflect inputs and outputs of each component's module, to a copy of any
+// dependent bodies already provided by the schema merger.
+//
+// Dependent bodies are keyed by both the name and the source of a component,
+// such that components sharing a module each get their own output references.
+func patchComponentSchemas(bodySchema *schema.BodySchema, record *state.StackRecord, reader CombinedReader) {
+	blockSchema, ok := bodySchema.Blocks["component"]
+	if !ok || len(blockSchema.Labels) == 0 {
+		return
+	}
+
+	labels := make([]*schema.LabelSchema, len(blockSchema.Labels))
+	for i, label := range blockSchema.Labels {
+		labels[i] = label.Copy()
+	}
+	labels[0].IsDepKey = true
+	blockSchema.Labels = labels
+
+	forEachExprs := componentForEachExprs(record)
+
+	dependentBody := make(map[schema.SchemaKey]*schema.BodySchema, len(blockSchema.DependentBody))
+	for key, depSchema := range blockSchema.DependentBody {
+		dependentBody[key] = depSchema
+	}
+	blockSchema.DependentBody = dependentBody
+
+	for name, comp := range record.Meta.Components {
+		if comp.Source == "" {
+			continue
+		}
+
+		depKeys := schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: name},
+			},
+			Attributes: []schema.AttributeDependent{
+				{
+					Name: "source",
+					Expr: schema.ExpressionValue{
+						Static: cty.StringVal(comp.Source),
+					},
+				},
+			},
+		}
+
+		var depSchema *schema.BodySchema
+		cm, ok := loadComponentModule(record.Path(), comp, reader)
+		if ok {
+			depSchema = cm.bodySchema(name, forEachExprs[name])
+		}
+
+		if _, ok := comp.SourceAddr.(tfaddr.Module); ok {
+			if depSchema == nil {
+				depSchema = &schema.BodySchema{}
+			}
+			if depSchema.Attributes == nil {
+				depSchema.Attributes = make(map[string]*schema.AttributeSchema)
+			}
+			// components with a source pointing to a registry module require a version constraint
+			depSchema.Attributes["version"] = &schema.AttributeSchema{
+				Constraint:  schema.LiteralType{Type: cty.String},
+				Description: lang.Markdown("Accepts a comma-separated list of version constraints for registry modules. Required for registry modules"),
+				IsRequired:  true,
+			}
+		}
+
+		if depSchema != nil {
+			blockSchema.DependentBody[schema.NewSchemaKey(depKeys)] = depSchema
+		}
+	}
+}
+
+// loadComponentModule finds data about the module of the given component,
+// preferring the module on disk and falling back to the Registry API
+// for registry modules which are not installed
+func loadComponentModule(stackPath string, comp tfstack.Component, reader CombinedReader) (*componentModule, bool) {
+	switch sourceAddr := comp.SourceAddr.(type) {
+	case tfmod.LocalSourceAddr:
+		return localComponentModule(filepath.Join(stackPath, filepath.FromSlash(sourceAddr.String())), reader)
+	case tfaddr.Module:
+		installedDir, ok := reader.InstalledModulePath(stackPath, sourceAddr.String())
+		if ok {
+			cm, ok := localComponentModule(filepath.Join(stackPath, filepath.FromSlash(installedDir)), reader)
+			if ok {
+				return cm, true
+			}
+		}
+
+		modData, err := reader.RegistryModuleMeta(sourceAddr, comp.Version)
+		if err != nil || modData == nil {
+			return nil, false
+		}
+		return registryComponentModule(modData), true
+	case tfmod.RemoteSourceAddr:
+		installedDir, ok := reader.InstalledModulePath(stackPath, sourceAddr.String())
+		if !ok {
+			return nil, false
+		}
+		return localComponentModule(filepath.Join(stackPath, filepath.FromSlash(installedDir)), reader)
+	}
+
+	return nil, false
+}
+
+func localComponentModule(modPath string, reader CombinedReader) (*componentModule, bool) {
+	meta, err := reader.LocalModuleMeta(modPath)
+	if err != nil || meta == nil {
+		return nil, false
+	}
+
+	cm := &componentModule{
+		path:      meta.Path,
+		filenames: meta.Filenames,
+		inputs:    make(map[string]*schema.AttributeSchema, len(meta.Variables)),
+		outputs:   make(map[string]componentOutput, len(meta.Outputs)),
+		providers: make(map[string]*schema.AttributeSchema, len(meta.ProviderReferences)),
+	}
+
+	for name, modVar := range meta.Variables {
+		varType := modVar.Type
+		if varType == cty.NilType {
+			varType = cty.DynamicPseudoType
+		}
+		aSchema := tfschema.ModuleVarToAttribute(modVar)
+		aSchema.Constraint = tfschema.ConvertAttributeTypeToConstraint(varType)
+		aSchema.OriginForTarget = &schema.PathTarget{
+			Address: schema.Address{
+				schema.StaticStep{Name: "var"},
+				schema.AttrNameStep{},
+			},
+			Path: lang.Path{
+				Path:       meta.Path,
+				LanguageID: tfschema.ModuleLanguageID,
+			},
+			Constraints: schema.Constraints{
+				ScopeId: variableScope,
+				Type:    varType,
+			},
+		}
+		cm.inputs[name] = aSchema
+	}
+
+	for pRef := range meta.ProviderReferences {
+		addr := pRef.LocalName
+		if pRef.Alias != "" {
+			addr += "." + pRef.Alias
+		}
+		cm.providers[addr] = &schema.AttributeSchema{
+			Constraint: schema.Reference{OfScopeId: providerScope},
+		}
+	}
+
+	for name, output := range meta.Outputs {
+		co := componentOutput{
+			isSensitive: output.IsSensitive,
+			value:       output.Value,
+		}
+		if output.Description != "" {
+			co.description = lang.PlainText(output.Description)
+		}
+		cm.outputs[name] = co
+	}
+
+	return cm, true
+}
+
+func registryComponentModule(modData *registry.ModuleData) *componentModule {
+	cm := &componentModule{
+		inputs:  make(map[string]*schema.AttributeSchema, len(modData.Inputs)),
+		outputs: make(map[string]componentOutput, len(modData.Outputs)),
+	}
+
+	for _, input := range modData.Inputs {
+		aSchema := &schema.AttributeSchema{
+			Description: input.Description,
+		}
+		if input.Required {
+			aSchema.IsRequired = true
+		} else {
+			aSchema.IsOptional = true
+		}
+
+		typ := input.Type
+		if typ == cty.NilType {
+			typ = cty.DynamicPseudoType
+		}
+		aSchema.Constraint = tfschema.ConvertAttributeTypeToConstraint(typ)
+
+		cm.inputs[input.Name] = aSchema
+	}
+
+	for _, output := range modData.Outputs {
+		// The Registry API doesn't tell us anything more about output type structure
+		// so we cannot target nested fields within objects, maps or lists
+		cm.outputs[output.Name] = componentOutput{
+			description: output.Description,
+		}
+	}
+
+	return cm
+}
+
+func (cm *componentModule) bodySchema(name string, forEachExpr hcl.Expression) *schema.BodySchema {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"inputs": {
+				Constraint: schema.Object{
+					Attributes: cm.inputs,
+				},
+			},
+		},
+	}
+
+	if cm.providers != nil {
+		bodySchema.Attributes["providers"] = &schema.AttributeSchema{
+			Constraint: schema.Object{
+				Attributes: cm.providers,
+			},
+		}
+	}
+
+	outputTypes := make(map[string]cty.Type, len(cm.outputs))
+	for outputName, output := range cm.outputs {
+		outputTypes[outputName] = output.typ()
+	}
+	outputsType := cty.Object(outputTypes)
+
+	componentAddr := lang.Address{
+		lang.RootStep{Name: "component"},
+		lang.AttrStep{Name: name},
+	}
+
+	if forEachExpr == nil {
+		bodySchema.TargetableAs = schema.Targetables{
+			{
+				Address:           componentAddr,
+				ScopeId:           moduleScope,
+				AsType:            outputsType,
+				NestedTargetables: cm.outputTargetables(componentAddr),
+			},
+		}
+		bodySchema.ImpliedOrigins = cm.impliedOrigins(componentAddr)
+	} else {
+		// Instances of components with for_each are referenced by key,
+		// which we can only provide targets for if the keys are known
+		instances := make(schema.Targetables, 0)
+		for _, key := range staticForEachKeys(forEachExpr) {
+			instanceAddr := append(componentAddr.Copy(), lang.IndexStep{Key: cty.StringVal(key)})
+			instances = append(instances, &schema.Targetable{
+				Address:           instanceAddr,
+				ScopeId:           componentScope,
+				AsType:            outputsType,
+				NestedTargetables: cm.outputTargetables(instanceAddr),
+			})
+			bodySchema.ImpliedOrigins = append(bodySchema.ImpliedOrigins, cm.impliedOrigins(instanceAddr)...)
+		}
+
+		bodySchema.TargetableAs = schema.Targetables{
+			{
+				Address:           componentAddr,
+				ScopeId:           moduleScope,
+				AsType:            cty.Map(outputsType),
+				NestedTargetables: instances,
+			},
+		}
+	}
+
+	if len(cm.filenames) > 0 {
+		filename := cm.filenames[0]
+
+		// Prioritize main.tf based on best practices as documented at
+		// https://learn.hashicorp.com/tutorials/terraform/module-create
+		if slices.Contains(cm.filenames, "main.tf") {
+			filename = "main.tf"
+		}
+
+		bodySchema.Targets = &schema.Target{
+			Path: lang.Path{
+				Path:       cm.path,
+				LanguageID: tfschema.ModuleLanguageID,
+			},
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    hcl.InitialPos,
+				End:      hcl.InitialPos,
+			},
+		}
+	}
+
+	return bodySchema
+}
+
+func (cm *componentModule) outputTargetables(parentAddr lang.Address) schema.Targetables {
+	targetables := make(schema.Targetables, 0, len(cm.outputs))
+	for name, output := range cm.outputs {
+		addr := append(parentAddr.Copy(), lang.AttrStep{Name: name})
+
+		targetable := &schema.Targetable{
+			Address:     addr,
+			ScopeId:     componentScope,
+			AsType:      output.typ(),
+			IsSensitive: output.isSensitive,
+			Description: output.description,
+		}
+		if output.value != cty.NilVal {
+			targetable.NestedTargetables = schema.NestedTargetablesForValue(addr, componentScope, output.value)
+		}
+		targetables = append(targetables, targetable)
+	}
+	sort.Sort(targetables)
+
+	return targetables
+}
+
+// impliedOrigins links component outputs to output blocks
+// within the module, which is only possible for modules on disk
+func (cm *componentModule) impliedOrigins(parentAddr lang.Address) schema.ImpliedOrigins {
+	origins := make(schema.ImpliedOrigins, 0)
+	if cm.path == "" {
+		return origins
+	}
+
+	for name := range cm.outputs {
+		origins = append(origins, schema.ImpliedOrigin{
+			OriginAddress: append(parentAddr.Copy(), lang.AttrStep{Name: name}),
+			TargetAddress: lang.Address{
+				lang.RootStep{Name: "output"},
+				lang.AttrStep{Name: name},
+			},
+			Path: lang.Path{
+				Path:       cm.path,
+				LanguageID: tfschema.ModuleLanguageID,
+			},
+			Constraints: schema.Constraints{
+				ScopeId: outputScope,
+			},
+		})
+	}
+
+	return origins
+}
+
+// componentForEachExprs returns for_each expressions
+// of components declared in the stack, by component name
+func componentForEachExprs(record *state.StackRecord) map[string]hcl.Expression {
+	exprs := make(map[string]hcl.Expression)
+
+	for name, f := range record.ParsedFiles {
+		if _, ok := name.(ast.StackFilename); !ok {
+			continue
+		}
+		content, _, _ := f.Body.PartialContent(componentBlockSchema)
+		for _, block := range content.Blocks {
+			attrs, _ := block.Body.JustAttributes()
+			if attr, ok := attrs["for_each"]; ok {
+				exprs[block.Labels[0]] = attr.Expr
+			}
+		}
+	}
+
+	return exprs
+}
+
+// staticForEachKeys returns instance keys for a for_each expression
+// which can be evaluated statically, such as a literal map
+// or a literal list of strings converted via toset()
+func staticForEachKeys(expr hcl.Expression) []string {
+	keys := make([]string, 0)
+
+	isSet := false
+	if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == "toset" && len(call.Args) == 1 {
+		expr = call.Args[0]
+		isSet = true
+	}
+
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return keys
+	}
+
+	typ := val.Type()
+	switch {
+	case !isSet && (typ.IsObjectType() || typ.IsMapType()):
+		for key := range val.AsValueMap() {
+			keys = append(keys, key)
+		}
+	case isSet && (typ.IsTupleType() || typ.IsListType() || typ.IsSetType()):
+		for _, elem := range val.AsValueSlice() {
+			if !elem.Type().Equals(cty.String) || elem.IsNull() {
+				continue
+			}
+			keys = append(keys, elem.AsString())
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
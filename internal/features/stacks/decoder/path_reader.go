@@ -18,9 +18,11 @@ import (
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/hashicorp/terraform-schema/registry"
 	tfschema "github.com/hashicorp/terraform-schema/schema"
 	stackschema "github.com/hashicorp/terraform-schema/schema/stacks"
 	tfstack "github.com/hashicorp/terraform-schema/stack"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type PathReader struct {
@@ -47,6 +49,10 @@ type ModuleReader interface {
 	// LocalModuleMeta returns the module meta data for a local module. This is the result
 	// of the [earlydecoder] when processing module files
 	LocalModuleMeta(modPath string) (*tfmod.Meta, error)
+
+	// RegistryModuleMeta returns the module meta data for registry modules
+	// as obtained from the Registry API
+	RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*registry.ModuleData, error)
 }
 
 type RootReader interface {
@@ -102,6 +108,7 @@ func stackPathContext(record *state.StackRecord, stateReader CombinedReader) (*d
 	if err != nil {
 		return nil, err
 	}
+	patchComponentSchemas(mergedSchema, record, stateReader)
 
 	functions, err := functionsForStack(record, version, stateReader)
 	if err != nil {
@@ -168,6 +175,7 @@ func deployPathContext(record *state.StackRecord) (*decoder.PathContext, error)
 	if err != nil {
 		return nil, err
 	}
+	patchDeploymentInputs(mergedSchema)
 
 	pathCtx := &decoder.PathContext{
 		Schema:           mergedSchema,
@@ -202,6 +210,38 @@ func deployPathContext(record *state.StackRecord) (*decoder.PathContext, error)
 	return pathCtx, nil
 }
 
+// patchDeploymentInputs makes completion of deployment inputs
+// prefill typed literal values, as inputs are typically literals
+// rather than references
+func patchDeploymentInputs(bodySchema *schema.BodySchema) {
+	deployment, ok := bodySchema.Blocks["deployment"]
+	if !ok || deployment.Body == nil {
+		return
+	}
+	inputs, ok := deployment.Body.Attributes["inputs"]
+	if !ok {
+		return
+	}
+	obj, ok := inputs.Constraint.(schema.Object)
+	if !ok {
+		return
+	}
+
+	for _, attr := range obj.Attributes {
+		if attr.OriginForTarget == nil {
+			continue
+		}
+		varType := attr.OriginForTarget.Constraints.Type
+		if varType == cty.NilType || varType == cty.DynamicPseudoType {
+			continue
+		}
+		attr.Constraint = schema.OneOf{
+			schema.LiteralType{Type: varType},
+			attr.Constraint,
+		}
+	}
+}
+
 func (pr *PathReader) Paths(ctx context.Context) []lang.Path {
 	paths := make([]lang.Path, 0)
 
@@ -0,0 +1,173 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Inputs checks inputs of deployment and component blocks against
+// variables declared in the stack or the component's module respectively,
+// i.e. that there are no inputs for undeclared variables,
+// all required variables are set and literal values
+// are convertible to the declared type.
+type Inputs struct{}
+
+type inputsBlockCtxKey struct{}
+
+func (v Inputs) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	switch nodeType := node.(type) {
+	case *hclsyntax.Block:
+		if nodeType.Type == "deployment" || nodeType.Type == "component" {
+			// Inputs of components are only known from the dependent body,
+			// which is provided to the body, so we keep track of the block
+			return context.WithValue(ctx, inputsBlockCtxKey{}, nodeType), diags
+		}
+	case *hclsyntax.Body:
+		block, ok := ctx.Value(inputsBlockCtxKey{}).(*hclsyntax.Block)
+		if !ok || block.Body != nodeType {
+			return ctx, diags
+		}
+		bodySchema, ok := nodeSchema.(*schema.BodySchema)
+		if !ok || bodySchema == nil {
+			return ctx, diags
+		}
+		diags = append(diags, blockInputsDiags(block, bodySchema)...)
+	}
+
+	return ctx, diags
+}
+
+func blockInputsDiags(block *hclsyntax.Block, bodySchema *schema.BodySchema) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	inputsSchema, ok := bodySchema.Attributes["inputs"]
+	if !ok {
+		return diags
+	}
+	variables, ok := inputsSchema.Constraint.(schema.Object)
+	if !ok {
+		// inputs of components are unknown unless the module is
+		return diags
+	}
+	if block.Type == "deployment" && len(variables.Attributes) == 0 {
+		// Without any known variables we can't tell whether
+		// none are declared or the stack wasn't decoded yet
+		return diags
+	}
+
+	declaredIn := "the stack"
+	setFor := "each deployment"
+	if block.Type == "component" {
+		declaredIn = "the module"
+		setFor = "the component"
+	}
+
+	inputsAttr, ok := block.Body.Attributes["inputs"]
+	if !ok {
+		for _, name := range requiredVariables(variables.Attributes) {
+			diags = append(diags, missingInputDiag(name, setFor, block.DefRange()))
+		}
+		return diags
+	}
+
+	objExpr, ok := inputsAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		// inputs may also come from an expression, e.g. a function call
+		return diags
+	}
+
+	declared := make(map[string]bool, len(objExpr.Items))
+	for _, item := range objExpr.Items {
+		name, ok := ihcl.ObjectKeyName(item.KeyExpr)
+		if !ok {
+			continue
+		}
+		declared[name] = true
+
+		varSchema, ok := variables.Attributes[name]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unexpected input",
+				Detail:   fmt.Sprintf("No variable named %q is declared in %s", name, declaredIn),
+				Subject:  item.KeyExpr.Range().Ptr(),
+			})
+			continue
+		}
+
+		diags = append(diags, inputTypeDiags(name, item.ValueExpr, varSchema)...)
+	}
+
+	for _, name := range requiredVariables(variables.Attributes) {
+		if !declared[name] {
+			diags = append(diags, missingInputDiag(name, setFor, inputsAttr.NameRange))
+		}
+	}
+
+	return diags
+}
+
+// inputTypeDiags reports literal values which cannot be converted
+// to the type of the variable. Any other expressions (e.g. references
+// to identity tokens or stores) are only known at plan time.
+func inputTypeDiags(name string, expr hclsyntax.Expression, varSchema *schema.AttributeSchema) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if varSchema.OriginForTarget == nil {
+		return diags
+	}
+	varType := varSchema.OriginForTarget.Constraints.Type
+	if varType == cty.NilType || varType == cty.DynamicPseudoType {
+		return diags
+	}
+
+	val, valDiags := expr.Value(nil)
+	if valDiags.HasErrors() || !val.IsWhollyKnown() {
+		return diags
+	}
+
+	_, err := convert.Convert(val, varType)
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid value for input",
+			Detail:   fmt.Sprintf("Unsuitable value for variable %q: %s", name, err),
+			Subject:  expr.Range().Ptr(),
+		})
+	}
+
+	return diags
+}
+
+func requiredVariables(attrs schema.ObjectAttributes) []string {
+	names := make([]string, 0)
+	for name, attr := range attrs {
+		if attr.IsRequired {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func missingInputDiag(name, setFor string, rng hcl.Range) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("Required input %q not specified", name),
+		Detail:   fmt.Sprintf("Variable %q has no default value, so it must be set for %s", name, setFor),
+		Subject:  rng.Ptr(),
+	}
+}
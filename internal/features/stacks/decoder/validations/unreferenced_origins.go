@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang.DiagnosticsMap {
@@ -37,6 +38,16 @@ func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang
 
 		address := localOrigin.Address()
 
+		if address[0].String() == "component" {
+			d, ok := componentReferenceDiag(pathCtx.ReferenceTargets, address)
+			if ok {
+				fileName := origin.OriginRange().Filename
+				d.Subject = origin.OriginRange().Ptr()
+				diagsMap[fileName] = diagsMap[fileName].Append(d)
+			}
+			continue
+		}
+
 		if len(address) > 2 {
 			// We temporarily ignore references with more than 2 segments
 			// as these indicate references to complex types
@@ -79,3 +90,71 @@ func UnreferencedOrigins(ctx context.Context, pathCtx *decoder.PathContext) lang
 
 	return diagsMap
 }
+
+// componentReferenceDiag checks a reference to a component, such as
+// component.name.output, or component.name["key"].output for components
+// with for_each, against outputs of the component's module, if known
+func componentReferenceDiag(targets reference.Targets, address lang.Address) (*hcl.Diagnostic, bool) {
+	if len(address) < 2 {
+		return nil, false
+	}
+	componentAddr := address[:2]
+	nameStep, ok := address[1].(lang.AttrStep)
+	if !ok {
+		return nil, false
+	}
+	componentName := nameStep.Name
+
+	declared := false
+	outputsType := cty.NilType
+	for _, target := range targets {
+		if !target.Addr.Equals(componentAddr) {
+			continue
+		}
+		declared = true
+		if target.Type != cty.NilType && (target.Type.IsObjectType() || target.Type.IsMapType()) {
+			outputsType = target.Type
+		}
+	}
+
+	if !declared {
+		return &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("No declaration found for %q", componentAddr),
+		}, true
+	}
+	if outputsType == cty.NilType || len(address) < 3 {
+		// outputs are unknown without the module
+		return nil, false
+	}
+
+	outputIdx := 2
+	if outputsType.IsMapType() {
+		if _, ok := address[2].(lang.IndexStep); !ok {
+			return &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing component instance key",
+				Detail:   fmt.Sprintf("Component %q has for_each set, so its outputs must be referenced per instance, e.g. %s[each.key]", componentName, componentAddr),
+			}, true
+		}
+		outputIdx = 3
+		outputsType = outputsType.ElementType()
+	}
+	if len(address) <= outputIdx {
+		return nil, false
+	}
+
+	outputStep, ok := address[outputIdx].(lang.AttrStep)
+	if !ok {
+		return nil, false
+	}
+	if !outputsType.HasAttribute(outputStep.Name) {
+		return &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Reference to undeclared output",
+			Detail:   fmt.Sprintf("The module of component %q has no output named %q", componentName, outputStep.Name),
+		}, true
+	}
+
+	return nil, false
+}
@@ -16,6 +16,7 @@ var stackValidators = []validator.Validator{
 	validator.MinBlocks{},
 	validator.UnexpectedAttribute{},
 	validator.UnexpectedBlock{},
+	validations.Inputs{},
 	validations.MissingRequiredAttribute{},
 	validations.StackBlockValidName{},
 }
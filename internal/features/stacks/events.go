@@ -311,6 +311,85 @@ func (f *StacksFeature) decodeStack(ctx context.Context, dir document.DirHandle,
 	}
 	ids = append(ids, metaId)
 
+	// This job may make an HTTP request, and we schedule it in
+	// the low-priority queue, so we don't want to wait for it.
+	_, err = f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+		Dir: dir,
+		Func: func(ctx context.Context) error {
+			return jobs.GetComponentDataFromRegistry(ctx, f.registryClient,
+				f.store, f.stateStore.RegistryModules, path)
+		},
+		Priority:  job.LowPriority,
+		DependsOn: job.IDs{metaId},
+		Type:      operation.OpTypeGetModuleDataFromRegistry.String(),
+		Defer: func(ctx context.Context, jobErr error) (job.IDs, error) {
+			if jobErr != nil {
+				// no new data, or the data could not be obtained
+				return nil, nil
+			}
+			// Registry data affects schema of components,
+			// so we collect targets and validate again
+			return f.revalidateComponents(ctx, dir, validationOptions.EnableEnhancedValidation)
+		},
+	})
+	if err != nil {
+		return ids, err
+	}
+
+	return ids, nil
+}
+
+// revalidateComponents collects reference targets of the stack
+// and validates it again, e.g. after module data became available
+func (f *StacksFeature) revalidateComponents(ctx context.Context, dir document.DirHandle, enhancedValidation bool) (job.IDs, error) {
+	ids := make(job.IDs, 0)
+	path := dir.Path()
+	// the whole stack is validated, not just a file which was changed
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+
+	refTargetsId, err := f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+		Dir: dir,
+		Func: func(ctx context.Context) error {
+			return jobs.DecodeReferenceTargets(ctx, f.store, f.moduleFeature, f.rootFeature, path)
+		},
+		Type:        operation.OpTypeDecodeReferenceTargets.String(),
+		IgnoreState: true,
+	})
+	if err != nil {
+		return ids, err
+	}
+	ids = append(ids, refTargetsId)
+
+	if enhancedValidation {
+		validationId, err := f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+			Dir: dir,
+			Func: func(ctx context.Context) error {
+				return jobs.SchemaStackValidation(ctx, f.store, f.moduleFeature, f.rootFeature, path)
+			},
+			Type:        operation.OpTypeSchemaStackValidation.String(),
+			DependsOn:   job.IDs{refTargetsId},
+			IgnoreState: true,
+		})
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, validationId)
+	}
+
+	refValidationId, err := f.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+		Dir: dir,
+		Func: func(ctx context.Context) error {
+			return jobs.ReferenceValidation(ctx, f.store, f.moduleFeature, f.rootFeature, path)
+		},
+		Type:        operation.OpTypeReferenceStackValidation.String(),
+		DependsOn:   job.IDs{refTargetsId},
+		IgnoreState: true,
+	})
+	if err != nil {
+		return ids, err
+	}
+	ids = append(ids, refValidationId)
+
 	return ids, nil
 }
 
@@ -0,0 +1,224 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	stackDecoder "github.com/hashicorp/terraform-ls/internal/features/stacks/decoder"
+	"github.com/hashicorp/terraform-ls/internal/features/stacks/state"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/hashicorp/terraform-schema/registry"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type componentModuleReader struct {
+	meta map[string]*tfmod.Meta
+}
+
+func (r componentModuleReader) LocalModuleMeta(modPath string) (*tfmod.Meta, error) {
+	meta, ok := r.meta[modPath]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return meta, nil
+}
+
+func (r componentModuleReader) RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*registry.ModuleData, error) {
+	return nil, errors.New("not found")
+}
+
+func newComponentModuleReader(stackPath string) componentModuleReader {
+	modPath := filepath.Join(stackPath, "app")
+	return componentModuleReader{
+		meta: map[string]*tfmod.Meta{
+			modPath: {
+				Path:      modPath,
+				Filenames: []string{"main.tf"},
+				Variables: map[string]tfmod.Variable{
+					"name": {Type: cty.String},
+					"size": {Type: cty.Number, DefaultValue: cty.NumberIntVal(1)},
+				},
+				Outputs: map[string]tfmod.Output{
+					"id": {
+						Description: "ID of the app",
+						Value:       cty.UnknownVal(cty.String),
+					},
+				},
+			},
+		},
+	}
+}
+
+func loadComponentStack(t *testing.T) (context.Context, *state.StackStore, componentModuleReader, string) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := state.NewStackStore(gs.ChangeStore, gs.ProviderSchemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stackPath := filepath.Join(testData, "component-io")
+
+	err = ss.Add(stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr := newComponentModuleReader(stackPath)
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{
+		Method:     "textDocument/didOpen",
+		LanguageID: ilsp.Stacks.String(),
+		URI:        "file:///test/components.tfcomponent.hcl",
+	})
+	err = ParseStackConfiguration(ctx, fs, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadStackMetadata(ctx, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceTargets(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ctx, ss, mr, stackPath
+}
+
+func TestComponentInputsAndOutputs_validation(t *testing.T) {
+	ctx, ss, mr, stackPath := loadComponentStack(t)
+
+	err := SchemaStackValidation(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := ss.StackRecordByPath(stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summaries := make([]string, 0)
+	for _, diagsMap := range record.Diagnostics {
+		for _, diags := range diagsMap {
+			for _, diag := range diags {
+				summaries = append(summaries, fmt.Sprintf("%d: %s: %s", diag.Subject.Start.Line, diag.Summary, diag.Detail))
+			}
+		}
+	}
+	sort.Strings(summaries)
+	expectedSummaries := []string{
+		`20: Required input "name" not specified: Variable "name" has no default value, so it must be set for the component`,
+		`31: Reference to undeclared output: The module of component "app" has no output named "missing"`,
+		`41: Reference to undeclared output: The module of component "many" has no output named "missing"`,
+		`46: Missing component instance key: Component "many" has for_each set, so its outputs must be referenced per instance, e.g. component.many[each.key]`,
+		`51: No declaration found for "component.unknown": `,
+		`6: Invalid value for input: Unsuitable value for variable "size": a number is required`,
+		`7: Unexpected input: No variable named "unknown" is declared in the module`,
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
+
+func TestComponentOutputs_completionAndHover(t *testing.T) {
+	ctx, ss, mr, stackPath := loadComponentStack(t)
+
+	d := decoder.NewDecoder(&stackDecoder.PathReader{
+		StateReader:  ss,
+		ModuleReader: mr,
+		RootReader:   RootReaderMock{},
+	})
+	pathDecoder, err := d.Path(lang.Path{
+		Path:       stackPath,
+		LanguageID: ilsp.Stacks.String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(stackPath, "components.tfcomponent.hcl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// after "component.app." in the "id" output
+	candidates, err := pathDecoder.CompletionAtPos(ctx, "components.tfcomponent.hcl",
+		posAfter(t, src, "value = component.app."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := make([]string, 0)
+	for _, c := range candidates.List {
+		labels = append(labels, c.Label)
+	}
+	if diff := cmp.Diff([]string{"component.app.id"}, labels); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+
+	// on "id" of the instance reference in the "each_id" output
+	hoverData, err := pathDecoder.HoverAtPos(ctx, "components.tfcomponent.hcl",
+		posAfter(t, src, `value = component.many["a"].i`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHover := "`component.many[\"a\"].id`\n_string_\n\nID of the app"
+	if diff := cmp.Diff(expectedHover, hoverData.Content.Value); diff != "" {
+		t.Fatalf("unexpected hover: %s", diff)
+	}
+}
+
+func posAfter(t *testing.T, src []byte, text string) hcl.Pos {
+	idx := strings.Index(string(src), text)
+	if idx < 0 {
+		t.Fatalf("%q not found", text)
+	}
+	offset := idx + len(text)
+	pos := hcl.InitialPos
+	for _, b := range src[:offset] {
+		pos.Byte++
+		if b == '\n' {
+			pos.Line++
+			pos.Column = 1
+			continue
+		}
+		pos.Column++
+	}
+	return pos
+}
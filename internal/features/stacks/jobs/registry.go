@@ -0,0 +1,72 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jobs
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	mjobs "github.com/hashicorp/terraform-ls/internal/features/modules/jobs"
+	"github.com/hashicorp/terraform-ls/internal/features/stacks/state"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	"github.com/hashicorp/terraform-ls/internal/registry"
+	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+// GetComponentDataFromRegistry obtains data about modules (inputs & outputs)
+// of components sourced from the Registry, based on components which were
+// previously parsed via [LoadStackMetadata]. This allows validating
+// components without the modules being installed.
+//
+// It returns [job.StateNotChangedErr] if data about all modules
+// was already cached, so that dependent jobs can be skipped.
+func GetComponentDataFromRegistry(ctx context.Context, regClient registry.Client, stackStore *state.StackStore, modRegStore *globalState.RegistryModuleStore, stackPath string) error {
+	record, err := stackStore.StackRecordByPath(stackPath)
+	if err != nil {
+		return err
+	}
+
+	var errs *multierror.Error
+	changed := false
+
+	for _, component := range record.Meta.Components {
+		sourceAddr, ok := component.SourceAddr.(tfaddr.Module)
+		if !ok {
+			// skip any modules which do not come from the Registry
+			continue
+		}
+
+		exists, err := modRegStore.Exists(sourceAddr, component.Version)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		err = mjobs.CacheRegistryModuleData(ctx, regClient, modRegStore, sourceAddr, component.Version)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		// data may not have been cached, e.g. when offline
+		exists, err = modRegStore.Exists(sourceAddr, component.Version)
+		if err == nil && exists {
+			changed = true
+		}
+	}
+
+	if errs.ErrorOrNil() != nil {
+		return errs
+	}
+	if !changed {
+		return job.StateNotChangedErr{Dir: document.DirHandleFromPath(stackPath)}
+	}
+
+	return nil
+}
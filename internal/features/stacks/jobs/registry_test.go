@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/features/stacks/state"
+	"github.com/hashicorp/terraform-ls/internal/filesystem"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/registry"
+	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfregistry "github.com/hashicorp/terraform-schema/registry"
+)
+
+const ecModuleVersionsMockResponse = `{"modules": [{"versions": [{"version": "0.0.8"}]}]}`
+
+const ecModuleDataMockResponse = `{
+  "version": "0.0.8",
+  "published_at": "2023-01-02T10:00:00Z",
+  "root": {
+    "inputs": [
+      {"name": "name", "type": "string", "required": true}
+    ],
+    "outputs": [
+      {"name": "id"}
+    ]
+  }
+}`
+
+type registryModuleReader struct {
+	store *globalState.RegistryModuleStore
+}
+
+func (r registryModuleReader) LocalModuleMeta(modPath string) (*tfmod.Meta, error) {
+	return nil, errors.New("not found")
+}
+
+func (r registryModuleReader) RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*tfregistry.ModuleData, error) {
+	return r.store.RegistryModuleMeta(addr, cons)
+}
+
+func TestGetComponentDataFromRegistry(t *testing.T) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := state.NewStackStore(gs.ChangeStore, gs.ProviderSchemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stackPath := filepath.Join(testData, "registry-component")
+
+	err = ss.Add(stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{
+		Method:     "textDocument/didOpen",
+		LanguageID: ilsp.Stacks.String(),
+		URI:        "file:///test/components.tfcomponent.hcl",
+	})
+	err = ParseStackConfiguration(ctx, fs, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadStackMetadata(ctx, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	regClient := registry.NewClient()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/v1/modules/puppetlabs/deployment/ec/versions" {
+			w.Write([]byte(ecModuleVersionsMockResponse))
+			return
+		}
+		if r.RequestURI == "/v1/modules/puppetlabs/deployment/ec/0.0.8" {
+			w.Write([]byte(ecModuleDataMockResponse))
+			return
+		}
+		http.Error(w, fmt.Sprintf("unexpected request: %q", r.RequestURI), 400)
+	}))
+	regClient.BaseURL = srv.URL
+	t.Cleanup(srv.Close)
+
+	err = GetComponentDataFromRegistry(ctx, regClient, ss, gs.RegistryModules, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// data is cached already
+	err = GetComponentDataFromRegistry(ctx, regClient, ss, gs.RegistryModules, stackPath)
+	if !errors.As(err, &job.StateNotChangedErr{}) {
+		t.Fatalf("expected state not to change, given: %#v", err)
+	}
+
+	mr := registryModuleReader{store: gs.RegistryModules}
+	err = DecodeReferenceTargets(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DecodeReferenceOrigins(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaStackValidation(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ReferenceValidation(ctx, ss, mr, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := ss.StackRecordByPath(stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summaries := make([]string, 0)
+	for _, diagsMap := range record.Diagnostics {
+		for _, diags := range diagsMap {
+			for _, diag := range diags {
+				summaries = append(summaries, fmt.Sprintf("%d: %s", diag.Subject.Start.Line, diag.Summary))
+			}
+		}
+	}
+	sort.Strings(summaries)
+	expectedSummaries := []string{
+		`17: Reference to undeclared output`,
+		`5: Required input "name" not specified`,
+		`6: Unexpected input`,
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
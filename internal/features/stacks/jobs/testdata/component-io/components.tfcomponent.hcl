@@ -0,0 +1,52 @@
+component "app" {
+  source = "./app"
+
+  inputs = {
+    name    = "web"
+    size    = "big"
+    unknown = true
+  }
+}
+
+component "many" {
+  source   = "./app"
+  for_each = toset(["a", "b"])
+
+  inputs = {
+    name = each.key
+  }
+}
+
+component "empty" {
+  source = "./app"
+}
+
+output "id" {
+  type  = string
+  value = component.app.id
+}
+
+output "missing" {
+  type  = string
+  value = component.app.missing
+}
+
+output "each_id" {
+  type  = string
+  value = component.many["a"].id
+}
+
+output "each_missing" {
+  type  = string
+  value = component.many["a"].missing
+}
+
+output "no_key" {
+  type  = string
+  value = component.many.id
+}
+
+output "unknown" {
+  type  = string
+  value = component.unknown.id
+}
@@ -0,0 +1,17 @@
+deployment "dev" {
+  inputs = {
+    region         = "eu-west-2"
+    instance_count = "many"
+    unknown        = true
+  }
+}
+
+deployment "prod" {
+  inputs = {
+    instance_count = 2
+    
+  }
+}
+
+deployment "staging" {
+}
@@ -0,0 +1,13 @@
+variable "region" {
+  type = string
+}
+
+variable "instance_count" {
+  type    = number
+  default = 1
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
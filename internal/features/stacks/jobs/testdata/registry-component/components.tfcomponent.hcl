@@ -0,0 +1,18 @@
+component "ec" {
+  source  = "puppetlabs/deployment/ec"
+  version = "0.0.8"
+
+  inputs = {
+    region = "eu-west-1"
+  }
+}
+
+output "id" {
+  type  = string
+  value = component.ec.id
+}
+
+output "missing" {
+  type  = string
+  value = component.ec.missing
+}
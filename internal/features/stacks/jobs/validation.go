@@ -71,6 +71,26 @@ func SchemaStackValidation(ctx context.Context, stackStore *state.StackStore, mo
 		}
 		diags[ast.FilenameFromName(filename)] = fileDiags
 
+		if rpcContext.LanguageID == ilsp.Stacks.String() {
+			// Deployment inputs are validated against variables
+			// declared in stack files, so a change there may
+			// affect diagnostics in deployment files too
+			deployDecoder, err := d.Path(lang.Path{
+				Path:       stackPath,
+				LanguageID: ilsp.Deploy.String(),
+			})
+			if err != nil {
+				return err
+			}
+			deployDiags, err := deployDecoder.Validate(ctx)
+			if err != nil {
+				return err
+			}
+			for name, fileDiags := range deployDiags {
+				diags[ast.FilenameFromName(name)] = fileDiags
+			}
+		}
+
 		sErr := stackStore.UpdateDiagnostics(stackPath, globalAst.SchemaValidationSource, diags)
 		if sErr != nil {
 			return sErr
@@ -5,17 +5,26 @@ package jobs
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"sort"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	stackDecoder "github.com/hashicorp/terraform-ls/internal/features/stacks/decoder"
 	"github.com/hashicorp/terraform-ls/internal/features/stacks/state"
 	"github.com/hashicorp/terraform-ls/internal/filesystem"
 	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfmod "github.com/hashicorp/terraform-schema/module"
+	"github.com/hashicorp/terraform-schema/registry"
 )
 
 type ModuleReaderMock struct{}
@@ -24,6 +33,10 @@ func (m ModuleReaderMock) LocalModuleMeta(modulePath string) (*tfmod.Meta, error
 	return nil, nil
 }
 
+func (m ModuleReaderMock) RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*registry.ModuleData, error) {
+	return nil, nil
+}
+
 type RootReaderMock struct{}
 
 func (r RootReaderMock) InstalledModuleCalls(modPath string) (map[string]tfmod.InstalledModuleCall, error) {
@@ -150,9 +163,148 @@ func runTestSchemaStackValidation_SingleFile(t *testing.T, tc struct {
 		t.Fatal(err)
 	}
 
-	expectedCount := 2
+	// deployment files are validated too, since their inputs
+	// depend on variables declared in the changed file
+	expectedCount := 3
 	diagsCount := record.Diagnostics[ast.SchemaValidationSource].Count()
 	if diagsCount != expectedCount {
 		t.Fatalf("expected %d diagnostics, %d given", expectedCount, diagsCount)
 	}
 }
+
+func TestSchemaStackValidation_DeploymentInputs(t *testing.T) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := state.NewStackStore(gs.ChangeStore, gs.ProviderSchemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stackPath := filepath.Join(testData, "deployment-inputs")
+
+	err = ss.Add(stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{
+		Method:     "textDocument/didOpen",
+		LanguageID: ilsp.Deploy.String(),
+		URI:        "file:///test/deployments.tfdeploy.hcl",
+	})
+	err = ParseStackConfiguration(ctx, fs, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadStackMetadata(ctx, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SchemaStackValidation(ctx, ss, ModuleReaderMock{}, RootReaderMock{}, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := ss.StackRecordByPath(stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summaries := make([]string, 0)
+	for _, diags := range record.Diagnostics[ast.SchemaValidationSource] {
+		for _, diag := range diags {
+			summaries = append(summaries, fmt.Sprintf("%d: %s", diag.Subject.Start.Line, diag.Summary))
+		}
+	}
+	sort.Strings(summaries)
+	expectedSummaries := []string{
+		`10: Required input "region" not specified`,
+		`16: Required input "region" not specified`,
+		`4: Invalid value for input`,
+		`5: Unexpected input`,
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
+
+func TestDeploymentInputs_completion(t *testing.T) {
+	ctx := context.Background()
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := state.NewStackStore(gs.ChangeStore, gs.ProviderSchemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stackPath := filepath.Join(testData, "deployment-inputs")
+
+	err = ss.Add(stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := filesystem.NewFilesystem(gs.DocumentStore)
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{
+		Method:     "textDocument/didOpen",
+		LanguageID: ilsp.Deploy.String(),
+		URI:        "file:///test/deployments.tfdeploy.hcl",
+	})
+	err = ParseStackConfiguration(ctx, fs, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadStackMetadata(ctx, ss, stackPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := decoder.NewDecoder(&stackDecoder.PathReader{
+		StateReader:  ss,
+		ModuleReader: ModuleReaderMock{},
+		RootReader:   RootReaderMock{},
+	})
+	pathDecoder, err := d.Path(lang.Path{
+		Path:       stackPath,
+		LanguageID: ilsp.Deploy.String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// inside inputs of the "prod" deployment
+	candidates, err := pathDecoder.CompletionAtPos(ctx, "deployments.tfdeploy.hcl", hcl.Pos{
+		Line:   12,
+		Column: 5,
+		Byte:   186,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snippets := make([]string, 0)
+	for _, c := range candidates.List {
+		snippets = append(snippets, c.TextEdit.Snippet)
+	}
+	expectedSnippets := []string{
+		"region = \"${1:value}\"",
+		"tags = {\n  \"${1:name}\" = \"${2:value}\"\n}",
+	}
+	if diff := cmp.Diff(expectedSnippets, snippets); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
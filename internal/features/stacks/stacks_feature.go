@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/features/stacks/state"
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
+	"github.com/hashicorp/terraform-ls/internal/registry"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 )
 
@@ -27,11 +28,12 @@ type StacksFeature struct {
 	logger     *log.Logger
 	stopFunc   context.CancelFunc
 
-	moduleFeature stackDecoder.ModuleReader
-	rootFeature   stackDecoder.RootReader
+	moduleFeature  stackDecoder.ModuleReader
+	rootFeature    stackDecoder.RootReader
+	registryClient registry.Client
 }
 
-func NewStacksFeature(bus *eventbus.EventBus, stateStore *globalState.StateStore, fs jobs.ReadOnlyFS, moduleFeature stackDecoder.ModuleReader, rootFeature stackDecoder.RootReader) (*StacksFeature, error) {
+func NewStacksFeature(bus *eventbus.EventBus, stateStore *globalState.StateStore, fs jobs.ReadOnlyFS, moduleFeature stackDecoder.ModuleReader, rootFeature stackDecoder.RootReader, registryClient registry.Client) (*StacksFeature, error) {
 	store, err := state.NewStackStore(stateStore.ChangeStore, stateStore.ProviderSchemas)
 	if err != nil {
 		return nil, err
@@ -39,14 +41,15 @@ func NewStacksFeature(bus *eventbus.EventBus, stateStore *globalState.StateStore
 	discardLogger := log.New(io.Discard, "", 0)
 
 	return &StacksFeature{
-		store:         store,
-		bus:           bus,
-		fs:            fs,
-		stateStore:    stateStore,
-		logger:        discardLogger,
-		stopFunc:      func() {},
-		moduleFeature: moduleFeature,
-		rootFeature:   rootFeature,
+		store:          store,
+		bus:            bus,
+		fs:             fs,
+		stateStore:     stateStore,
+		logger:         discardLogger,
+		stopFunc:       func() {},
+		moduleFeature:  moduleFeature,
+		rootFeature:    rootFeature,
+		registryClient: registryClient,
 	}, nil
 }
 
@@ -8,8 +8,13 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 )
 
+// DefaultTestDirectory is the directory within a module
+// where Terraform looks for tests by default
+const DefaultTestDirectory = "tests"
+
 type Filename interface {
 	String() string
 	IsJSON() bool
@@ -33,7 +38,32 @@ func (mf TestFilename) IsIgnored() bool {
 
 func IsTestFilename(name string) bool {
 	return strings.HasSuffix(name, ".tftest.hcl") ||
-		strings.HasSuffix(name, ".tftest.json")
+		strings.HasSuffix(name, ".tftest.json") ||
+		strings.HasSuffix(name, ".tofutest.hcl") ||
+		strings.HasSuffix(name, ".tofutest.json")
+}
+
+// IsEngineTestFilename returns true for test files which are
+// loaded by the given engine, i.e. *.tofutest.hcl files are ignored
+// by Terraform
+func IsEngineTestFilename(name string, e engine.Engine) bool {
+	if engine.IsOpenTofuFilename(name) {
+		return e != engine.Terraform && IsTestFilename(name)
+	}
+	return IsTestFilename(name)
+}
+
+// OpenTofuTestOverrideFilename returns the name of the OpenTofu-specific
+// test file which takes precedence over the given Terraform test file,
+// e.g. main.tofutest.hcl for main.tftest.hcl, or false if there is no such file.
+func OpenTofuTestOverrideFilename(name string) (string, bool) {
+	if strings.HasSuffix(name, ".tftest.hcl") {
+		return strings.TrimSuffix(name, ".tftest.hcl") + ".tofutest.hcl", true
+	}
+	if strings.HasSuffix(name, ".tftest.json") {
+		return strings.TrimSuffix(name, ".tftest.json") + ".tofutest.json", true
+	}
+	return "", false
 }
 
 // MockFilename is a custom type for mock configuration files
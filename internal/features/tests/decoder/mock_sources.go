@@ -0,0 +1,45 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var mockProviderSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "mock_provider", LabelNames: []string{"name"}},
+	},
+}
+
+// MockProviderSources returns directories with shared mock data
+// (*.tfmock.hcl) referenced via source of mock_provider blocks
+func MockProviderSources(files ast.Files, testPath string) []string {
+	paths := make([]string, 0)
+
+	for name, f := range files {
+		if _, ok := name.(ast.TestFilename); !ok {
+			continue
+		}
+		content, _, _ := f.Body.PartialContent(mockProviderSchema)
+		for _, block := range content.Blocks {
+			attrs, _ := block.Body.JustAttributes()
+			sourceAttr, ok := attrs["source"]
+			if !ok {
+				continue
+			}
+			val, diags := sourceAttr.Expr.Value(nil)
+			if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+				continue
+			}
+			paths = append(paths, filepath.Join(testPath, filepath.FromSlash(val.AsString())))
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,380 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	resourceScope = lang.ScopeId("resource")
+	dataScope     = lang.ScopeId("data")
+	moduleScope   = lang.ScopeId("module")
+)
+
+// TestedModulePath returns the path of the module under test
+// for the given test directory. Tests either live alongside
+// the module or within its "tests" directory.
+func TestedModulePath(testPath string, hasModule func(path string) bool) (string, bool) {
+	if hasModule(testPath) {
+		return testPath, true
+	}
+	if filepath.Base(testPath) == ast.DefaultTestDirectory {
+		parentPath := filepath.Dir(testPath)
+		if hasModule(parentPath) {
+			return parentPath, true
+		}
+	}
+	return "", false
+}
+
+// testedModulePath returns the path of the module under test for
+// the given test directory. Directories with shared mock data
+// (e.g. tests/mocks/aws) resolve to the module under test of
+// the test directory which references them via mock_provider.
+func testedModulePath(testPath string, reader CombinedReader) (string, bool) {
+	hasModule := func(path string) bool {
+		meta, err := reader.LocalModuleMeta(path)
+		return err == nil && len(meta.Filenames) > 0
+	}
+
+	modPath, ok := TestedModulePath(testPath, hasModule)
+	if ok {
+		return modPath, true
+	}
+
+	records, err := reader.List()
+	if err != nil {
+		return "", false
+	}
+	for _, record := range records {
+		if record.Path() == testPath {
+			continue
+		}
+		for _, sourcePath := range MockProviderSources(record.ParsedFiles, record.Path()) {
+			if sourcePath != testPath {
+				continue
+			}
+			if modPath, ok := TestedModulePath(record.Path(), hasModule); ok {
+				return modPath, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// testedModule provides data about the module under test
+// which is relevant to override and mock blocks
+type testedModule struct {
+	path    string
+	meta    *tfmod.Meta
+	targets reference.Targets
+}
+
+func loadTestedModule(testPath string, reader CombinedReader) (*testedModule, bool) {
+	modPath, ok := testedModulePath(testPath, reader)
+	if !ok {
+		return nil, false
+	}
+
+	meta, err := reader.LocalModuleMeta(modPath)
+	if err != nil {
+		return nil, false
+	}
+	targets, err := reader.ReferenceTargetsForModule(modPath)
+	if err != nil {
+		return nil, false
+	}
+
+	return &testedModule{
+		path:    modPath,
+		meta:    meta,
+		targets: targets,
+	}, true
+}
+
+// referenceTargets returns targets of resources, data sources
+// and module calls, which can be overridden within tests
+func (tm *testedModule) referenceTargets(testPath string) reference.Targets {
+	targets := make(reference.Targets, 0)
+	for _, target := range tm.targets {
+		if target.ScopeId != resourceScope && target.ScopeId != dataScope && target.ScopeId != moduleScope {
+			continue
+		}
+		if tm.path != testPath {
+			// Ranges are relative to the module directory,
+			// so they would point to non-existent files here
+			target = target.Copy()
+			target.RangePtr = nil
+			target.DefRangePtr = nil
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// patchSchema makes override and mock blocks aware of resources,
+// data sources and module calls of the module under test, such that
+// values can be completed and validated based on their schemas.
+func (tm *testedModule) patchSchema(bs *schema.BodySchema, reader CombinedReader) {
+	resourceBodies := make(map[string]*schema.BodySchema, 0)
+	dataBodies := make(map[string]*schema.BodySchema, 0)
+	overrideResources := make(map[schema.SchemaKey]*schema.BodySchema, 0)
+	overrideData := make(map[schema.SchemaKey]*schema.BodySchema, 0)
+	overrideModules := make(map[schema.SchemaKey]*schema.BodySchema, 0)
+
+	for _, target := range tm.targets {
+		switch target.ScopeId {
+		case resourceScope:
+			if len(target.Addr) != 2 {
+				continue
+			}
+			typeName, ok := ihcl.AddressStepName(target.Addr[0])
+			if !ok {
+				continue
+			}
+			body, ok := tm.resourceSchema(typeName, false, reader)
+			if !ok {
+				continue
+			}
+			resourceBodies[typeName] = body
+			overrideResources[targetDependencyKey(target.Addr)] = valuesBodySchema("values", body)
+		case dataScope:
+			if len(target.Addr) != 3 {
+				continue
+			}
+			typeName, ok := ihcl.AddressStepName(target.Addr[1])
+			if !ok {
+				continue
+			}
+			body, ok := tm.resourceSchema(typeName, true, reader)
+			if !ok {
+				continue
+			}
+			dataBodies[typeName] = body
+			overrideData[targetDependencyKey(target.Addr)] = valuesBodySchema("values", body)
+		case moduleScope:
+			if len(target.Addr) != 2 {
+				continue
+			}
+			name, ok := ihcl.AddressStepName(target.Addr[1])
+			if !ok {
+				continue
+			}
+			outputs, ok := tm.moduleOutputs(name, reader)
+			if !ok {
+				continue
+			}
+			overrideModules[targetDependencyKey(target.Addr)] = &schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"outputs": {
+						Constraint:  schema.Object{Attributes: outputs},
+						IsOptional:  true,
+						Description: lang.Markdown("Specify the values that should be returned for specific outputs"),
+					},
+				},
+			}
+		}
+	}
+
+	mockResources := make(map[schema.SchemaKey]*schema.BodySchema, 0)
+	for typeName, body := range resourceBodies {
+		mockResources[labelDependencyKey(typeName)] = valuesBodySchema("defaults", body)
+	}
+	mockData := make(map[schema.SchemaKey]*schema.BodySchema, 0)
+	for typeName, body := range dataBodies {
+		mockData[labelDependencyKey(typeName)] = valuesBodySchema("defaults", body)
+	}
+
+	patch := func(body *schema.BodySchema) {
+		if body == nil {
+			return
+		}
+		patchTargetedBlock(body.Blocks["override_resource"], overrideResources)
+		patchTargetedBlock(body.Blocks["override_data"], overrideData)
+		patchTargetedBlock(body.Blocks["override_module"], overrideModules)
+		patchDependentBlock(body.Blocks["mock_resource"], mockResources)
+		patchDependentBlock(body.Blocks["mock_data"], mockData)
+	}
+
+	patch(bs)
+	for _, blockType := range []string{"mock_provider", "run"} {
+		if block, ok := bs.Blocks[blockType]; ok {
+			patch(block.Body)
+		}
+	}
+}
+
+// resourceSchema returns the body schema of a resource or data source
+// based on the provider schema of the provider implied by its type
+func (tm *testedModule) resourceSchema(typeName string, isData bool, reader CombinedReader) (*schema.BodySchema, bool) {
+	for ref, pAddr := range tm.meta.ProviderReferences {
+		if ref.Alias != "" || !tfschema.TypeBelongsToProvider(typeName, ref) {
+			continue
+		}
+		if body, ok := tm.providerResourceSchema(pAddr, typeName, isData, reader); ok {
+			return body, true
+		}
+	}
+
+	// Providers which are not explicitly required are assumed
+	// to come from the default namespace, as in Terraform
+	localName, _, _ := strings.Cut(typeName, "_")
+	return tm.providerResourceSchema(tfaddr.NewProvider(tfaddr.DefaultProviderRegistryHost, "hashicorp", localName),
+		typeName, isData, reader)
+}
+
+func (tm *testedModule) providerResourceSchema(pAddr tfaddr.Provider, typeName string, isData bool, reader CombinedReader) (*schema.BodySchema, bool) {
+	pSchema, err := reader.ProviderSchema(tm.path, pAddr, tm.meta.ProviderRequirements[pAddr])
+	if err != nil || pSchema == nil {
+		return nil, false
+	}
+
+	var body *schema.BodySchema
+	if isData {
+		body = pSchema.DataSources[typeName]
+	} else {
+		body = pSchema.Resources[typeName]
+	}
+	return body, body != nil
+}
+
+// moduleOutputs returns outputs of a local module called
+// from the module under test
+func (tm *testedModule) moduleOutputs(name string, reader CombinedReader) (schema.ObjectAttributes, bool) {
+	call, ok := tm.meta.ModuleCalls[name]
+	if !ok {
+		return nil, false
+	}
+	if _, ok := call.SourceAddr.(tfmod.LocalSourceAddr); !ok {
+		return nil, false
+	}
+
+	meta, err := reader.LocalModuleMeta(filepath.Join(tm.path, filepath.FromSlash(call.SourceAddr.String())))
+	if err != nil {
+		return nil, false
+	}
+
+	attrs := make(schema.ObjectAttributes, len(meta.Outputs))
+	for outputName, output := range meta.Outputs {
+		attrs[outputName] = &schema.AttributeSchema{
+			Constraint:  schema.AnyExpression{OfType: cty.DynamicPseudoType},
+			IsOptional:  true,
+			Description: lang.PlainText(output.Description),
+		}
+	}
+	return attrs, true
+}
+
+func targetDependencyKey(addr lang.Address) schema.SchemaKey {
+	return schema.NewSchemaKey(schema.DependencyKeys{
+		Attributes: []schema.AttributeDependent{
+			{
+				Name: "target",
+				Expr: schema.ExpressionValue{
+					Address: addr,
+				},
+			},
+		},
+	})
+}
+
+func labelDependencyKey(typeName string) schema.SchemaKey {
+	return schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{
+			{Index: 0, Value: typeName},
+		},
+	})
+}
+
+func patchTargetedBlock(block *schema.BlockSchema, depBodies map[schema.SchemaKey]*schema.BodySchema) {
+	if block == nil || block.Body == nil || len(depBodies) == 0 {
+		return
+	}
+	target, ok := block.Body.Attributes["target"]
+	if !ok {
+		return
+	}
+	target.IsDepKey = true
+	patchDependentBlock(block, depBodies)
+}
+
+func patchDependentBlock(block *schema.BlockSchema, depBodies map[schema.SchemaKey]*schema.BodySchema) {
+	if block == nil || len(depBodies) == 0 {
+		return
+	}
+	if block.DependentBody == nil {
+		block.DependentBody = make(map[schema.SchemaKey]*schema.BodySchema, len(depBodies))
+	}
+	for key, body := range depBodies {
+		block.DependentBody[key] = body
+	}
+}
+
+// valuesBodySchema returns a body with a single attribute
+// of the given name representing (all optional) attributes
+// of the given resource or data source
+func valuesBodySchema(attrName string, resourceBody *schema.BodySchema) *schema.BodySchema {
+	return &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			attrName: {
+				Constraint:  schema.Object{Attributes: objectAttributesFromBody(resourceBody)},
+				IsOptional:  true,
+				Description: lang.Markdown("Specify the values that should be returned for specific attributes"),
+			},
+		},
+	}
+}
+
+func objectAttributesFromBody(body *schema.BodySchema) schema.ObjectAttributes {
+	attrs := make(schema.ObjectAttributes, 0)
+	if body == nil {
+		return attrs
+	}
+
+	for name, attr := range body.Attributes {
+		attr = attr.Copy()
+		// Any attribute, including computed ones, can be overridden
+		// and none of them has to be
+		attr.IsRequired = false
+		attr.IsOptional = true
+		attr.IsComputed = false
+		attr.IsDepKey = false
+		attrs[name] = attr
+	}
+
+	for name, block := range body.Blocks {
+		var cons schema.Constraint = schema.Object{
+			Attributes: objectAttributesFromBody(block.Body),
+		}
+		switch block.Type {
+		case schema.BlockTypeList:
+			cons = schema.List{Elem: cons}
+		case schema.BlockTypeSet:
+			cons = schema.Set{Elem: cons}
+		case schema.BlockTypeMap:
+			cons = schema.Map{Elem: cons}
+		}
+		attrs[name] = &schema.AttributeSchema{
+			Constraint:   cons,
+			IsOptional:   true,
+			Description:  block.Description,
+			IsDeprecated: block.IsDeprecated,
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,339 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/state"
+	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfmod "github.com/hashicorp/terraform-schema/module"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	testschema "github.com/hashicorp/terraform-schema/schema/tests"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	testModPath = filepath.Join("tmp", "mod")
+	awsAddr     = tfaddr.MustParseProviderSource("hashicorp/aws")
+)
+
+type testedModuleReader struct {
+	meta    map[string]*tfmod.Meta
+	targets map[string]reference.Targets
+}
+
+func (r testedModuleReader) LocalModuleMeta(modPath string) (*tfmod.Meta, error) {
+	meta, ok := r.meta[modPath]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return meta, nil
+}
+
+func (r testedModuleReader) ReferenceTargetsForModule(modPath string) (reference.Targets, error) {
+	return r.targets[modPath], nil
+}
+
+func (r testedModuleReader) List() ([]*state.TestRecord, error) {
+	return nil, nil
+}
+
+func (r testedModuleReader) TestRecordByPath(modPath string) (*state.TestRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r testedModuleReader) ProviderSchema(modPath string, addr tfaddr.Provider, vc version.Constraints) (*tfschema.ProviderSchema, error) {
+	if !addr.Equals(awsAddr) {
+		return nil, errors.New("not found")
+	}
+	return &tfschema.ProviderSchema{
+		Resources: map[string]*schema.BodySchema{
+			"aws_instance": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"ami": {
+						Constraint: schema.LiteralType{Type: cty.String},
+						IsRequired: true,
+					},
+					"arn": {
+						Constraint: schema.LiteralType{Type: cty.String},
+						IsComputed: true,
+					},
+				},
+			},
+		},
+		DataSources: map[string]*schema.BodySchema{
+			"aws_ami": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"image_id": {
+						Constraint: schema.LiteralType{Type: cty.String},
+						IsComputed: true,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r testedModuleReader) TerraformVersion(modPath string) *version.Version {
+	return nil
+}
+
+func newTestedModuleReader() CombinedReader {
+	r := testedModuleReader{
+		meta: map[string]*tfmod.Meta{
+			testModPath: {
+				Path:      testModPath,
+				Filenames: []string{"main.tf"},
+				ProviderReferences: map[tfmod.ProviderRef]tfaddr.Provider{
+					{LocalName: "aws"}: awsAddr,
+				},
+				ProviderRequirements: tfmod.ProviderRequirements{
+					awsAddr: version.Constraints{},
+				},
+			},
+		},
+		targets: map[string]reference.Targets{
+			testModPath: {
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "web"},
+					},
+					ScopeId: resourceScope,
+					RangePtr: &hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.InitialPos,
+						End:      hcl.InitialPos,
+					},
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "data"},
+						lang.AttrStep{Name: "aws_ami"},
+						lang.AttrStep{Name: "ubuntu"},
+					},
+					ScopeId: dataScope,
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "region"},
+					},
+					ScopeId: lang.ScopeId("variable"),
+				},
+			},
+		},
+	}
+	return CombinedReader{
+		ModuleReader: r,
+		StateReader:  r,
+		RootReader:   r,
+	}
+}
+
+func TestTestedModulePath(t *testing.T) {
+	reader := newTestedModuleReader()
+	hasModule := func(path string) bool {
+		_, err := reader.LocalModuleMeta(path)
+		return err == nil
+	}
+
+	modPath, ok := TestedModulePath(testModPath, hasModule)
+	if !ok || modPath != testModPath {
+		t.Fatalf("expected module alongside tests, given: %q", modPath)
+	}
+
+	modPath, ok = TestedModulePath(filepath.Join(testModPath, "tests"), hasModule)
+	if !ok || modPath != testModPath {
+		t.Fatalf("expected module in parent directory, given: %q", modPath)
+	}
+
+	_, ok = TestedModulePath(filepath.Join(testModPath, "other"), hasModule)
+	if ok {
+		t.Fatal("expected no module for unrelated directory")
+	}
+}
+
+func TestTestedModule_mockProviderSource(t *testing.T) {
+	gs, err := globalState.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, err := state.NewTestStore(gs.ChangeStore, gs.ProviderSchemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testPath := filepath.Join(testModPath, "tests")
+	mockPath := filepath.Join(testPath, "mocks", "aws")
+	for _, path := range []string{testPath, mockPath} {
+		err = ts.Add(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, diags := hclsyntax.ParseConfig([]byte(`mock_provider "aws" {
+  source = "./mocks/aws"
+}
+`), "main.tftest.hcl", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	err = ts.UpdateParsedFiles(testPath, ast.Files{
+		ast.TestFilename("main.tftest.hcl"): f,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := newTestedModuleReader()
+	reader.StateReader = ts
+
+	tm, ok := loadTestedModule(mockPath, reader)
+	if !ok {
+		t.Fatal("expected module under test to be found for mock source")
+	}
+	if tm.path != testModPath {
+		t.Fatalf("expected module %q, given: %q", testModPath, tm.path)
+	}
+
+	_, ok = loadTestedModule(filepath.Join(testPath, "mocks", "gcp"), reader)
+	if ok {
+		t.Fatal("expected no module for unreferenced mock directory")
+	}
+}
+
+func TestTestedModule_referenceTargets(t *testing.T) {
+	tm, ok := loadTestedModule(filepath.Join(testModPath, "tests"), newTestedModuleReader())
+	if !ok {
+		t.Fatal("expected module under test to be found")
+	}
+
+	targets := tm.referenceTargets(filepath.Join(testModPath, "tests"))
+	addrs := make([]string, 0)
+	for _, target := range targets {
+		addrs = append(addrs, target.Addr.String())
+		if target.RangePtr != nil {
+			t.Fatalf("expected range of %s to be removed", target.Addr)
+		}
+	}
+	expectedAddrs := []string{"aws_instance.web", "data.aws_ami.ubuntu"}
+	if diff := cmp.Diff(expectedAddrs, addrs); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+}
+
+func TestTestedModule_overrideValues(t *testing.T) {
+	reader := newTestedModuleReader()
+	testPath := filepath.Join(testModPath, "tests")
+
+	tm, ok := loadTestedModule(testPath, reader)
+	if !ok {
+		t.Fatal("expected module under test to be found")
+	}
+
+	coreSchema, err := testschema.CoreTestSchemaForVersion(tfschema.LatestAvailableVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bodySchema := coreSchema.Copy()
+	tm.patchSchema(bodySchema, reader)
+
+	cfg := []byte(`override_resource {
+  target = aws_instance.web
+  values = {
+    arn = "arn:aws:ec2:eu-west-1:123456789012:instance/i-1234"
+    foo = "bar"
+  }
+}
+run "test" {
+  override_data {
+    target = data.aws_ami.ubuntu
+    values = {
+      
+    }
+  }
+}
+`)
+	f, diags := hclsyntax.ParseConfig(cfg, "main.tftest.hcl", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	d := decoder.NewDecoder(&staticPathReader{
+		pathCtx: &decoder.PathContext{
+			Schema:           bodySchema,
+			ReferenceOrigins: make(reference.Origins, 0),
+			ReferenceTargets: tm.referenceTargets(testPath),
+			Files: map[string]*hcl.File{
+				"main.tftest.hcl": f,
+			},
+			Validators: validators,
+		},
+	})
+	pathDecoder, err := d.Path(lang.Path{Path: testPath, LanguageID: "terraform-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates, err := pathDecoder.CompletionAtPos(context.Background(), "main.tftest.hcl", hcl.Pos{
+		Line:   12,
+		Column: 7,
+		Byte:   231,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := make([]string, 0)
+	for _, c := range candidates.List {
+		labels = append(labels, c.Label)
+	}
+	if diff := cmp.Diff([]string{"image_id"}, labels); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+
+	fileDiags, err := pathDecoder.ValidateFile(context.Background(), "main.tftest.hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	summaries := make([]string, 0)
+	for _, diag := range fileDiags {
+		summaries = append(summaries, diag.Summary+": "+diag.Detail)
+	}
+	sort.Strings(summaries)
+	expectedSummaries := []string{
+		`Unexpected attribute: An attribute named "foo" is not expected here`,
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
+
+type staticPathReader struct {
+	pathCtx *decoder.PathContext
+}
+
+func (r *staticPathReader) PathContext(path lang.Path) (*decoder.PathContext, error) {
+	return r.pathCtx, nil
+}
+
+func (r *staticPathReader) Paths(ctx context.Context) []lang.Path {
+	return []lang.Path{}
+}
@@ -36,6 +36,7 @@ type StateReader interface {
 
 type ModuleReader interface {
 	LocalModuleMeta(modPath string) (*tfmod.Meta, error)
+	ReferenceTargetsForModule(modPath string) (reference.Targets, error)
 }
 
 type RootReader interface {
@@ -100,6 +101,11 @@ func testPathContext(record *state.TestRecord, stateReader CombinedReader) (*dec
 		return nil, err
 	}
 
+	testedModule, hasModule := loadTestedModule(record.Path(), stateReader)
+	if hasModule {
+		testedModule.patchSchema(mergedSchema, stateReader)
+	}
+
 	pathCtx := &decoder.PathContext{
 		Schema:           mergedSchema,
 		ReferenceOrigins: make(reference.Origins, 0),
@@ -119,6 +125,9 @@ func testPathContext(record *state.TestRecord, stateReader CombinedReader) (*dec
 			pathCtx.ReferenceTargets = append(pathCtx.ReferenceTargets, target)
 		}
 	}
+	if hasModule {
+		pathCtx.ReferenceTargets = append(pathCtx.ReferenceTargets, testedModule.referenceTargets(record.Path())...)
+	}
 
 	for name, f := range record.ParsedFiles {
 		if _, ok := name.(ast.TestFilename); ok {
@@ -154,6 +163,11 @@ func mockPathContext(record *state.TestRecord, stateReader CombinedReader) (*dec
 		return nil, err
 	}
 
+	testedModule, hasModule := loadTestedModule(record.Path(), stateReader)
+	if hasModule {
+		testedModule.patchSchema(mergedSchema, stateReader)
+	}
+
 	pathCtx := &decoder.PathContext{
 		Schema:           mergedSchema,
 		ReferenceOrigins: make(reference.Origins, 0),
@@ -173,6 +187,9 @@ func mockPathContext(record *state.TestRecord, stateReader CombinedReader) (*dec
 			pathCtx.ReferenceTargets = append(pathCtx.ReferenceTargets, target)
 		}
 	}
+	if hasModule {
+		pathCtx.ReferenceTargets = append(pathCtx.ReferenceTargets, testedModule.referenceTargets(record.Path())...)
+	}
 
 	for name, f := range record.ParsedFiles {
 		if _, ok := name.(ast.MockFilename); ok {
@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package validations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+// UnexpectedObjectAttribute reports attributes within object expressions
+// which are not known to the schema, such as misspelled resource
+// attributes within values of an override_resource block.
+type UnexpectedObjectAttribute struct{}
+
+func (v UnexpectedObjectAttribute) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	if schemacontext.HasUnknownSchema(ctx) {
+		return ctx, diags
+	}
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok || nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+
+	return ctx, unexpectedObjectAttributes(attr.Expr, attrSchema.Constraint)
+}
+
+func unexpectedObjectAttributes(expr hclsyntax.Expression, cons schema.Constraint) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	obj, ok := cons.(schema.Object)
+	// Objects without any known attributes represent
+	// cases where we don't know the schema
+	if !ok || len(obj.Attributes) == 0 {
+		return diags
+	}
+	objExpr, ok := expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return diags
+	}
+
+	for _, item := range objExpr.Items {
+		name, ok := ihcl.ObjectKeyName(item.KeyExpr)
+		if !ok {
+			continue
+		}
+
+		aSchema, ok := obj.Attributes[name]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unexpected attribute",
+				Detail:   fmt.Sprintf("An attribute named %q is not expected here", name),
+				Subject:  item.KeyExpr.Range().Ptr(),
+			})
+			continue
+		}
+
+		diags = append(diags, unexpectedObjectAttributes(item.ValueExpr, aSchema.Constraint)...)
+	}
+
+	return diags
+}
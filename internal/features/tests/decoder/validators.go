@@ -5,6 +5,7 @@ package decoder
 
 import (
 	"github.com/hashicorp/hcl-lang/validator"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/decoder/validations"
 )
 
 var validators = []validator.Validator{
@@ -16,4 +17,5 @@ var validators = []validator.Validator{
 	validator.MissingRequiredAttribute{},
 	validator.UnexpectedAttribute{},
 	validator.UnexpectedBlock{},
+	validations.UnexpectedObjectAttribute{},
 }
@@ -11,7 +11,9 @@ import (
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/eventbus"
+	modAst "github.com/hashicorp/terraform-ls/internal/features/modules/ast"
 	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
+	testDecoder "github.com/hashicorp/terraform-ls/internal/features/tests/decoder"
 	"github.com/hashicorp/terraform-ls/internal/features/tests/jobs"
 	"github.com/hashicorp/terraform-ls/internal/features/tests/state"
 	"github.com/hashicorp/terraform-ls/internal/job"
@@ -193,7 +195,7 @@ func (f *TestsFeature) decodeTest(ctx context.Context, dir document.DirHandle, i
 				f.logger.Printf("loading module metadata returned error: %s", jobErr)
 			}
 
-			spawnedIds, err := loadTestModuleSources(ctx, f.store, f.bus, path)
+			spawnedIds, err := loadTestModuleSources(ctx, f.store, f.bus, f.fs, path)
 			if err != nil {
 				return deferIds, err
 			}
@@ -269,17 +271,54 @@ func (f *TestsFeature) removeIndexedTest(rawPath string) {
 	}
 }
 
-func loadTestModuleSources(ctx context.Context, testStore *state.TestStore, bus *eventbus.EventBus, testPath string) (job.IDs, error) {
+func loadTestModuleSources(ctx context.Context, testStore *state.TestStore, bus *eventbus.EventBus, fs jobs.ReadOnlyFS, testPath string) (job.IDs, error) {
 	ids := make(job.IDs, 0)
 
-	_, err := testStore.TestRecordByPath(testPath)
+	record, err := testStore.TestRecordByPath(testPath)
 	if err != nil {
 		return ids, err
 	}
 
-	// TODO! load the adjacent Terraform module (usually ../) TFECO-7479
+	// The module under test is located either alongside tests
+	// or in the parent directory (of the "tests" directory)
+	modPath, ok := testDecoder.TestedModulePath(testPath, func(path string) bool {
+		return hasModuleFiles(fs, path)
+	})
+	if ok {
+		spawnedIds := bus.DidOpen(eventbus.DidOpenEvent{
+			Context:    ctx,
+			Dir:        document.DirHandleFromPath(modPath),
+			LanguageID: lsp.Terraform.String(),
+		})
+		ids = append(ids, spawnedIds...)
+	}
+
 	// TODO load the run -> module block sources TFECO-7483
-	// TODO load the mock_provider block sources TFECO-7481
+
+	for _, sourcePath := range testDecoder.MockProviderSources(record.ParsedFiles, testPath) {
+		if sourcePath == testPath {
+			continue
+		}
+		spawnedIds := bus.DidOpen(eventbus.DidOpenEvent{
+			Context:    ctx,
+			Dir:        document.DirHandleFromPath(sourcePath),
+			LanguageID: lsp.Mock.String(),
+		})
+		ids = append(ids, spawnedIds...)
+	}
 
 	return ids, nil
 }
+
+func hasModuleFiles(fs jobs.ReadOnlyFS, path string) bool {
+	entries, err := fs.ReadDir(path)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && modAst.IsModuleFilename(entry.Name()) && !globalAst.IsIgnoredFile(entry.Name()) {
+			return true
+		}
+	}
+	return false
+}
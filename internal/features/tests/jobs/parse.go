@@ -43,7 +43,8 @@ func ParseTestConfiguration(ctx context.Context, fs ReadOnlyFS, testStore *state
 	// Only parse the file that's being changed/opened, unless this is 1st-time parsing
 	if record.DiagnosticsState[globalAst.HCLParsingSource] == operation.OpStateLoaded &&
 		rpcContext.IsDidChangeRequest() &&
-		isMatchingLanguageId {
+		isMatchingLanguageId &&
+		isParsedIndividually(record, rpcContext.URI) {
 		// the file has already been parsed, so only examine this file and not the whole module
 		err = testStore.SetDiagnosticsState(testPath, globalAst.HCLParsingSource, operation.OpStateLoading)
 		if err != nil {
@@ -80,7 +81,7 @@ func ParseTestConfiguration(ctx context.Context, fs ReadOnlyFS, testStore *state
 			return err
 		}
 
-		files, diags, err = parser.ParseFiles(fs, testPath)
+		files, diags, err = parser.ParseFiles(fs, testPath, testStore.Engine())
 	}
 
 	sErr := testStore.UpdateParsedFiles(testPath, files, err)
@@ -95,3 +96,15 @@ func ParseTestConfiguration(ctx context.Context, fs ReadOnlyFS, testStore *state
 
 	return err
 }
+
+// isParsedIndividually reports whether the changed file can be parsed
+// on its own, i.e. whether it is already among the parsed files,
+// as e.g. a new main.tofutest.hcl replaces main.tftest.hcl in OpenTofu
+func isParsedIndividually(record *state.TestRecord, docURI string) bool {
+	filePath, err := uri.PathFromURI(docURI)
+	if err != nil {
+		return true
+	}
+	_, ok := record.ParsedFiles[ast.FilenameFromName(filepath.Base(filePath))]
+	return ok
+}
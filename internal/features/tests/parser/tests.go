@@ -8,10 +8,11 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"github.com/hashicorp/terraform-ls/internal/terraform/parser"
 )
 
-func ParseFiles(fs parser.FS, testPath string) (ast.Files, ast.Diagnostics, error) {
+func ParseFiles(fs parser.FS, testPath string, eng engine.Engine) (ast.Files, ast.Diagnostics, error) {
 	files := make(ast.Files, 0)
 	diags := make(ast.Diagnostics, 0)
 
@@ -20,6 +21,17 @@ func ParseFiles(fs parser.FS, testPath string) (ast.Files, ast.Diagnostics, erro
 		return nil, nil, err
 	}
 
+	names := make(map[string]struct{}, len(infos))
+	filenames := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		names[info.Name()] = struct{}{}
+		filenames = append(filenames, info.Name())
+	}
+	eng = engine.FromFilenames(eng, filenames)
+
 	for _, info := range infos {
 		if info.IsDir() {
 			// We only care about files
@@ -27,10 +39,18 @@ func ParseFiles(fs parser.FS, testPath string) (ast.Files, ast.Diagnostics, erro
 		}
 
 		name := info.Name()
-		if !ast.IsTestFilename(name) && !ast.IsMockFilename(name) {
+		if !ast.IsEngineTestFilename(name, eng) && !ast.IsMockFilename(name) {
 			continue
 		}
 
+		// OpenTofu loads e.g. main.tofutest.hcl instead of
+		// main.tftest.hcl when both files are present
+		if tofuName, ok := ast.OpenTofuTestOverrideFilename(name); ok && eng == engine.OpenTofu {
+			if _, exists := names[tofuName]; exists {
+				continue
+			}
+		}
+
 		fullPath := filepath.Join(testPath, name)
 
 		src, err := fs.ReadFile(fullPath)
@@ -9,10 +9,12 @@ import (
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
 	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfschema "github.com/hashicorp/terraform-schema/schema"
@@ -26,12 +28,25 @@ type TestStore struct {
 
 	changeStore          *globalState.ChangeStore
 	providerSchemasStore *globalState.ProviderSchemaStore
+
+	// engine represents the engine configured for the session
+	engine engine.Engine
 }
 
 func (s *TestStore) SetLogger(logger *log.Logger) {
 	s.logger = logger
 }
 
+// SetEngine sets the engine configured for the session
+func (s *TestStore) SetEngine(e engine.Engine) {
+	s.engine = e
+}
+
+// Engine returns the engine configured for the session
+func (s *TestStore) Engine() engine.Engine {
+	return s.engine
+}
+
 func (s *TestStore) Add(testPath string) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
@@ -208,6 +223,42 @@ func (s *TestStore) UpdateDiagnostics(path string, source globalAst.DiagnosticSo
 	return nil
 }
 
+// UpdateFileDiagnostics replaces diagnostics of a single file
+// from the given source, leaving other files untouched
+func (s *TestStore) UpdateFileDiagnostics(path string, source globalAst.DiagnosticSource, filename ast.Filename, diags hcl.Diagnostics) error {
+	txn := s.db.Txn(true)
+	txn.Defer(func() {
+		s.SetDiagnosticsState(path, source, op.OpStateLoaded)
+	})
+	defer txn.Abort()
+
+	oldMod, err := testByPath(txn, path)
+	if err != nil {
+		return err
+	}
+
+	mod := oldMod.Copy()
+	if mod.Diagnostics == nil {
+		mod.Diagnostics = make(ast.SourceDiagnostics)
+	}
+	sourceDiags := mod.Diagnostics[source].Copy()
+	sourceDiags[filename] = diags
+	mod.Diagnostics[source] = sourceDiags
+
+	err = txn.Insert(s.tableName, mod)
+	if err != nil {
+		return err
+	}
+
+	err = s.queueRecordChange(oldMod, mod)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 func (s *TestStore) SetMetaState(path string, state op.OpState) error {
 	txn := s.db.Txn(true)
 	defer txn.Abort()
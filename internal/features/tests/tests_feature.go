@@ -10,13 +10,17 @@ import (
 
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-ls/internal/eventbus"
 	"github.com/hashicorp/terraform-ls/internal/features/modules/jobs"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
 	testDecoder "github.com/hashicorp/terraform-ls/internal/features/tests/decoder"
 	"github.com/hashicorp/terraform-ls/internal/features/tests/state"
 	"github.com/hashicorp/terraform-ls/internal/job"
 	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
 	globalState "github.com/hashicorp/terraform-ls/internal/state"
+	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 )
 
 type TestsFeature struct {
@@ -54,6 +58,12 @@ func (f *TestsFeature) SetLogger(logger *log.Logger) {
 	f.store.SetLogger(logger)
 }
 
+// SetEngine sets the engine configured (or detected) for the session.
+// engine.Auto means that the engine is detected for each test directory.
+func (f *TestsFeature) SetEngine(e engine.Engine) {
+	f.store.SetEngine(e)
+}
+
 // Start starts the features separate goroutine.
 // It listens to various events from the EventBus and performs corresponding actions.
 func (f *TestsFeature) Start(ctx context.Context) {
@@ -136,3 +146,17 @@ func (f *TestsFeature) Diagnostics(path string) diagnostics.Diagnostics {
 
 	return diags
 }
+
+// TestedModulePath returns the path of the module which
+// tests in the given directory are run against
+func (f *TestsFeature) TestedModulePath(testPath string) (string, bool) {
+	return testDecoder.TestedModulePath(testPath, func(path string) bool {
+		return hasModuleFiles(f.fs, path)
+	})
+}
+
+// UpdateTestResults replaces diagnostics from an earlier
+// test run of the given file with the provided ones
+func (f *TestsFeature) UpdateTestResults(testPath, filename string, diags hcl.Diagnostics) error {
+	return f.store.UpdateFileDiagnostics(testPath, globalAst.TerraformTestSource, ast.TestFilename(filename), diags)
+}
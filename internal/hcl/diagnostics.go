@@ -0,0 +1,13 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+// Informational is attached as extra information to diagnostics
+// which should be reported with the information severity,
+// because HCL itself has no such severity
+type Informational struct{}
+
+func (Informational) DiagnosticInformational() bool {
+	return true
+}
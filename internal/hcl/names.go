@@ -0,0 +1,57 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	hcllib "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ObjectKeyName returns the name of an object attribute key,
+// which may be either a keyword or a quoted string
+func ObjectKeyName(keyExpr hcllib.Expression) (string, bool) {
+	if kw := hcllib.ExprAsKeyword(keyExpr); kw != "" {
+		return kw, true
+	}
+
+	val, diags := keyExpr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+// AddressStepName returns the name of a root or attribute step,
+// or the key of an index step with a known string key
+func AddressStepName(step lang.AddressStep) (string, bool) {
+	switch s := step.(type) {
+	case lang.RootStep:
+		return s.Name, true
+	case lang.AttrStep:
+		return s.Name, true
+	case lang.IndexStep:
+		return stringKey(s.Key)
+	}
+	return "", false
+}
+
+// PathStepName returns the name of an attribute step,
+// or the key of an index step with a known string key
+func PathStepName(step cty.PathStep) (string, bool) {
+	switch s := step.(type) {
+	case cty.GetAttrStep:
+		return s.Name, true
+	case cty.IndexStep:
+		return stringKey(s.Key)
+	}
+	return "", false
+}
+
+func stringKey(key cty.Value) (string, bool) {
+	if key.Type() != cty.String || !key.IsKnown() || key.IsNull() {
+		return "", false
+	}
+	return key.AsString(), true
+}
@@ -0,0 +1,73 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestObjectKeyName(t *testing.T) {
+	testCases := []struct {
+		src          string
+		expectedName string
+		expectedOk   bool
+	}{
+		{"foo", "foo", true},
+		{`"foo"`, "foo", true},
+		{"var.foo", "", false},
+		{"42", "", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.src), func(t *testing.T) {
+			expr, diags := hclsyntax.ParseExpression([]byte(tc.src), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			name, ok := ObjectKeyName(expr)
+			if name != tc.expectedName || ok != tc.expectedOk {
+				t.Fatalf("expected %q (%t), given %q (%t)", tc.expectedName, tc.expectedOk, name, ok)
+			}
+		})
+	}
+}
+
+func TestStepName(t *testing.T) {
+	testCases := []struct {
+		step         interface{}
+		expectedName string
+		expectedOk   bool
+	}{
+		{lang.RootStep{Name: "var"}, "var", true},
+		{lang.AttrStep{Name: "foo"}, "foo", true},
+		{lang.IndexStep{Key: cty.StringVal("foo")}, "foo", true},
+		{lang.IndexStep{Key: cty.NumberIntVal(0)}, "", false},
+		{lang.IndexStep{Key: cty.UnknownVal(cty.String)}, "", false},
+		{cty.GetAttrStep{Name: "foo"}, "foo", true},
+		{cty.IndexStep{Key: cty.StringVal("foo")}, "foo", true},
+		{cty.IndexStep{Key: cty.NumberIntVal(0)}, "", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var name string
+			var ok bool
+			switch step := tc.step.(type) {
+			case lang.AddressStep:
+				name, ok = AddressStepName(step)
+			case cty.PathStep:
+				name, ok = PathStepName(step)
+			}
+			if name != tc.expectedName || ok != tc.expectedOk {
+				t.Fatalf("expected %q (%t), given %q (%t)", tc.expectedName, tc.expectedOk, name, ok)
+			}
+		})
+	}
+}
@@ -26,21 +26,19 @@ func (svc *service) textDocumentCodeAction(ctx context.Context, params lsp.CodeA
 	var ca []lsp.CodeAction
 
 	// For action definitions, refer to https://code.visualstudio.com/api/references/vscode-api#CodeActionKind
-	// We only support format type code actions at the moment, and do not want to format without the client asking for
-	// them, so exit early here if nothing is requested.
-	if len(params.Context.Only) == 0 {
-		svc.logger.Printf("No code action requested, exiting")
-		return ca, nil
-	}
-
-	for _, o := range params.Context.Only {
-		svc.logger.Printf("Code actions requested: %q", o)
-	}
+	// We do not want to format without the client asking for it, so only
+	// actions other than source actions are offered if nothing is requested.
+	wantedCodeActions := ilsp.SupportedCodeActions.WithoutSource()
+	if len(params.Context.Only) > 0 {
+		for _, o := range params.Context.Only {
+			svc.logger.Printf("Code actions requested: %q", o)
+		}
 
-	wantedCodeActions := ilsp.SupportedCodeActions.Only(params.Context.Only)
-	if len(wantedCodeActions) == 0 {
-		return nil, fmt.Errorf("could not find a supported code action to execute for %s, wanted %v",
-			params.TextDocument.URI, params.Context.Only)
+		wantedCodeActions = ilsp.SupportedCodeActions.Only(params.Context.Only)
+		if len(wantedCodeActions) == 0 {
+			return nil, fmt.Errorf("could not find a supported code action to execute for %s, wanted %v",
+				params.TextDocument.URI, params.Context.Only)
+		}
 	}
 
 	svc.logger.Printf("Code actions supported: %v", wantedCodeActions)
@@ -52,17 +50,20 @@ func (svc *service) textDocumentCodeAction(ctx context.Context, params lsp.CodeA
 		return ca, err
 	}
 
-	for action := range wantedCodeActions {
+	// A failing action must not prevent other actions from being offered
+	for _, action := range wantedCodeActions.AsSlice() {
 		switch action {
 		case ilsp.SourceFormatAllTerraform:
 			tfExec, err := module.TerraformExecutorForModule(ctx, dh.Dir.Path())
 			if err != nil {
-				return ca, errors.EnrichTfExecError(err)
+				svc.logger.Printf("code action %q failed: %s", action, errors.EnrichTfExecError(err))
+				continue
 			}
 
 			edits, err := svc.formatDocument(ctx, tfExec, doc.Text, dh)
 			if err != nil {
-				return ca, err
+				svc.logger.Printf("code action %q failed: %s", action, err)
+				continue
 			}
 
 			ca = append(ca, lsp.CodeAction{
@@ -74,6 +75,35 @@ func (svc *service) textDocumentCodeAction(ctx context.Context, params lsp.CodeA
 					},
 				},
 			})
+		case ilsp.RefactorRewriteImportTerraform:
+			importActions, err := svc.importQueryResultsActions(dh, params.Range)
+			if err != nil {
+				svc.logger.Printf("code action %q failed: %s", action, err)
+				continue
+			}
+			ca = append(ca, importActions...)
+		case ilsp.RefactorExtractModuleTerraform:
+			extractActions, err := svc.extractModuleActions(ctx, dh, doc, params.Range)
+			if err != nil {
+				svc.logger.Printf("code action %q failed: %s", action, err)
+				continue
+			}
+			ca = append(ca, extractActions...)
+		case ilsp.RefactorExtractLocalTerraform, ilsp.RefactorExtractVariableTerraform,
+			ilsp.RefactorInlineLocalTerraform, ilsp.RefactorRewriteForEachTerraform:
+			refactorActions, err := svc.refactorActions(dh, doc, params.Range, action)
+			if err != nil {
+				svc.logger.Printf("code action %q failed: %s", action, err)
+				continue
+			}
+			ca = append(ca, refactorActions...)
+		case ilsp.RefactorRewriteModuleVersionTerraform:
+			versionActions, err := svc.moduleVersionActions(dh, doc, params.Range)
+			if err != nil {
+				svc.logger.Printf("code action %q failed: %s", action, err)
+				continue
+			}
+			ca = append(ca, versionActions...)
 		}
 	}
 
@@ -0,0 +1,169 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/refactor"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+// extractModuleActions offers to move resource and data blocks
+// within the range into a new local module
+func (svc *service) extractModuleActions(ctx context.Context, dh document.Handle, doc *document.Document, rng lsp.Range) ([]lsp.CodeAction, error) {
+	ca := make([]lsp.CodeAction, 0)
+	if doc.LanguageID != ilsp.Terraform.String() || svc.features == nil || svc.features.Modules == nil {
+		return ca, nil
+	}
+
+	// The new module's files can only be created
+	// by clients which support resource operations
+	cc, err := ilsp.ClientCapabilities(ctx)
+	if err != nil {
+		return ca, err
+	}
+	if cc.Workspace.WorkspaceEdit == nil || !cc.Workspace.WorkspaceEdit.DocumentChanges ||
+		!slices.Contains(cc.Workspace.WorkspaceEdit.ResourceOperations, lsp.Create) {
+		return ca, nil
+	}
+
+	modPath := dh.Dir.Path()
+	pathCtx, err := svc.features.Modules.PathContext(lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Terraform.String(),
+	})
+	if err != nil {
+		return ca, err
+	}
+
+	hclRng, err := hclRangeFromLSP(dh.Filename, rng, doc)
+	if err != nil {
+		return ca, err
+	}
+
+	takenNames := make(map[string]bool)
+	if entries, err := svc.fs.ReadDir(filepath.Join(modPath, "modules")); err == nil {
+		for _, entry := range entries {
+			takenNames[entry.Name()] = true
+		}
+	}
+
+	em, ok := refactor.ExtractModule(pathCtx, dh.Filename, hclRng, takenNames)
+	if !ok {
+		return ca, nil
+	}
+
+	extracted := em.Blocks[0]
+	if len(em.Blocks) > 1 {
+		extracted = fmt.Sprintf("%d blocks", len(em.Blocks))
+	}
+
+	ca = append(ca, lsp.CodeAction{
+		Title: fmt.Sprintf("Extract %s into module %q", extracted, em.Name),
+		Kind:  ilsp.RefactorExtractModuleTerraform,
+		Edit:  svc.workspaceEditWithNewFiles(modPath, em.Changes),
+	})
+
+	return ca, nil
+}
+
+// workspaceEditWithNewFiles turns changes into document changes,
+// which create any new files before editing existing ones
+func (svc *service) workspaceEditWithNewFiles(modPath string, changes refactor.Changes) lsp.WorkspaceEdit {
+	docChanges := make([]lsp.DocumentChanges, 0)
+
+	newFiles := make([]string, 0, len(changes.NewFiles))
+	for name := range changes.NewFiles {
+		newFiles = append(newFiles, name)
+	}
+	sort.Strings(newFiles)
+
+	for _, name := range newFiles {
+		fileURI := lsp.DocumentURI(uri.FromPath(filepath.Join(modPath, filepath.FromSlash(name))))
+		docChanges = append(docChanges, lsp.DocumentChanges{
+			CreateFile: &lsp.CreateFile{
+				Kind: "create",
+				URI:  fileURI,
+			},
+		}, lsp.DocumentChanges{
+			TextDocumentEdit: &lsp.TextDocumentEdit{
+				TextDocument: lsp.OptionalVersionedTextDocumentIdentifier{
+					Version:                lsp.NullVersion,
+					TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: fileURI},
+				},
+				Edits: []lsp.TextEdit{
+					{NewText: string(changes.NewFiles[name])},
+				},
+			},
+		})
+	}
+
+	for _, filename := range sortedEditFilenames(changes) {
+		dh := document.HandleFromPath(filepath.Join(modPath, filename))
+		// documents which aren't open are edited on disk
+		version := lsp.NullVersion
+		if doc, err := svc.stateStore.DocumentStore.GetDocument(dh); err == nil {
+			version = int32(doc.Version)
+		}
+		docChanges = append(docChanges, lsp.DocumentChanges{
+			TextDocumentEdit: &lsp.TextDocumentEdit{
+				TextDocument: lsp.OptionalVersionedTextDocumentIdentifier{
+					Version:                version,
+					TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: lsp.DocumentURI(dh.FullURI())},
+				},
+				Edits: textEditsFromRefactor(changes.Edits[filename]),
+			},
+		})
+	}
+
+	return lsp.WorkspaceEdit{
+		DocumentChanges: docChanges,
+	}
+}
+
+func sortedEditFilenames(changes refactor.Changes) []string {
+	filenames := make([]string, 0, len(changes.Edits))
+	for filename := range changes.Edits {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
+func textEditsFromRefactor(edits []refactor.Edit) []lsp.TextEdit {
+	textEdits := make([]lsp.TextEdit, 0, len(edits))
+	for _, edit := range edits {
+		textEdits = append(textEdits, lsp.TextEdit{
+			Range:   ilsp.HCLRangeToLSP(edit.Range),
+			NewText: edit.NewText,
+		})
+	}
+	return textEdits
+}
+
+func hclRangeFromLSP(filename string, rng lsp.Range, doc *document.Document) (hcl.Range, error) {
+	start, err := ilsp.HCLPositionFromLspPosition(rng.Start, doc)
+	if err != nil {
+		return hcl.Range{}, err
+	}
+	end, err := ilsp.HCLPositionFromLspPosition(rng.End, doc)
+	if err != nil {
+		return hcl.Range{}, err
+	}
+	return hcl.Range{
+		Filename: filename,
+		Start:    start,
+		End:      end,
+	}, nil
+}
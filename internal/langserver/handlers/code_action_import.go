@@ -0,0 +1,184 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	searchAst "github.com/hashicorp/terraform-ls/internal/features/search/ast"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	globalAst "github.com/hashicorp/terraform-ls/internal/terraform/ast"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+var resourceBlocksSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+	},
+}
+
+// importQueryResultsActions offers to write import and resource blocks
+// for resources found by the last query for list blocks within the range,
+// with one action per configuration file the blocks can be written into
+func (svc *service) importQueryResultsActions(dh document.Handle, rng lsp.Range) ([]lsp.CodeAction, error) {
+	ca := make([]lsp.CodeAction, 0)
+	if !searchAst.IsSearchFilename(dh.Filename) || svc.features == nil || svc.features.Search == nil {
+		return ca, nil
+	}
+
+	searchPath := dh.Dir.Path()
+	listBlocks, err := svc.features.Search.ListBlocks(searchPath, dh.Filename)
+	if err != nil {
+		return ca, err
+	}
+
+	addresses := make([]string, 0, len(listBlocks))
+	for address, blockRng := range listBlocks {
+		if rangesOverlap(ilsp.HCLRangeToLSP(blockRng), rng) {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return ca, nil
+	}
+	sort.Strings(addresses)
+
+	configFiles, err := svc.configFiles(searchPath)
+	if err != nil {
+		return ca, err
+	}
+
+	for _, address := range addresses {
+		list, ok := svc.features.Search.QueryResults(searchPath, address)
+		if !ok || len(list.Results) == 0 {
+			continue
+		}
+
+		takenNames := make(map[string]bool)
+		for _, f := range configFiles {
+			for _, name := range resourceNames(f.src, f.name, list.ResourceType) {
+				takenNames[name] = true
+			}
+		}
+
+		config, err := svc.features.Search.ImportConfig(searchPath, address, takenNames)
+		if err != nil {
+			return ca, err
+		}
+
+		found := fmt.Sprintf("%d resources", len(list.Results))
+		if len(list.Results) == 1 {
+			found = "1 resource"
+		}
+
+		for _, f := range configFiles {
+			ca = append(ca, lsp.CodeAction{
+				Title: fmt.Sprintf("Import %s found by %s into %s", found, address, f.name),
+				Kind:  ilsp.RefactorRewriteImportTerraform,
+				Edit: lsp.WorkspaceEdit{
+					Changes: map[lsp.DocumentURI][]lsp.TextEdit{
+						lsp.DocumentURI(uri.FromPath(filepath.Join(searchPath, f.name))): {
+							appendEdit(f.src, config),
+						},
+					},
+				},
+			})
+		}
+	}
+
+	return ca, nil
+}
+
+type configFile struct {
+	name string
+	src  []byte
+}
+
+// configFiles returns native syntax configuration files of the module
+// alongside the query files, i.e. files the generated blocks can go into
+func (svc *service) configFiles(modPath string) ([]configFile, error) {
+	entries, err := svc.fs.ReadDir(modPath)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]configFile, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".tf") || globalAst.IsIgnoredFile(name) {
+			continue
+		}
+		src, err := svc.fs.ReadFile(filepath.Join(modPath, name))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, configFile{name: name, src: src})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+
+	return files, nil
+}
+
+// resourceNames returns names of resources of the given type declared in src
+func resourceNames(src []byte, filename, resourceType string) []string {
+	names := make([]string, 0)
+	f, _ := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if f == nil {
+		return names
+	}
+	content, _, _ := f.Body.PartialContent(resourceBlocksSchema)
+	for _, block := range content.Blocks {
+		if block.Labels[0] == resourceType {
+			names = append(names, block.Labels[1])
+		}
+	}
+	return names
+}
+
+// appendEdit returns an edit appending text to the end of src,
+// separated from any existing content by an empty line
+func appendEdit(src, text []byte) lsp.TextEdit {
+	line := bytes.Count(src, []byte("\n"))
+	lastLine := src[bytes.LastIndexByte(src, '\n')+1:]
+	pos := lsp.Position{
+		Line:      uint32(line),
+		Character: uint32(len(utf16.Encode([]rune(string(lastLine))))),
+	}
+
+	prefix := ""
+	switch {
+	case len(src) == 0:
+	case len(lastLine) > 0:
+		prefix = "\n\n"
+	default:
+		prefix = "\n"
+	}
+
+	return lsp.TextEdit{
+		Range: lsp.Range{
+			Start: pos,
+			End:   pos,
+		},
+		NewText: prefix + string(text),
+	}
+}
+
+func rangesOverlap(a, b lsp.Range) bool {
+	return !positionBefore(a.End, b.Start) && !positionBefore(b.End, a.Start)
+}
+
+func positionBefore(a, b lsp.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/refactor"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/registry"
+)
+
+// moduleVersionActions offers to bump the version of the registry
+// module call within the range to the newest version of the same
+// major version, and to the newest major version.
+//
+// Only versions cached from the registry are considered. Inputs and
+// outputs of the module which would no longer be available after
+// the upgrade, but are in use, are pointed out in the title.
+func (svc *service) moduleVersionActions(dh document.Handle, doc *document.Document, rng lsp.Range) ([]lsp.CodeAction, error) {
+	ca := make([]lsp.CodeAction, 0)
+	if doc.LanguageID != ilsp.Terraform.String() || svc.features == nil || svc.features.Modules == nil {
+		return ca, nil
+	}
+
+	modPath := dh.Dir.Path()
+	pathCtx, err := svc.features.Modules.PathContext(lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Terraform.String(),
+	})
+	if err != nil {
+		return ca, err
+	}
+
+	hclRng, err := hclRangeFromLSP(dh.Filename, rng, doc)
+	if err != nil {
+		return ca, err
+	}
+
+	mc, ok := refactor.RegistryModuleCallAt(pathCtx, dh.Filename, hclRng)
+	if !ok {
+		return ca, nil
+	}
+
+	versions, err := svc.features.Modules.RegistryModuleVersions(mc.Source)
+	if err != nil {
+		// versions are not cached (yet)
+		return ca, nil
+	}
+	upgrades, ok := registry.FindModuleUpgrades(versions, mc.Version)
+	if !ok {
+		return ca, nil
+	}
+
+	for _, v := range []*version.Version{upgrades.Compatible, upgrades.Major} {
+		if v == nil {
+			continue
+		}
+
+		title := fmt.Sprintf("Upgrade module %q to %s", mc.Name, v)
+		warnings := svc.moduleUpgradeWarnings(mc, v)
+		if len(warnings) > 0 {
+			title = fmt.Sprintf("%s (%s)", title, strings.Join(warnings, "; "))
+		}
+
+		ca = append(ca, lsp.CodeAction{
+			Title:       title,
+			Kind:        ilsp.RefactorRewriteModuleVersionTerraform,
+			IsPreferred: v == upgrades.Compatible && len(warnings) == 0,
+			Edit:        workspaceEditFromDiff(modPath, pathCtx, refactor.BumpModuleVersion(mc, v)),
+		})
+	}
+
+	return ca, nil
+}
+
+// moduleUpgradeWarnings compares inputs and outputs of the currently
+// used version of the module with the given version
+func (svc *service) moduleUpgradeWarnings(mc refactor.RegistryModuleCall, v *version.Version) []string {
+	warnings := make([]string, 0)
+
+	oldData, err := svc.features.Modules.RegistryModuleMeta(mc.Source, mc.Version)
+	if err != nil {
+		return warnings
+	}
+	newData, err := svc.features.Modules.RegistryModuleMeta(mc.Source, version.MustConstraints(version.NewConstraint(v.String())))
+	if err != nil {
+		return warnings
+	}
+
+	inputs, outputs := refactor.RemovedModuleInterface(mc, oldData, newData)
+	if len(inputs) > 0 {
+		warnings = append(warnings, fmt.Sprintf("removes inputs in use: %s", strings.Join(inputs, ", ")))
+	}
+	if len(outputs) > 0 {
+		warnings = append(warnings, fmt.Sprintf("removes outputs in use: %s", strings.Join(outputs, ", ")))
+	}
+	return warnings
+}
@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/refactor"
+	"github.com/hashicorp/terraform-ls/internal/hcl"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+)
+
+// refactorActions offers the given refactoring for the expression,
+// local value or block within the range
+func (svc *service) refactorActions(dh document.Handle, doc *document.Document, rng lsp.Range, action lsp.CodeActionKind) ([]lsp.CodeAction, error) {
+	ca := make([]lsp.CodeAction, 0)
+	if doc.LanguageID != ilsp.Terraform.String() || svc.features == nil || svc.features.Modules == nil {
+		return ca, nil
+	}
+	// Extracting is only offered for explicit selections,
+	// not for any expression the cursor happens to be in
+	isExtraction := action == ilsp.RefactorExtractLocalTerraform || action == ilsp.RefactorExtractVariableTerraform
+	if isExtraction && rng.Start == rng.End {
+		return ca, nil
+	}
+
+	modPath := dh.Dir.Path()
+	pathCtx, err := svc.features.Modules.PathContext(lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Terraform.String(),
+	})
+	if err != nil {
+		return ca, err
+	}
+
+	hclRng, err := hclRangeFromLSP(dh.Filename, rng, doc)
+	if err != nil {
+		return ca, err
+	}
+
+	var changes refactor.Changes
+	var name, title string
+	var ok bool
+	switch action {
+	case ilsp.RefactorExtractLocalTerraform:
+		changes, name, ok = refactor.ExtractLocal(pathCtx, dh.Filename, hclRng)
+		title = fmt.Sprintf("Extract expression into local value %q", name)
+	case ilsp.RefactorInlineLocalTerraform:
+		changes, name, ok = refactor.InlineLocal(pathCtx, dh.Filename, hclRng)
+		title = fmt.Sprintf("Inline local value %q", name)
+	case ilsp.RefactorExtractVariableTerraform:
+		changes, name, ok = refactor.ExtractVariable(pathCtx, dh.Filename, hclRng)
+		title = fmt.Sprintf("Extract literal into variable %q", name)
+	case ilsp.RefactorRewriteForEachTerraform:
+		var cc refactor.CountConversion
+		cc, ok = refactor.ConvertCountToForEach(pathCtx, dh.Filename, hclRng)
+		changes = cc.Changes
+		title = fmt.Sprintf("Convert count of %s to for_each", cc.Address)
+		if len(cc.Reasons) > 0 {
+			title += " (incomplete, see TODO)"
+		}
+	}
+	if !ok {
+		return ca, nil
+	}
+
+	ca = append(ca, lsp.CodeAction{
+		Title: title,
+		Kind:  action,
+		Edit:  workspaceEditFromDiff(modPath, pathCtx, changes),
+	})
+
+	return ca, nil
+}
+
+// workspaceEditFromDiff applies changes to the parsed files and
+// turns the difference into edits of each file
+func workspaceEditFromDiff(modPath string, pathCtx *decoder.PathContext, changes refactor.Changes) lsp.WorkspaceEdit {
+	edit := lsp.WorkspaceEdit{
+		Changes: make(map[lsp.DocumentURI][]lsp.TextEdit),
+	}
+
+	for _, filename := range sortedEditFilenames(changes) {
+		f, ok := pathCtx.Files[filename]
+		if !ok {
+			continue
+		}
+		dh := document.HandleFromPath(filepath.Join(modPath, filename))
+		after := refactor.ApplyEdits(f.Bytes, changes.Edits[filename])
+		edit.Changes[lsp.DocumentURI(dh.FullURI())] = ilsp.TextEditsFromDocumentChanges(hcl.Diff(dh, f.Bytes, after))
+	}
+
+	return edit
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfregistry "github.com/hashicorp/terraform-schema/registry"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -362,3 +364,486 @@ func TestLangServer_codeAction_no_code_action_requested(t *testing.T) {
 		})
 	}
 }
+
+func TestLangServer_codeAction_extractModule(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {
+			"workspace": {
+				"workspaceEdit": {
+					"documentChanges": true,
+					"resourceOperations": ["create"]
+				}
+			}
+		},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 2,
+			"languageId": "terraform",
+			"text": "resource \"random_pet\" \"name\" {\n}\n\noutput \"name\" {\n  value = random_pet.name.id\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 0, "character": 0 },
+				"end": { "line": 0, "character": 0 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.extract"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Extract random_pet.name into module \"name\"",
+					"kind": "refactor.extract.module.terraform",
+					"edit": {
+						"documentChanges": [
+							{
+								"kind": "create",
+								"uri": "%[1]s/modules/name/main.tf"
+							},
+							{
+								"textDocument": {
+									"version": null,
+									"uri": "%[1]s/modules/name/main.tf"
+								},
+								"edits": [
+									{
+										"range": {
+											"start": { "line": 0, "character": 0 },
+											"end": { "line": 0, "character": 0 }
+										},
+										"newText": "resource \"random_pet\" \"name\" {\n}\n"
+									}
+								]
+							},
+							{
+								"kind": "create",
+								"uri": "%[1]s/modules/name/outputs.tf"
+							},
+							{
+								"textDocument": {
+									"version": null,
+									"uri": "%[1]s/modules/name/outputs.tf"
+								},
+								"edits": [
+									{
+										"range": {
+											"start": { "line": 0, "character": 0 },
+											"end": { "line": 0, "character": 0 }
+										},
+										"newText": "output \"random_pet_name_id\" {\n  value = random_pet.name.id\n}\n"
+									}
+								]
+							},
+							{
+								"kind": "create",
+								"uri": "%[1]s/modules/name/variables.tf"
+							},
+							{
+								"textDocument": {
+									"version": null,
+									"uri": "%[1]s/modules/name/variables.tf"
+								},
+								"edits": [
+									{
+										"range": {
+											"start": { "line": 0, "character": 0 },
+											"end": { "line": 0, "character": 0 }
+										},
+										"newText": ""
+									}
+								]
+							},
+							{
+								"textDocument": {
+									"version": 2,
+									"uri": "%[1]s/main.tf"
+								},
+								"edits": [
+									{
+										"range": {
+											"start": { "line": 4, "character": 10 },
+											"end": { "line": 4, "character": 28 }
+										},
+										"newText": "module.name.random_pet_name_id"
+									},
+									{
+										"range": {
+											"start": { "line": 0, "character": 0 },
+											"end": { "line": 1, "character": 1 }
+										},
+										"newText": "module \"name\" {\n  source = \"./modules/name\"\n}\n\nmoved {\n  from = random_pet.name\n  to   = module.name.random_pet.name\n}"
+									}
+								]
+							}
+						]
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+func TestLangServer_codeAction_inlineLocal(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "locals {\n  name = \"app\"\n  env  = \"dev\"\n}\n\noutput \"name\" {\n  value = local.name\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 6, "character": 12 },
+				"end": { "line": 6, "character": 12 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.inline"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Inline local value \"name\"",
+					"kind": "refactor.inline.local.terraform",
+					"edit": {
+						"changes": {
+							"%s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 1, "character": 0 },
+										"end": { "line": 2, "character": 0 }
+									},
+									"newText": ""
+								},
+								{
+									"range": {
+										"start": { "line": 6, "character": 0 },
+										"end": { "line": 7, "character": 0 }
+									},
+									"newText": "  value = \"app\"\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+func TestLangServer_codeAction_failedActionKeepsOthers(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): append(validTfMockCalls(), &mock.Call{
+					Method:        "Format",
+					Repeatability: 1,
+					Arguments: []interface{}{
+						mock.AnythingOfType(""),
+						[]byte("locals {\n  name   = \"app\"\n}\n"),
+					},
+					ReturnArguments: []interface{}{
+						[]byte("locals {\n  name = \"app\"\n}\n"),
+						nil,
+					},
+				}),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "locals {\n  name   = \"app\"\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	// The range is outside of the document, so inlining fails,
+	// which must not prevent formatting from being offered
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 10, "character": 0 },
+				"end": { "line": 10, "character": 0 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.inline", "source.formatAll.terraform"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Format Document",
+					"kind": "source.formatAll.terraform",
+					"edit": {
+						"changes": {
+							"%s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 1, "character": 0 },
+										"end": { "line": 2, "character": 0 }
+									},
+									"newText": "  name = \"app\"\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+func TestLangServer_codeAction_moduleVersion(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheLabelModuleVersions(t, ss)
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, labelModuleConfig, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": { "uri": "%s/main.tf" },
+			"range": {
+				"start": { "line": 2, "character": 4 },
+				"end": { "line": 2, "character": 4 }
+			},
+			"context": { "diagnostics": [], "only": ["refactor.rewrite.moduleVersion"] }
+		}`, tmpDir.URI)}, fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": [
+				{
+					"title": "Upgrade module \"label\" to 1.1.0",
+					"kind": "refactor.rewrite.moduleVersion.terraform",
+					"isPreferred": true,
+					"edit": {
+						"changes": {
+							"%[1]s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 2, "character": 0 },
+										"end": { "line": 3, "character": 0 }
+									},
+									"newText": "  version = \"~\u003e 1.1.0\"\n"
+								}
+							]
+						}
+					}
+				},
+				{
+					"title": "Upgrade module \"label\" to 2.0.0 (removes inputs in use: enabled; removes outputs in use: id_full)",
+					"kind": "refactor.rewrite.moduleVersion.terraform",
+					"edit": {
+						"changes": {
+							"%[1]s/main.tf": [
+								{
+									"range": {
+										"start": { "line": 2, "character": 0 },
+										"end": { "line": 3, "character": 0 }
+									},
+									"newText": "  version = \"~\u003e 2.0.0\"\n"
+								}
+							]
+						}
+					}
+				}
+			]
+		}`, tmpDir.URI))
+}
+
+const labelModuleConfig = `module "label" {
+  source  = "cloudposse/label/null"
+  version = "~> 1.0.0"
+
+  enabled = true
+}
+
+output "id" {
+  value = module.label.id_full
+}
+`
+
+// cacheLabelModuleVersions caches versions of a registry module,
+// where the newest major version removes an input and an output
+func cacheLabelModuleVersions(t *testing.T, ss *state.StateStore) {
+	t.Helper()
+
+	addr, err := tfaddr.ParseModuleSource("cloudposse/label/null")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions := version.Collection{}
+	for _, raw := range []string{"2.0.0", "1.1.0", "1.0.2"} {
+		versions = append(versions, version.Must(version.NewVersion(raw)))
+	}
+	_, err = ss.RegistryModules.CacheVersions(addr, versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ss.RegistryModules.Cache(addr, versions[2],
+		[]tfregistry.Input{{Name: "enabled"}},
+		[]tfregistry.Output{{Name: "id_full"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.RegistryModules.Cache(addr, versions[1],
+		[]tfregistry.Input{{Name: "enabled"}},
+		[]tfregistry.Output{{Name: "id_full"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.RegistryModules.Cache(addr, versions[0],
+		[]tfregistry.Input{{Name: "context"}},
+		[]tfregistry.Output{{Name: "id"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
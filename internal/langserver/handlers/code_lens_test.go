@@ -6,6 +6,7 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -394,3 +395,288 @@ variable "instances" {
 			]
 	}`)
 }
+
+func TestCodeLens_testRun(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform-test",
+			"text": "run \"first\" {\n  assert {\n    condition = true\n    error_message = \"fail\"\n  }\n}\n",
+			"uri": "%s/main.tftest.hcl"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	fileUri := fmt.Sprintf("%s/main.tftest.hcl", tmpDir.URI)
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": %q
+			}
+		}`, fileUri),
+	}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 0}
+				},
+				"command": {
+					"title": "Run file",
+					"command": "terraform-ls.test.run",
+					"arguments": ["uri=%s"]
+				}
+			},
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 11}
+				},
+				"command": {
+					"title": "Run file (show first result)",
+					"command": "terraform-ls.test.run",
+					"arguments": ["uri=%s", "run=first"]
+				}
+			}
+		]
+	}`, fileUri, fileUri))
+}
+
+func TestCodeLens_stateInstances(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "terraform.tfstate"), []byte(testStateFile), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"random_pet\" \"web\" {\n  count = 2\n}\n\nresource \"random_pet\" \"db\" {\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 27}
+				},
+				"command": {
+					"title": "2 instances in state",
+					"command": ""
+				}
+			},
+			{
+				"range": {
+					"start": {"line": 4, "character": 0},
+					"end": {"line": 4, "character": 26}
+				},
+				"command": {
+					"title": "0 instances in state",
+					"command": ""
+				}
+			}
+		]
+	}`)
+}
+
+const testStateFile = `{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "serial": 1,
+  "lineage": "3b2c6bd2-3f5c-2cb4-5a5c-cd0bbcf86f0d",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "random_pet",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/hashicorp/random\"]",
+      "instances": [
+        {
+          "index_key": 0,
+          "schema_version": 0,
+          "attributes": {"id": "fond-fox", "length": 2, "keepers": {"secret": "s3cr3t"}},
+          "sensitive_attributes": [
+            [{"type": "get_attr", "value": "keepers"}, {"type": "index", "value": {"value": "secret", "type": "string"}}]
+          ]
+        },
+        {
+          "index_key": 1,
+          "schema_version": 0,
+          "attributes": {"id": "brave-owl", "length": 2, "keepers": null},
+          "sensitive_attributes": []
+        }
+      ]
+    }
+  ]
+}`
+
+func TestCodeLens_moduleUpgrades(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheLabelModuleVersions(t, ss)
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": %q,
+			"uri": "%s/main.tf"
+		}
+	}`, labelModuleConfig, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": [
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 14}
+				},
+				"command": {
+					"title": "newer version available: 1.1.0",
+					"command": ""
+				}
+			},
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 14}
+				},
+				"command": {
+					"title": "newer major version available: 2.0.0",
+					"command": ""
+				}
+			}
+		]
+	}`)
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/eval"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const evaluateVersion = 0
+
+type evaluateResponse struct {
+	FormatVersion int    `json:"v"`
+	Value         string `json:"value,omitempty"`
+	Type          string `json:"type"`
+	Known         bool   `json:"known"`
+}
+
+// EvaluateHandler statically evaluates the given expression within
+// the given module, resolving input variables and local values.
+//
+// Values depending on anything only known at runtime, such as
+// attributes of resources, are reported as not known.
+func (h *CmdHandler) EvaluateHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := evaluateResponse{
+		FormatVersion: evaluateVersion,
+	}
+
+	modUri, ok := args.GetString("uri")
+	if !ok || modUri == "" {
+		return response, fmt.Errorf("%w: expected module uri argument to be set", jrpc2.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(modUri) {
+		return response, fmt.Errorf("URI %q is not valid", modUri)
+	}
+
+	expression, ok := args.GetString("expression")
+	if !ok || expression == "" {
+		return response, fmt.Errorf("%w: expected expression argument to be set", jrpc2.InvalidParams.Err())
+	}
+
+	modPath, err := uri.PathFromURI(modUri)
+	if err != nil {
+		return response, err
+	}
+
+	jobIds, err := h.StateStore.JobStore.ListIncompleteJobsForDir(document.DirHandleFromPath(modPath))
+	if err != nil {
+		return response, err
+	}
+	h.StateStore.JobStore.WaitForJobs(ctx, jobIds...)
+
+	evaluator, err := h.ModulesFeature.Evaluator(modPath, h.variableFiles(modPath))
+	if err != nil {
+		return response, err
+	}
+
+	val, diags := evaluator.EvaluateString(expression)
+	if diags.HasErrors() {
+		return response, fmt.Errorf("%w: %s", jrpc2.InvalidParams.Err(), firstErrorDiag(diags))
+	}
+
+	response.Type = typeexpr.TypeString(val.Type())
+	response.Value, response.Known = eval.FormatValue(val)
+
+	return response, nil
+}
+
+// variableFiles returns parsed variable files of the module, if any
+func (h *CmdHandler) variableFiles(modPath string) map[string]*hcl.File {
+	if h.VariablesFeature == nil {
+		return nil
+	}
+	pathCtx, err := h.VariablesFeature.PathContext(lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Tfvars.String(),
+	})
+	if err != nil {
+		return nil
+	}
+	return pathCtx.Files
+}
+
+func firstErrorDiag(diags hcl.Diagnostics) string {
+	for _, diag := range diags {
+		if diag.Severity == hcl.DiagError {
+			if diag.Detail != "" {
+				return fmt.Sprintf("%s: %s", diag.Summary, diag.Detail)
+			}
+			return diag.Summary
+		}
+	}
+	return diags.Error()
+}
@@ -7,7 +7,12 @@ import (
 	"log"
 
 	fmodules "github.com/hashicorp/terraform-ls/internal/features/modules"
+	fpolicy "github.com/hashicorp/terraform-ls/internal/features/policy"
+	fpolicytest "github.com/hashicorp/terraform-ls/internal/features/policytest"
 	frootmodules "github.com/hashicorp/terraform-ls/internal/features/rootmodules"
+	fsearch "github.com/hashicorp/terraform-ls/internal/features/search"
+	ftests "github.com/hashicorp/terraform-ls/internal/features/tests"
+	fvariables "github.com/hashicorp/terraform-ls/internal/features/variables"
 	"github.com/hashicorp/terraform-ls/internal/state"
 )
 
@@ -18,4 +23,9 @@ type CmdHandler struct {
 	// the features here?
 	ModulesFeature     *fmodules.ModulesFeature
 	RootModulesFeature *frootmodules.RootModulesFeature
+	TestsFeature       *ftests.TestsFeature
+	PolicyFeature      *fpolicy.PolicyFeature
+	PolicyTestFeature  *fpolicytest.PolicyTestFeature
+	SearchFeature      *fsearch.SearchFeature
+	VariablesFeature   *fvariables.VariablesFeature
 }
@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	lsErrors "github.com/hashicorp/terraform-ls/internal/langserver/errors"
+	"github.com/hashicorp/terraform-ls/internal/langserver/progress"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/module"
+	"github.com/hashicorp/terraform-ls/internal/terraform/plan"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const planAnnotateVersion = 0
+
+type planAnnotateResponse struct {
+	FormatVersion int          `json:"v"`
+	Changes       []planChange `json:"changes"`
+}
+
+type planChange struct {
+	Address string   `json:"address"`
+	Actions []string `json:"actions"`
+	Summary string   `json:"summary"`
+}
+
+// PlanAnnotateHandler annotates the configuration of the given root
+// module with changes of a saved plan.
+//
+// The plan is either a JSON file as produced by terraform show -json,
+// or a plan file produced by terraform plan -out, which is then
+// converted via terraform show -json.
+func (h *CmdHandler) PlanAnnotateHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := planAnnotateResponse{
+		FormatVersion: planAnnotateVersion,
+		Changes:       make([]planChange, 0),
+	}
+
+	modPath, err := modulePathFromArgs(args)
+	if err != nil {
+		return response, err
+	}
+
+	planArg, ok := args.GetString("plan")
+	if !ok || planArg == "" {
+		return response, fmt.Errorf("%w: expected plan argument to be set", jrpc2.InvalidParams.Err())
+	}
+	planPath := planArg
+	if uri.IsURIValid(planArg) {
+		planPath, err = uri.PathFromURI(planArg)
+		if err != nil {
+			return response, err
+		}
+	}
+	if !filepath.IsAbs(planPath) {
+		planPath = filepath.Join(modPath, planPath)
+	}
+
+	if h.RootModulesFeature == nil || h.ModulesFeature == nil {
+		return response, fmt.Errorf("plan annotations are not available")
+	}
+
+	p, err := h.readPlan(ctx, modPath, planPath)
+	if err != nil {
+		return response, err
+	}
+
+	jobIds, err := h.StateStore.JobStore.ListIncompleteJobsForDir(document.DirHandleFromPath(modPath))
+	if err != nil {
+		return response, err
+	}
+	h.StateStore.JobStore.WaitForJobs(ctx, jobIds...)
+
+	pathCtx, err := h.ModulesFeature.PathContext(lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Terraform.String(),
+	})
+	if err != nil {
+		return response, err
+	}
+
+	err = h.RootModulesFeature.UpdatePlan(modPath, p)
+	if err != nil {
+		return response, err
+	}
+	err = h.ModulesFeature.UpdatePlanAnnotations(modPath, p.Diagnostics(pathCtx.Files))
+	if err != nil {
+		return response, err
+	}
+
+	for _, address := range p.Addresses() {
+		for _, change := range p.Changes[address] {
+			actions := make([]string, 0, len(change.Actions))
+			for _, action := range change.Actions {
+				actions = append(actions, string(action))
+			}
+			response.Changes = append(response.Changes, planChange{
+				Address: change.String(),
+				Actions: actions,
+				Summary: change.Summary(),
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// PlanClearHandler removes annotations of a saved plan
+// from the configuration of the given root module
+func (h *CmdHandler) PlanClearHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	modPath, err := modulePathFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.RootModulesFeature == nil || h.ModulesFeature == nil {
+		return nil, fmt.Errorf("plan annotations are not available")
+	}
+
+	err = h.RootModulesFeature.UpdatePlan(modPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = h.ModulesFeature.UpdatePlanAnnotations(modPath, map[string]hcl.Diagnostics{})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (h *CmdHandler) readPlan(ctx context.Context, modPath, planPath string) (*plan.Plan, error) {
+	if strings.EqualFold(filepath.Ext(planPath), ".json") {
+		src, err := os.ReadFile(planPath)
+		if err != nil {
+			return nil, err
+		}
+		return plan.ParsePlan(src)
+	}
+
+	tfExec, err := module.TerraformExecutorForModule(ctx, modPath)
+	if err != nil {
+		return nil, lsErrors.EnrichTfExecError(err)
+	}
+
+	progress.Begin(ctx, "Reading plan")
+	defer func() {
+		progress.End(ctx, "Finished")
+	}()
+
+	progress.Report(ctx, "Running terraform show ...")
+	jsonPlan, err := tfExec.ShowPlanFile(ctx, planPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.FromJSON(jsonPlan)
+}
+
+func modulePathFromArgs(args cmd.CommandArgs) (string, error) {
+	modUri, ok := args.GetString("uri")
+	if !ok || modUri == "" {
+		return "", fmt.Errorf("%w: expected module uri argument to be set", jrpc2.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(modUri) {
+		return "", fmt.Errorf("URI %q is not valid", modUri)
+	}
+
+	return uri.PathFromURI(modUri)
+}
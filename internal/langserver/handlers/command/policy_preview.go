@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/features/policy/ast"
+	"github.com/hashicorp/terraform-ls/internal/features/policy/preview"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const policyPreviewVersion = 0
+
+type policyPreviewResponse struct {
+	FormatVersion int                   `json:"v"`
+	Results       []policyPreviewResult `json:"results"`
+}
+
+type policyPreviewResult struct {
+	Policy    string `json:"policy"`
+	Resource  string `json:"resource"`
+	ModuleURI string `json:"module_uri"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// PolicyPreviewHandler evaluates resource policies of the given policy
+// directory against resources of all modules in the workspace and
+// reports violations as diagnostics of the offending resources.
+func (h *CmdHandler) PolicyPreviewHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := policyPreviewResponse{
+		FormatVersion: policyPreviewVersion,
+		Results:       make([]policyPreviewResult, 0),
+	}
+
+	policyUri, ok := args.GetString("uri")
+	if !ok || policyUri == "" {
+		return response, fmt.Errorf("%w: expected policy uri argument to be set", jrpc2.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(policyUri) {
+		return response, fmt.Errorf("URI %q is not valid", policyUri)
+	}
+
+	policyPath, err := uri.PathFromURI(policyUri)
+	if err != nil {
+		return response, err
+	}
+	if ast.IsPolicyFilename(policyPath) {
+		policyPath = filepath.Dir(policyPath)
+	}
+
+	if h.PolicyFeature == nil || h.ModulesFeature == nil {
+		return response, fmt.Errorf("policy preview is not available")
+	}
+
+	parsedModules, err := h.ModulesFeature.ParsedModuleFiles(ctx)
+	if err != nil {
+		return response, err
+	}
+	modules := make([]preview.Module, 0, len(parsedModules))
+	for modPath, files := range parsedModules {
+		modules = append(modules, preview.Module{
+			Path:  modPath,
+			Files: files,
+		})
+	}
+
+	results, err := h.PolicyFeature.Preview(policyPath, modules)
+	if err != nil {
+		return response, err
+	}
+
+	diags := make(map[string]map[string]hcl.Diagnostics, len(modules))
+	for modPath := range parsedModules {
+		diags[modPath] = make(map[string]hcl.Diagnostics)
+	}
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			filename := diag.Subject.Filename
+			diags[result.ModulePath][filename] = append(diags[result.ModulePath][filename], diag)
+		}
+
+		response.Results = append(response.Results, policyPreviewResult{
+			Policy:    result.Policy,
+			Resource:  result.Resource,
+			ModuleURI: uri.FromPath(result.ModulePath),
+			Status:    string(result.Status),
+			Message:   result.Message,
+		})
+	}
+
+	for modPath, modDiags := range diags {
+		err = h.ModulesFeature.UpdatePolicyPreviewResults(modPath, modDiags)
+		if err != nil {
+			h.Logger.Printf("failed to update policy preview results for %q: %s", modPath, err)
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,164 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/policytest/ast"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/langserver/progress"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/terraform/module"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const policyTestRunVersion = 0
+
+// PolicyTestRunHandler runs test cases of the given policy test file
+// and reports failed test cases as diagnostics.
+//
+// Test cases are run via terraform policy test if Terraform is available
+// and supports it. Otherwise they are evaluated in-process, using only
+// values which are known statically.
+func (h *CmdHandler) PolicyTestRunHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := testRunResponse{
+		FormatVersion: policyTestRunVersion,
+		Runs:          make([]testRunResult, 0),
+	}
+
+	fileUri, ok := args.GetString("uri")
+	if !ok || fileUri == "" {
+		return response, fmt.Errorf("%w: expected policy test file uri argument to be set", jrpc2.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(fileUri) {
+		return response, fmt.Errorf("URI %q is not valid", fileUri)
+	}
+
+	fh := document.HandleFromURI(fileUri)
+	if !ast.IsPolicyTestFilename(fh.Filename) {
+		return response, fmt.Errorf("%w: %q is not a policy test file", jrpc2.InvalidParams.Err(), fh.Filename)
+	}
+	caseName, _ := args.GetString("case")
+
+	if h.PolicyTestFeature == nil {
+		return response, fmt.Errorf("policy tests are not available")
+	}
+
+	testPath := fh.Dir.Path()
+	workDir := h.PolicyTestFeature.PolicyPath(testPath)
+	filter, err := filepath.Rel(workDir, filepath.Join(testPath, fh.Filename))
+	if err != nil {
+		return response, err
+	}
+	filter = filepath.ToSlash(filter)
+
+	progress.Begin(ctx, "Running policy tests")
+	defer func() {
+		progress.End(ctx, "Finished")
+	}()
+
+	output, err := h.runPolicyTests(ctx, workDir, filter)
+	if err != nil {
+		return response, err
+	}
+
+	var diags hcl.Diagnostics
+	if output.status != "" {
+		response.Status = output.status
+		response.Runs = output.runs
+		diags = output.diagnostics(filter)
+	} else {
+		progress.Report(ctx, "Evaluating test cases ...")
+		results, err := h.PolicyTestFeature.RunTestCases(testPath, fh.Filename)
+		if err != nil {
+			return response, err
+		}
+
+		response.Status = "pass"
+		diags = make(hcl.Diagnostics, 0)
+		for _, result := range results {
+			progress.Report(ctx, fmt.Sprintf("%q: %s", result.Name, result.Status))
+			response.Runs = append(response.Runs, testRunResult{
+				Name:   result.Name,
+				Status: result.Status,
+			})
+
+			switch result.Status {
+			case "fail":
+				if response.Status == "pass" {
+					response.Status = "fail"
+				}
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Test case failed",
+					Detail:   result.Message,
+					Subject:  result.Range.Ptr(),
+				})
+			case "error":
+				response.Status = "error"
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "Test case not evaluable",
+					Detail:   result.Message,
+					Subject:  result.Range.Ptr(),
+				})
+			}
+		}
+	}
+
+	err = h.PolicyTestFeature.UpdateTestResults(testPath, fh.Filename, diags)
+	if err != nil {
+		h.Logger.Printf("failed to update policy test results for %q: %s", testPath, err)
+	}
+
+	if caseName != "" {
+		runs := make([]testRunResult, 0)
+		for _, run := range response.Runs {
+			if run.Name == caseName {
+				runs = append(runs, run)
+			}
+		}
+		response.Runs = runs
+	}
+
+	return response, nil
+}
+
+// runPolicyTests runs terraform policy test for the given file.
+// The status of the returned output is empty if Terraform
+// is not available or does not support policy tests.
+func (h *CmdHandler) runPolicyTests(ctx context.Context, workDir, filter string) (*testOutput, error) {
+	output := newTestOutput(ctx, filter)
+
+	tfExec, err := module.TerraformExecutorForModule(ctx, workDir)
+	if err != nil {
+		h.Logger.Printf("running policy tests in-process: %s", err)
+		return output, nil
+	}
+
+	progress.Report(ctx, fmt.Sprintf("Running terraform policy test -filter=%s ...", filter))
+
+	err = tfExec.PolicyTest(ctx, output, filter)
+	output.Flush()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.CtxErr != nil {
+			return output, err
+		}
+		if output.status == "" {
+			h.Logger.Printf("running policy tests in-process: %s", err)
+		}
+		// failing tests also cause non-zero exit code
+	}
+
+	return output, nil
+}
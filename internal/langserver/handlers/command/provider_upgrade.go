@@ -0,0 +1,210 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/upgrade"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
+	"github.com/hashicorp/terraform-ls/internal/schemas"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+const providerUpgradeReportVersion = 0
+
+type providerUpgradeReportResponse struct {
+	FormatVersion int                     `json:"v"`
+	Provider      string                  `json:"provider"`
+	Version       string                  `json:"version"`
+	Modules       []providerUpgradeModule `json:"modules"`
+}
+
+type providerUpgradeModule struct {
+	URI            string                  `json:"uri"`
+	CurrentVersion string                  `json:"current_version"`
+	Impacts        []providerUpgradeImpact `json:"impacts"`
+}
+
+type providerUpgradeImpact struct {
+	Kind        string                  `json:"kind"`
+	Address     string                  `json:"address"`
+	Path        string                  `json:"path,omitempty"`
+	Replacement string                  `json:"replacement,omitempty"`
+	Summary     string                  `json:"summary"`
+	Detail      string                  `json:"detail"`
+	Range       providerUpgradeLocation `json:"range"`
+}
+
+type providerUpgradeLocation struct {
+	URI   string    `json:"uri"`
+	Range lsp.Range `json:"range"`
+}
+
+// ProviderUpgradeReportHandler compares the schema of the given provider
+// version with the schema currently used by each module in the workspace
+// and reports blocks and attributes affected by upgrading to that version
+// as diagnostics, replacing diagnostics of any earlier report.
+//
+// Only schemas known to the server are compared, i.e. schemas obtained
+// from initialized modules or preloaded schemas bundled with the server.
+func (h *CmdHandler) ProviderUpgradeReportHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := providerUpgradeReportResponse{
+		FormatVersion: providerUpgradeReportVersion,
+		Modules:       make([]providerUpgradeModule, 0),
+	}
+
+	if h.ModulesFeature == nil {
+		return response, fmt.Errorf("provider upgrade report is not available")
+	}
+
+	parsedModules, err := h.ModulesFeature.ParsedModuleFiles(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	clear, _ := args.GetBool("clear")
+	if clear {
+		for modPath := range parsedModules {
+			err = h.ModulesFeature.UpdateProviderUpgradeReport(modPath, map[string]hcl.Diagnostics{})
+			if err != nil {
+				h.Logger.Printf("failed to clear provider upgrade report for %q: %s", modPath, err)
+			}
+		}
+		return response, nil
+	}
+
+	rawAddr, ok := args.GetString("provider")
+	if !ok || rawAddr == "" {
+		return response, fmt.Errorf("%w: expected provider argument to be set", jrpc2.InvalidParams.Err())
+	}
+	pAddr, err := tfaddr.ParseProviderSource(rawAddr)
+	if err != nil {
+		return response, fmt.Errorf("%w: invalid provider address: %s", jrpc2.InvalidParams.Err(), err)
+	}
+	rawVersion, ok := args.GetString("version")
+	if !ok || rawVersion == "" {
+		return response, fmt.Errorf("%w: expected version argument to be set", jrpc2.InvalidParams.Err())
+	}
+	targetVersion, err := version.NewVersion(rawVersion)
+	if err != nil {
+		return response, fmt.Errorf("%w: invalid version: %s", jrpc2.InvalidParams.Err(), err)
+	}
+	response.Provider = pAddr.String()
+	response.Version = targetVersion.String()
+
+	target, err := h.providerSchemaForVersion(ctx, pAddr, targetVersion)
+	if err != nil {
+		return response, err
+	}
+
+	modPaths := make([]string, 0, len(parsedModules))
+	for modPath := range parsedModules {
+		modPaths = append(modPaths, modPath)
+	}
+	sort.Strings(modPaths)
+
+	for _, modPath := range modPaths {
+		diags := make(map[string]hcl.Diagnostics)
+
+		current, ok := h.currentProviderSchema(modPath, pAddr, targetVersion)
+		if ok {
+			refs, _ := h.ModulesFeature.ProviderReferences(modPath)
+			u := upgrade.Upgrade{
+				Provider:      pAddr,
+				From:          current.Version,
+				CurrentSchema: current.Schema,
+				To:            target.Version,
+				TargetSchema:  target.Schema,
+			}
+			impacts := u.Impacts(parsedModules[modPath], refs)
+			if len(impacts) > 0 {
+				module := providerUpgradeModule{
+					URI:            uri.FromPath(modPath),
+					CurrentVersion: current.Version.String(),
+					Impacts:        make([]providerUpgradeImpact, 0, len(impacts)),
+				}
+				for _, impact := range impacts {
+					filename := impact.Range.Filename
+					diags[filename] = append(diags[filename], impact.Diagnostic())
+
+					module.Impacts = append(module.Impacts, providerUpgradeImpact{
+						Kind:        string(impact.Kind),
+						Address:     impact.Address,
+						Path:        impact.Path,
+						Replacement: impact.Replacement,
+						Summary:     impact.Summary,
+						Detail:      impact.Detail,
+						Range: providerUpgradeLocation{
+							URI:   uri.FromPath(filepath.Join(modPath, filename)),
+							Range: ilsp.HCLRangeToLSP(impact.Range),
+						},
+					})
+				}
+				response.Modules = append(response.Modules, module)
+			}
+		}
+
+		err = h.ModulesFeature.UpdateProviderUpgradeReport(modPath, diags)
+		if err != nil {
+			h.Logger.Printf("failed to update provider upgrade report for %q: %s", modPath, err)
+		}
+	}
+
+	return response, nil
+}
+
+// providerSchemaForVersion returns the schema of the given provider version,
+// preloading the bundled schema if it isn't known yet
+func (h *CmdHandler) providerSchemaForVersion(ctx context.Context, pAddr tfaddr.Provider, v *version.Version) (*state.ProviderSchema, error) {
+	ps, err := h.StateStore.ProviderSchemas.ProviderSchemaForVersion(pAddr, v)
+	if err == nil {
+		return ps, nil
+	}
+	var noSchemaErr *state.NoSchemaError
+	if !errors.As(err, &noSchemaErr) {
+		return nil, err
+	}
+
+	err = state.PreloadSchemaForProviderAddr(ctx, pAddr, schemas.FS, h.StateStore.ProviderSchemas, h.Logger)
+	if err != nil {
+		return nil, err
+	}
+	ps, err = h.StateStore.ProviderSchemas.ProviderSchemaForVersion(pAddr, v)
+	if err != nil {
+		return nil, fmt.Errorf("schema of %s %s is not available", pAddr.ForDisplay(), v)
+	}
+	return ps, nil
+}
+
+// currentProviderSchema returns the schema of the provider which best
+// matches the version constraints of the module, other than the target
+func (h *CmdHandler) currentProviderSchema(modPath string, pAddr tfaddr.Provider, target *version.Version) (*state.ProviderSchema, bool) {
+	var cons version.Constraints
+	if reqs, err := h.ModulesFeature.ProviderRequirements(modPath); err == nil {
+		cons = reqs[pAddr]
+	}
+
+	candidates, err := h.StateStore.ProviderSchemas.ProviderSchemaVersions(modPath, pAddr, cons)
+	if err != nil {
+		return nil, false
+	}
+	for _, ps := range candidates {
+		if !ps.Version.Equal(target) {
+			return ps, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,143 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/creachadair/jrpc2"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/search/ast"
+	"github.com/hashicorp/terraform-ls/internal/features/search/query"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	lsErrors "github.com/hashicorp/terraform-ls/internal/langserver/errors"
+	"github.com/hashicorp/terraform-ls/internal/langserver/progress"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/terraform/module"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const queryRunVersion = 0
+
+type queryRunResponse struct {
+	FormatVersion int         `json:"v"`
+	Lists         []queryList `json:"lists"`
+}
+
+type queryList struct {
+	Address      string        `json:"address"`
+	ResourceType string        `json:"resource_type"`
+	Results      []queryResult `json:"results"`
+}
+
+type queryResult struct {
+	DisplayName string         `json:"display_name"`
+	Identity    map[string]any `json:"identity"`
+}
+
+// QueryRunHandler runs terraform query in the directory of the given
+// query file and returns resources found by list blocks of that file.
+//
+// Results are kept, so that import and resource blocks can be generated
+// for them via code actions on the corresponding list blocks.
+func (h *CmdHandler) QueryRunHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := queryRunResponse{
+		FormatVersion: queryRunVersion,
+		Lists:         make([]queryList, 0),
+	}
+
+	fileUri, ok := args.GetString("uri")
+	if !ok || fileUri == "" {
+		return response, fmt.Errorf("%w: expected query file uri argument to be set", jrpc2.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(fileUri) {
+		return response, fmt.Errorf("URI %q is not valid", fileUri)
+	}
+
+	fh := document.HandleFromURI(fileUri)
+	if !ast.IsSearchFilename(fh.Filename) {
+		return response, fmt.Errorf("%w: %q is not a query file", jrpc2.InvalidParams.Err(), fh.Filename)
+	}
+
+	if h.SearchFeature == nil {
+		return response, fmt.Errorf("queries are not available")
+	}
+
+	searchPath := fh.Dir.Path()
+	tfExec, err := module.TerraformExecutorForModule(ctx, searchPath)
+	if err != nil {
+		return response, lsErrors.EnrichTfExecError(err)
+	}
+
+	progress.Begin(ctx, "Running query")
+	defer func() {
+		progress.End(ctx, "Finished")
+	}()
+
+	progress.Report(ctx, "Running terraform query ...")
+
+	var output bytes.Buffer
+	err = tfExec.Query(ctx, &output)
+	lists, diags, parseErr := query.ParseOutput(output.Bytes())
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.CtxErr == nil {
+			if summary, ok := firstError(diags); ok {
+				return response, fmt.Errorf("terraform query failed: %s", summary)
+			}
+		}
+		return response, err
+	}
+	if parseErr != nil {
+		return response, parseErr
+	}
+
+	err = h.SearchFeature.UpdateQueryResults(searchPath, lists)
+	if err != nil {
+		return response, err
+	}
+
+	listBlocks, err := h.SearchFeature.ListBlocks(searchPath, fh.Filename)
+	if err != nil {
+		return response, err
+	}
+
+	for _, list := range lists {
+		if _, ok := listBlocks[list.Address]; !ok && len(listBlocks) > 0 {
+			continue
+		}
+
+		ql := queryList{
+			Address:      list.Address,
+			ResourceType: list.ResourceType,
+			Results:      make([]queryResult, 0, len(list.Results)),
+		}
+		for _, result := range list.Results {
+			ql.Results = append(ql.Results, queryResult{
+				DisplayName: result.DisplayName,
+				Identity:    result.Identity,
+			})
+		}
+		response.Lists = append(response.Lists, ql)
+	}
+
+	return response, nil
+}
+
+func firstError(diags []tfjson.Diagnostic) (string, bool) {
+	for _, diag := range diags {
+		if diag.Severity == tfjson.DiagnosticSeverityError {
+			if diag.Detail != "" {
+				return fmt.Sprintf("%s: %s", diag.Summary, diag.Detail), true
+			}
+			return diag.Summary, true
+		}
+	}
+	return "", false
+}
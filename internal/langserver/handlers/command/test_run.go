@@ -0,0 +1,233 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/hcl/v2"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/tests/ast"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/langserver/diagnostics"
+	lsErrors "github.com/hashicorp/terraform-ls/internal/langserver/errors"
+	"github.com/hashicorp/terraform-ls/internal/langserver/progress"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/terraform/module"
+	"github.com/hashicorp/terraform-ls/internal/uri"
+)
+
+const testRunVersion = 0
+
+type testRunResponse struct {
+	FormatVersion int             `json:"v"`
+	Status        string          `json:"status"`
+	Runs          []testRunResult `json:"runs"`
+}
+
+type testRunResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// TestRunHandler runs tests of the given test file via terraform test
+// and reports failed assertions as diagnostics.
+//
+// Terraform can only filter tests by file, so the whole file is run
+// even if a particular run block is requested. The response then only
+// contains the result of that run block.
+func (h *CmdHandler) TestRunHandler(ctx context.Context, args cmd.CommandArgs) (interface{}, error) {
+	response := testRunResponse{
+		FormatVersion: testRunVersion,
+		Runs:          make([]testRunResult, 0),
+	}
+
+	fileUri, ok := args.GetString("uri")
+	if !ok || fileUri == "" {
+		return response, fmt.Errorf("%w: expected test file uri argument to be set", jrpc2.InvalidParams.Err())
+	}
+
+	if !uri.IsURIValid(fileUri) {
+		return response, fmt.Errorf("URI %q is not valid", fileUri)
+	}
+
+	fh := document.HandleFromURI(fileUri)
+	if !ast.IsTestFilename(fh.Filename) {
+		return response, fmt.Errorf("%w: %q is not a test file", jrpc2.InvalidParams.Err(), fh.Filename)
+	}
+	runName, _ := args.GetString("run")
+
+	testPath := fh.Dir.Path()
+	workDir := testPath
+	if h.TestsFeature != nil {
+		if modPath, ok := h.TestsFeature.TestedModulePath(testPath); ok {
+			workDir = modPath
+		}
+	}
+	filter, err := filepath.Rel(workDir, filepath.Join(testPath, fh.Filename))
+	if err != nil {
+		return response, err
+	}
+	filter = filepath.ToSlash(filter)
+
+	tfExec, err := module.TerraformExecutorForModule(ctx, workDir)
+	if err != nil {
+		return response, lsErrors.EnrichTfExecError(err)
+	}
+
+	progress.Begin(ctx, "Running tests")
+	defer func() {
+		progress.End(ctx, "Finished")
+	}()
+
+	progress.Report(ctx, fmt.Sprintf("Running terraform test -filter=%s ...", filter))
+
+	output := newTestOutput(ctx, filter)
+	err = tfExec.Test(ctx, output, filter)
+	output.Flush()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.CtxErr != nil || output.status == "" {
+			return response, err
+		}
+		// failing tests also cause non-zero exit code
+	}
+
+	if h.TestsFeature != nil {
+		err = h.TestsFeature.UpdateTestResults(testPath, fh.Filename, output.diagnostics(filter))
+		if err != nil {
+			h.Logger.Printf("failed to update test results for %q: %s", testPath, err)
+		}
+	}
+
+	response.Status = output.status
+	for _, run := range output.runs {
+		if runName != "" && run.Name != runName {
+			continue
+		}
+		response.Runs = append(response.Runs, run)
+	}
+
+	return response, nil
+}
+
+// testMessage represents a single line of machine-readable
+// output of terraform test
+type testMessage struct {
+	Message    string             `json:"@message"`
+	TestFile   string             `json:"@testfile"`
+	TestRun    string             `json:"@testrun"`
+	Type       string             `json:"type"`
+	Diagnostic *tfjson.Diagnostic `json:"diagnostic"`
+	Run        *struct {
+		Path     string `json:"path"`
+		Run      string `json:"run"`
+		Progress string `json:"progress"`
+		Status   string `json:"status"`
+	} `json:"test_run"`
+	Summary *struct {
+		Status string `json:"status"`
+	} `json:"test_summary"`
+}
+
+// testOutput processes output of terraform test as it is written,
+// reporting progress and collecting results of the given file
+type testOutput struct {
+	ctx      context.Context
+	testFile string
+	buf      bytes.Buffer
+
+	status    string
+	runs      []testRunResult
+	jsonDiags []tfjson.Diagnostic
+}
+
+func newTestOutput(ctx context.Context, testFile string) *testOutput {
+	return &testOutput{
+		ctx:       ctx,
+		testFile:  testFile,
+		runs:      make([]testRunResult, 0),
+		jsonDiags: make([]tfjson.Diagnostic, 0),
+	}
+}
+
+func (o *testOutput) Write(p []byte) (int, error) {
+	o.buf.Write(p)
+	for {
+		line, err := o.buf.ReadBytes('\n')
+		if err != nil {
+			// keep the incomplete line until the rest is written
+			o.buf.Reset()
+			o.buf.Write(line)
+			break
+		}
+		o.processLine(line)
+	}
+	return len(p), nil
+}
+
+// Flush processes any remaining output not terminated by a newline
+func (o *testOutput) Flush() {
+	if o.buf.Len() > 0 {
+		o.processLine(o.buf.Bytes())
+		o.buf.Reset()
+	}
+}
+
+func (o *testOutput) processLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+
+	var msg testMessage
+	err := json.Unmarshal(line, &msg)
+	if err != nil {
+		return
+	}
+
+	switch msg.Type {
+	case "test_run":
+		if msg.Run == nil || msg.Run.Path != o.testFile {
+			return
+		}
+		switch msg.Run.Progress {
+		case "starting":
+			progress.Report(o.ctx, fmt.Sprintf("Running %q ...", msg.Run.Run))
+		case "complete":
+			progress.Report(o.ctx, fmt.Sprintf("%q: %s", msg.Run.Run, msg.Run.Status))
+			o.runs = append(o.runs, testRunResult{
+				Name:   msg.Run.Run,
+				Status: msg.Run.Status,
+			})
+		}
+	case "diagnostic":
+		if msg.Diagnostic == nil || msg.TestFile != o.testFile {
+			return
+		}
+		o.jsonDiags = append(o.jsonDiags, *msg.Diagnostic)
+	case "test_summary":
+		if msg.Summary != nil {
+			o.status = msg.Summary.Status
+		}
+	}
+}
+
+// diagnostics returns diagnostics pointing to the given test file,
+// such as failed assertions, whose range is the failing condition
+func (o *testOutput) diagnostics(testFile string) hcl.Diagnostics {
+	diags := make(hcl.Diagnostics, 0)
+	for _, diag := range diagnostics.HCLDiagsFromJSON(o.jsonDiags)[testFile] {
+		diag.Subject.Filename = filepath.Base(testFile)
+		diags = append(diags, diag)
+	}
+	return diags
+}
@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestTestOutput(t *testing.T) {
+	output := newTestOutput(context.Background(), "tests/main.tftest.hcl")
+
+	lines := []string{
+		`{"@level":"info","@message":"  \"first\"... pass","@testfile":"tests/main.tftest.hcl","@testrun":"first","test_run":{"path":"tests/main.tftest.hcl","run":"first","progress":"complete","status":"pass"},"type":"test_run"}`,
+		`{"@level":"info","@message":"  \"other\"... pass","@testfile":"tests/other.tftest.hcl","@testrun":"other","test_run":{"path":"tests/other.tftest.hcl","run":"other","progress":"complete","status":"pass"},"type":"test_run"}`,
+		`{"@level":"info","@message":"  \"second\"... fail","@testfile":"tests/main.tftest.hcl","@testrun":"second","test_run":{"path":"tests/main.tftest.hcl","run":"second","progress":"complete","status":"fail"},"type":"test_run"}`,
+		`{"@level":"error","@message":"Error: Test assertion failed","@testfile":"tests/main.tftest.hcl","@testrun":"second","diagnostic":{"severity":"error","summary":"Test assertion failed","detail":"wrong name","range":{"filename":"tests/main.tftest.hcl","start":{"line":8,"column":17,"byte":99},"end":{"line":8,"column":41,"byte":123}}},"type":"diagnostic"}`,
+		`{"@level":"info","@message":"Failure! 1 passed, 1 failed.","test_summary":{"status":"fail","passed":1,"failed":1,"errored":0,"skipped":0},"type":"test_summary"}`,
+	}
+
+	// write in chunks not aligned with lines, as a process would
+	raw := []byte{}
+	for _, line := range lines {
+		raw = append(raw, []byte(line+"\n")...)
+	}
+	for len(raw) > 0 {
+		n := 100
+		if len(raw) < n {
+			n = len(raw)
+		}
+		output.Write(raw[:n])
+		raw = raw[n:]
+	}
+	output.Flush()
+
+	expectedRuns := []testRunResult{
+		{Name: "first", Status: "pass"},
+		{Name: "second", Status: "fail"},
+	}
+	if diff := cmp.Diff(expectedRuns, output.runs); diff != "" {
+		t.Fatalf("unexpected runs: %s", diff)
+	}
+	if output.status != "fail" {
+		t.Fatalf("unexpected status: %q", output.status)
+	}
+
+	expectedDiags := hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Test assertion failed",
+			Detail:   "wrong name",
+			Subject: &hcl.Range{
+				Filename: "main.tftest.hcl",
+				Start:    hcl.Pos{Line: 8, Column: 17, Byte: 99},
+				End:      hcl.Pos{Line: 8, Column: 41, Byte: 123},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDiags, output.diagnostics("tests/main.tftest.hcl")); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
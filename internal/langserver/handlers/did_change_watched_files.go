@@ -89,11 +89,34 @@ func (svc *service) DidChangeWatchedFiles(ctx context.Context, params lsp.DidCha
 			continue
 		}
 
+		// If the terraform.tfstate (or its JSON snapshot) file changes
+		if modUri, ok := datadir.ModuleUriFromStateFile(rawURI); ok {
+			modHandle := document.DirHandleFromURI(modUri)
+			svc.eventBus.StateChange(eventbus.StateChangeEvent{
+				Context:    ctx, // We pass the context for data here
+				Dir:        modHandle,
+				ChangeType: change.Type,
+			})
+
+			continue
+		}
+
 		rawPath, err := uri.PathFromURI(rawURI)
 		if err != nil {
 			svc.logger.Printf("error parsing %q: %s", rawURI, err)
 			continue
 		}
+
+		// If a file within a directory of provider schemas supplied by the user changes
+		if dir, ok := svc.providerSchemaDirForFile(rawPath); ok {
+			_, err = svc.loadProviderSchemaFiles(svc.sessCtx, dir)
+			if err != nil {
+				svc.logger.Printf("failed to schedule reloading of provider schemas from %q: %s", dir, err)
+			}
+
+			continue
+		}
+
 		isDir := false
 
 		if change.Type == lsp.Deleted {
@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/langserver"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/uri"
 	"github.com/hashicorp/terraform-ls/internal/walker"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/otiai10/copy"
@@ -1225,3 +1226,205 @@ func TestLangServer_DidChangeWatchedFiles_moduleInstalled(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestLangServer_DidChangeWatchedFiles_stateChanged(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"random_pet\" \"web\" {\n  count = 2\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	// no lenses without any state
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": []
+	}`)
+
+	err = os.WriteFile(filepath.Join(tmpDir.Path(), "terraform.tfstate"), []byte(testStateFile), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls.Call(t, &langserver.CallRequest{
+		Method: "workspace/didChangeWatchedFiles",
+		ReqParams: fmt.Sprintf(`{
+    "changes": [
+        {
+            "uri": "%s/terraform.tfstate",
+            "type": 1
+        }
+    ]
+}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 5,
+		"result": [
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 27}
+				},
+				"command": {
+					"title": "2 instances in state",
+					"command": ""
+				}
+			}
+		]
+	}`)
+}
+
+func TestLangServer_DidChangeWatchedFiles_providerSchemaFileChanged(t *testing.T) {
+	tmpDir := TempDir(t)
+	schemaDir := t.TempDir()
+	schemaFile := filepath.Join(schemaDir, "internal.json")
+	err := os.WriteFile(schemaFile, []byte(testInternalProviderSchema("name")), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+	    "processId": 12345,
+	    "initializationOptions": {
+	        "providerSchemas": {
+	            "directories": [%q]
+	        }
+	    }
+	}`, tmpDir.URI, schemaDir)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	waitForAllJobs(t, ss)
+
+	pAddr := tfaddr.MustParseProviderSource("acme/internal")
+	ps, err := ss.ProviderSchemas.ProviderSchema(tmpDir.Path(), pAddr, version.Constraints{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["internal_thing"].Attributes["name"]; !ok {
+		t.Fatalf("expected schema to be loaded, given %#v", ps.Resources["internal_thing"])
+	}
+
+	err = os.WriteFile(schemaFile, []byte(testInternalProviderSchema("label")), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls.Call(t, &langserver.CallRequest{
+		Method: "workspace/didChangeWatchedFiles",
+		ReqParams: fmt.Sprintf(`{
+    "changes": [
+        {
+            "uri": %q,
+            "type": 2
+        }
+    ]
+}`, uri.FromPath(schemaFile))})
+	waitForAllJobs(t, ss)
+
+	ps, err = ss.ProviderSchemas.ProviderSchema(tmpDir.Path(), pAddr, version.Constraints{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["internal_thing"].Attributes["label"]; !ok {
+		t.Fatalf("expected schema to be reloaded, given %#v", ps.Resources["internal_thing"])
+	}
+}
+
+func testInternalProviderSchema(attrName string) string {
+	return `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/acme/internal": {
+      "provider": {"version": 0, "block": {}},
+      "resource_schemas": {
+        "internal_thing": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "` + attrName + `": {"type": "string", "optional": true}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/creachadair/jrpc2"
 	"github.com/hashicorp/terraform-ls/internal/document"
 	"github.com/hashicorp/terraform-ls/internal/eventbus"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 	"github.com/hashicorp/terraform-ls/internal/uri"
 )
@@ -29,8 +30,13 @@ func (svc *service) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpenT
 		return fmt.Errorf("invalid URI: %s", docURI)
 	}
 
+	languageID := params.TextDocument.LanguageID
+	if languageID == ilsp.OpenTofu.String() {
+		languageID = ilsp.Terraform.String()
+	}
+
 	dh := document.HandleFromURI(docURI)
-	err := svc.stateStore.DocumentStore.OpenDocument(dh, params.TextDocument.LanguageID,
+	err := svc.stateStore.DocumentStore.OpenDocument(dh, languageID,
 		int(params.TextDocument.Version), []byte(params.TextDocument.Text))
 	if err != nil {
 		return err
@@ -39,7 +45,7 @@ func (svc *service) TextDocumentDidOpen(ctx context.Context, params lsp.DidOpenT
 	svc.eventBus.DidOpen(eventbus.DidOpenEvent{
 		Context:    ctx, // We pass the context for data here
 		Dir:        dh.Dir,
-		LanguageID: params.TextDocument.LanguageID,
+		LanguageID: languageID,
 	})
 
 	if svc.singleFileMode {
@@ -22,15 +22,28 @@ func cmdHandlers(svc *service) cmd.Handlers {
 	if svc.features != nil {
 		cmdHandler.ModulesFeature = svc.features.Modules
 		cmdHandler.RootModulesFeature = svc.features.RootModules
+		cmdHandler.TestsFeature = svc.features.Tests
+		cmdHandler.PolicyFeature = svc.features.Policy
+		cmdHandler.PolicyTestFeature = svc.features.PolicyTest
+		cmdHandler.SearchFeature = svc.features.Search
+		cmdHandler.VariablesFeature = svc.features.Variables
 	}
 	return cmd.Handlers{
-		cmd.Name("rootmodules"):        removedHandler("use module.callers instead"),
-		cmd.Name("module.callers"):     cmdHandler.ModuleCallersHandler,
-		cmd.Name("terraform.init"):     cmdHandler.TerraformInitHandler,
-		cmd.Name("terraform.validate"): cmdHandler.TerraformValidateHandler,
-		cmd.Name("module.calls"):       cmdHandler.ModuleCallsHandler,
-		cmd.Name("module.providers"):   cmdHandler.ModuleProvidersHandler,
-		cmd.Name("module.terraform"):   cmdHandler.TerraformVersionRequestHandler,
+		cmd.Name("rootmodules"):            removedHandler("use module.callers instead"),
+		cmd.Name("module.callers"):         cmdHandler.ModuleCallersHandler,
+		cmd.Name("terraform.init"):         cmdHandler.TerraformInitHandler,
+		cmd.Name("terraform.validate"):     cmdHandler.TerraformValidateHandler,
+		cmd.Name("module.calls"):           cmdHandler.ModuleCallsHandler,
+		cmd.Name("module.providers"):       cmdHandler.ModuleProvidersHandler,
+		cmd.Name("module.terraform"):       cmdHandler.TerraformVersionRequestHandler,
+		cmd.Name("test.run"):               cmdHandler.TestRunHandler,
+		cmd.Name("policy.preview"):         cmdHandler.PolicyPreviewHandler,
+		cmd.Name("policytest.run"):         cmdHandler.PolicyTestRunHandler,
+		cmd.Name("query.run"):              cmdHandler.QueryRunHandler,
+		cmd.Name("evaluate"):               cmdHandler.EvaluateHandler,
+		cmd.Name("plan.annotate"):          cmdHandler.PlanAnnotateHandler,
+		cmd.Name("plan.clear"):             cmdHandler.PlanClearHandler,
+		cmd.Name("provider.upgradeReport"): cmdHandler.ProviderUpgradeReportHandler,
 	}
 }
 
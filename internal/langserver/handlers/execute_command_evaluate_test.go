@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_workspaceExecuteCommand_evaluate(t *testing.T) {
+	tmpDir := TempDir(t)
+	testFileURI := fmt.Sprintf("%s/main.tf", tmpDir.URI)
+	InitPluginCache(t, tmpDir.Path())
+
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "terraform.tfvars"), []byte("env = \"prod\"\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "variable \"env\" {\n  default = \"dev\"\n}\n\nlocals {\n  cidr = \"10.0.0.0/16\"\n  name = \"${var.env}-app\"\n}\n",
+			"uri": %q
+		}
+	}`, testFileURI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s", "expression=[local.name, cidrsubnet(local.cidr, 8, 1)]"]
+	}`, cmd.Name("evaluate"), tmpDir.URI)}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": {
+			"v": 0,
+			"value": "[\"prod-app\", \"10.0.1.0/24\"]",
+			"type": "tuple([string,string])",
+			"known": true
+		}
+	}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s", "expression=aws_instance.web.id"]
+	}`, cmd.Name("evaluate"), tmpDir.URI)}, `{
+		"jsonrpc": "2.0",
+		"id": 4,
+		"result": {
+			"v": 0,
+			"type": "any",
+			"known": false
+		}
+	}`)
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("evaluate"), tmpDir.URI)}, jrpc2.InvalidParams.Err())
+}
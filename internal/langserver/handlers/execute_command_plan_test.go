@@ -0,0 +1,195 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLangServer_workspaceExecuteCommand_planAnnotate(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "tfplan.json"), []byte(testPlanFile), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"random_pet\" \"web\" {\n  length = 3\n}\n\nresource \"random_pet\" \"db\" {\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s", "plan=tfplan.json"]
+	}`, cmd.Name("plan.annotate"), tmpDir.URI)}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": {
+			"v": 0,
+			"changes": [
+				{
+					"address": "random_pet.db",
+					"actions": ["create"],
+					"summary": "will be created"
+				},
+				{
+					"address": "random_pet.web",
+					"actions": ["delete", "create"],
+					"summary": "will be replaced"
+				}
+			]
+		}
+	}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 4,
+		"result": [
+			{
+				"range": {
+					"start": {"line": 0, "character": 0},
+					"end": {"line": 0, "character": 27}
+				},
+				"command": {
+					"title": "will be replaced",
+					"command": ""
+				}
+			},
+			{
+				"range": {
+					"start": {"line": 4, "character": 0},
+					"end": {"line": 4, "character": 26}
+				},
+				"command": {
+					"title": "will be created",
+					"command": ""
+				}
+			}
+		]
+	}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("plan.clear"), tmpDir.URI)}, `{
+		"jsonrpc": "2.0",
+		"id": 5,
+		"result": null
+	}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeLens",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			}
+		}`, tmpDir.URI),
+	}, `{
+		"jsonrpc": "2.0",
+		"id": 6,
+		"result": []
+	}`)
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("plan.annotate"), tmpDir.URI)}, jrpc2.InvalidParams.Err())
+}
+
+const testPlanFile = `{
+  "format_version": "1.2",
+  "terraform_version": "1.9.0",
+  "resource_changes": [
+    {
+      "address": "random_pet.web",
+      "mode": "managed",
+      "type": "random_pet",
+      "name": "web",
+      "provider_name": "registry.terraform.io/hashicorp/random",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {"id": "fond-fox", "length": 2},
+        "after": {"length": 3},
+        "after_unknown": {"id": true},
+        "replace_paths": [["length"]]
+      }
+    },
+    {
+      "address": "random_pet.db",
+      "mode": "managed",
+      "type": "random_pet",
+      "name": "db",
+      "provider_name": "registry.terraform.io/hashicorp/random",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"length": 2},
+        "after_unknown": {"id": true}
+      }
+    }
+  ]
+}`
@@ -0,0 +1,164 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfexec "github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+const policyPreviewMockPolicy = `resource_policy "aws_s3_bucket" "acl" {
+  enforce {
+    condition     = attrs.acl == "private"
+    error_message = "Buckets must be private"
+  }
+}
+`
+
+const policyPreviewMockConfig = `resource "aws_s3_bucket" "private" {
+  acl = "private"
+}
+
+resource "aws_s3_bucket" "public" {
+  acl = "public-read"
+}
+
+resource "aws_s3_bucket" "computed" {
+  acl = var.acl
+}
+`
+
+func TestLangServer_workspaceExecuteCommand_policyPreview_argumentError(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q
+	}`, cmd.Name("policy.preview"))}, jrpc2.InvalidParams.Err())
+}
+
+func TestLangServer_workspaceExecuteCommand_policyPreview_basic(t *testing.T) {
+	tmpDir := TempDir(t, "policies")
+	policyDir := filepath.Join(tmpDir.Path(), "policies")
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.tf"), []byte(policyPreviewMockConfig), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(policyDir, "main.policy.hcl"), []byte(policyPreviewMockPolicy), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policyFileURI := fmt.Sprintf("%s/policies/main.policy.hcl", tmpDir.URI)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+				policyDir:     validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform-policy",
+			"text": %q,
+			"uri": %q
+		}
+	}`, policyPreviewMockPolicy, policyFileURI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("policy.preview"), policyFileURI)}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": {
+			"v": 0,
+			"results": [
+				{
+					"policy": "resource_policy.aws_s3_bucket.acl",
+					"resource": "aws_s3_bucket.private",
+					"module_uri": %q,
+					"status": "pass"
+				},
+				{
+					"policy": "resource_policy.aws_s3_bucket.acl",
+					"resource": "aws_s3_bucket.public",
+					"module_uri": %q,
+					"status": "fail",
+					"message": "Buckets must be private"
+				},
+				{
+					"policy": "resource_policy.aws_s3_bucket.acl",
+					"resource": "aws_s3_bucket.computed",
+					"module_uri": %q,
+					"status": "not_evaluable",
+					"message": "The condition depends on values which are not known statically"
+				}
+			]
+		}
+	}`, tmpDir.URI, tmpDir.URI, tmpDir.URI))
+}
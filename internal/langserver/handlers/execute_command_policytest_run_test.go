@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfexec "github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+const policyTestRunMockPolicy = `resource_policy "aws_instance" "type" {
+  enforce {
+    condition     = attrs.instance_type == "t2.micro"
+    error_message = "Only t2.micro instances are allowed"
+  }
+}
+`
+
+const policyTestRunMockConfig = `resource "aws_instance" "valid" {
+  attrs = {
+    instance_type = "t2.micro"
+  }
+}
+
+resource "aws_instance" "invalid" {
+  attrs = {
+    instance_type = "m5.large"
+  }
+}
+`
+
+func TestLangServer_workspaceExecuteCommand_policyTestRun_argumentError(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s/main.policy.hcl"]
+	}`, cmd.Name("policytest.run"), tmpDir.URI)}, jrpc2.InvalidParams.Err())
+}
+
+func TestLangServer_workspaceExecuteCommand_policyTestRun_inProcess(t *testing.T) {
+	tmpDir := TempDir(t, "tests")
+	testDir := filepath.Join(tmpDir.Path(), "tests")
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.policy.hcl"), []byte(policyTestRunMockPolicy), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(testDir, "main.policytest.hcl"), []byte(policyTestRunMockConfig), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFileURI := fmt.Sprintf("%s/tests/main.policytest.hcl", tmpDir.URI)
+
+	// Terraform without support for policy tests
+	tfMockCalls := append(validTfMockCalls(), &mock.Call{
+		Method:        "PolicyTest",
+		Repeatability: 1,
+		Arguments: []interface{}{
+			mock.AnythingOfType(""),
+			mock.Anything,
+			"tests/main.policytest.hcl",
+		},
+		ReturnArguments: []interface{}{
+			&tfexec.ExitError{
+				Err: &exec.ExitError{},
+			},
+		},
+	})
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): tfMockCalls,
+				testDir:       validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform-policytest",
+			"text": %q,
+			"uri": %q
+		}
+	}`, policyTestRunMockConfig, testFileURI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("policytest.run"), testFileURI)}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": {
+			"v": 0,
+			"status": "fail",
+			"runs": [
+				{
+					"name": "resource.aws_instance.valid",
+					"status": "pass"
+				},
+				{
+					"name": "resource.aws_instance.invalid",
+					"status": "fail"
+				}
+			]
+		}
+	}`)
+}
@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfexec "github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
+	"github.com/stretchr/testify/mock"
+)
+
+const providerUpgradeMockConfig = `resource "upgradetest_instance" "web" {
+  name      = "web"
+  disk_size = 10
+}
+`
+
+func TestLangServer_workspaceExecuteCommand_providerUpgradeReport_argumentError(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["provider=hashicorp/upgradetest"]
+	}`, cmd.Name("provider.upgradeReport"))}, jrpc2.InvalidParams.Err())
+}
+
+func TestLangServer_workspaceExecuteCommand_providerUpgradeReport_basic(t *testing.T) {
+	tmpDir := TempDir(t)
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.tf"), []byte(providerUpgradeMockConfig), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainFileURI := fmt.Sprintf("%s/main.tf", tmpDir.URI)
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pAddr := tfaddr.MustParseProviderSource("hashicorp/upgradetest")
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("1.0.0")), &tfschema.ProviderSchema{
+		Resources: map[string]*schema.BodySchema{
+			"upgradetest_instance": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"name":      {IsRequired: true},
+					"disk_size": {IsOptional: true},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.ProviderSchemas.AddPreloadedSchema(pAddr, version.Must(version.NewVersion("2.0.0")), &tfschema.ProviderSchema{
+		Resources: map[string]*schema.BodySchema{
+			"upgradetest_instance": {
+				Attributes: map[string]*schema.AttributeSchema{
+					"name": {
+						IsRequired:   true,
+						IsDeprecated: true,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["provider=hashicorp/upgradetest", "version=2.0.0"]
+	}`, cmd.Name("provider.upgradeReport"))}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 2,
+		"result": {
+			"v": 0,
+			"provider": "registry.terraform.io/hashicorp/upgradetest",
+			"version": "2.0.0",
+			"modules": [
+				{
+					"uri": %q,
+					"current_version": "1.0.0",
+					"impacts": [
+						{
+							"kind": "removed",
+							"address": "upgradetest_instance.web",
+							"path": "disk_size",
+							"summary": "Argument removed in hashicorp/upgradetest 2.0.0",
+							"detail": "Argument \"disk_size\" of upgradetest_instance.web is not supported by hashicorp/upgradetest 2.0.0.",
+							"range": {
+								"uri": %q,
+								"range": {
+									"start": {"line": 2, "character": 2},
+									"end": {"line": 2, "character": 11}
+								}
+							}
+						},
+						{
+							"kind": "deprecated",
+							"address": "upgradetest_instance.web",
+							"path": "name",
+							"summary": "Argument deprecated in hashicorp/upgradetest 2.0.0",
+							"detail": "Argument \"name\" is deprecated in hashicorp/upgradetest 2.0.0.",
+							"range": {
+								"uri": %q,
+								"range": {
+									"start": {"line": 1, "character": 2},
+									"end": {"line": 1, "character": 6}
+								}
+							}
+						}
+					]
+				}
+			]
+		}
+	}`, tmpDir.URI, mainFileURI, mainFileURI))
+}
@@ -0,0 +1,195 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfexec "github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+const queryRunMockOutput = `{"@level":"info","@message":"Terraform 1.14.0","@module":"terraform.ui","type":"version","terraform":"1.14.0","ui":"1.2"}
+{"@level":"info","@message":"list.test_instance.all: Starting query...","@module":"terraform.ui","list_start":{"address":"list.test_instance.all","resource_type":"test_instance"},"type":"list_start"}
+{"@level":"info","@message":"list.test_instance.all: Result found","@module":"terraform.ui","list_resource_found":{"address":"list.test_instance.all","display_name":"web","identity":{"id":"i-0123"},"identity_version":1,"resource_type":"test_instance"},"type":"list_resource_found"}
+{"@level":"info","@message":"list.test_instance.all: List complete","@module":"terraform.ui","list_complete":{"address":"list.test_instance.all","resource_type":"test_instance","total":1},"type":"list_complete"}
+`
+
+const queryRunMockConfig = `list "test_instance" "all" {
+  provider = test
+}
+`
+
+func TestLangServer_workspaceExecuteCommand_queryRun_argumentError(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s/main.tf"]
+	}`, cmd.Name("query.run"), tmpDir.URI)}, jrpc2.InvalidParams.Err())
+}
+
+func TestLangServer_workspaceExecuteCommand_queryRun_basic(t *testing.T) {
+	tmpDir := TempDir(t)
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.tf"), []byte(`resource "test_instance" "web" {}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(tmpDir.Path(), "main.tfquery.hcl"), []byte(queryRunMockConfig), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queryFileURI := fmt.Sprintf("%s/main.tfquery.hcl", tmpDir.URI)
+
+	tfMockCalls := append(validTfMockCalls(), &mock.Call{
+		Method:        "Query",
+		Repeatability: 1,
+		Arguments: []interface{}{
+			mock.AnythingOfType(""),
+			mock.Anything,
+		},
+		ReturnArguments: []interface{}{
+			nil,
+		},
+		RunFn: func(args mock.Arguments) {
+			w := args.Get(1).(io.Writer)
+			w.Write([]byte(queryRunMockOutput))
+		},
+	})
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): tfMockCalls,
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform-search",
+			"text": %q,
+			"uri": %q
+		}
+	}`, queryRunMockConfig, queryFileURI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s"]
+	}`, cmd.Name("query.run"), queryFileURI)}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": {
+			"v": 0,
+			"lists": [
+				{
+					"address": "list.test_instance.all",
+					"resource_type": "test_instance",
+					"results": [
+						{
+							"display_name": "web",
+							"identity": {
+								"id": "i-0123"
+							}
+						}
+					]
+				}
+			]
+		}
+	}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/codeAction",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": { "uri": %q },
+		"range": {
+			"start": { "line": 0, "character": 2 },
+			"end": { "line": 0, "character": 2 }
+		},
+		"context": { "diagnostics": [] }
+	}`, queryFileURI)}, fmt.Sprintf(`{
+		"jsonrpc": "2.0",
+		"id": 4,
+		"result": [
+			{
+				"title": "Import 1 resource found by list.test_instance.all into main.tf",
+				"kind": "refactor.rewrite.import.terraform",
+				"edit": {
+					"changes": {
+						"%s/main.tf": [
+							{
+								"range": {
+									"start": { "line": 0, "character": 33 },
+									"end": { "line": 0, "character": 33 }
+								},
+								"newText": "\n\nimport {\n  to = test_instance.web_2\n  identity = {\n    id = \"i-0123\"\n  }\n}\n\nresource \"test_instance\" \"web_2\" {\n}\n"
+							}
+						]
+					}
+				}
+			}
+		]
+	}`, tmpDir.URI))
+}
@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/hashicorp/terraform-ls/internal/langserver"
+	"github.com/hashicorp/terraform-ls/internal/langserver/cmd"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	tfexec "github.com/hashicorp/terraform-ls/internal/terraform/exec"
+	"github.com/hashicorp/terraform-ls/internal/walker"
+	"github.com/stretchr/testify/mock"
+)
+
+const testRunMockOutput = `{"@level":"info","@message":"Terraform 1.9.0","@module":"terraform.ui","type":"version","terraform":"1.9.0","ui":"1.2"}
+{"@level":"info","@message":"Found 1 file and 2 run blocks","@module":"terraform.ui","test_abstract":{"tests/main.tftest.hcl":["first","second"]},"type":"test_abstract"}
+{"@level":"info","@message":"tests/main.tftest.hcl... in progress","@module":"terraform.ui","@testfile":"tests/main.tftest.hcl","test_file":{"path":"tests/main.tftest.hcl","progress":"starting"},"type":"test_file"}
+{"@level":"info","@message":"  \"first\"... in progress","@module":"terraform.ui","@testfile":"tests/main.tftest.hcl","@testrun":"first","test_run":{"path":"tests/main.tftest.hcl","run":"first","progress":"starting","elapsed":0},"type":"test_run"}
+{"@level":"info","@message":"  \"first\"... pass","@module":"terraform.ui","@testfile":"tests/main.tftest.hcl","@testrun":"first","test_run":{"path":"tests/main.tftest.hcl","run":"first","progress":"complete","status":"pass"},"type":"test_run"}
+{"@level":"info","@message":"  \"second\"... in progress","@module":"terraform.ui","@testfile":"tests/main.tftest.hcl","@testrun":"second","test_run":{"path":"tests/main.tftest.hcl","run":"second","progress":"starting","elapsed":0},"type":"test_run"}
+{"@level":"info","@message":"  \"second\"... fail","@module":"terraform.ui","@testfile":"tests/main.tftest.hcl","@testrun":"second","test_run":{"path":"tests/main.tftest.hcl","run":"second","progress":"complete","status":"fail"},"type":"test_run"}
+{"@level":"error","@message":"Error: Test assertion failed","@module":"terraform.ui","@testfile":"tests/main.tftest.hcl","@testrun":"second","diagnostic":{"severity":"error","summary":"Test assertion failed","detail":"wrong name","range":{"filename":"tests/main.tftest.hcl","start":{"line":8,"column":17,"byte":99},"end":{"line":8,"column":41,"byte":123}}},"type":"diagnostic"}
+{"@level":"info","@message":"tests/main.tftest.hcl... fail","@module":"terraform.ui","@testfile":"tests/main.tftest.hcl","test_file":{"path":"tests/main.tftest.hcl","progress":"complete","status":"fail"},"type":"test_file"}
+{"@level":"info","@message":"Failure! 1 passed, 1 failed.","@module":"terraform.ui","test_summary":{"status":"fail","passed":1,"failed":1,"errored":0,"skipped":0},"type":"test_summary"}
+`
+
+const testRunMockConfig = `run "first" {
+  assert {
+    condition     = true
+    error_message = "fail"
+  }
+}
+run "second" {
+  assert {
+    condition     = var.name == "expected"
+    error_message = "wrong name"
+  }
+}
+`
+
+func TestLangServer_workspaceExecuteCommand_testRun_argumentError(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+
+	ls.CallAndExpectError(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s/main.tf"]
+	}`, cmd.Name("test.run"), tmpDir.URI)}, jrpc2.InvalidParams.Err())
+}
+
+func TestLangServer_workspaceExecuteCommand_testRun_basic(t *testing.T) {
+	tmpDir := TempDir(t, "tests")
+	testDir := filepath.Join(tmpDir.Path(), "tests")
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "main.tf"), []byte(`variable "name" {}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(testDir, "main.tftest.hcl"), []byte(testRunMockConfig), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFileURI := fmt.Sprintf("%s/tests/main.tftest.hcl", tmpDir.URI)
+
+	tfMockCalls := append(validTfMockCalls(), &mock.Call{
+		Method:        "Test",
+		Repeatability: 1,
+		Arguments: []interface{}{
+			mock.AnythingOfType(""),
+			mock.Anything,
+			"tests/main.tftest.hcl",
+		},
+		ReturnArguments: []interface{}{
+			&tfexec.ExitError{
+				Err: &exec.ExitError{},
+			},
+		},
+		RunFn: func(args mock.Arguments) {
+			w := args.Get(1).(io.Writer)
+			w.Write([]byte(testRunMockOutput))
+		},
+	})
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &tfexec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): tfMockCalls,
+				testDir:       validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+	    "capabilities": {},
+	    "rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform-test",
+			"text": %q,
+			"uri": %q
+		}
+	}`, testRunMockConfig, testFileURI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "workspace/executeCommand",
+		ReqParams: fmt.Sprintf(`{
+		"command": %q,
+		"arguments": ["uri=%s", "run=second"]
+	}`, cmd.Name("test.run"), testFileURI)}, `{
+		"jsonrpc": "2.0",
+		"id": 3,
+		"result": {
+			"v": 0,
+			"status": "fail",
+			"runs": [
+				{
+					"name": "second",
+					"status": "fail"
+				}
+			]
+		}
+	}`)
+}
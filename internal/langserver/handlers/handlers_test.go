@@ -51,7 +51,7 @@ func initializeResponse(t *testing.T, commandPrefix string) string {
 				"referencesProvider": true,
 				"documentSymbolProvider": true,
 				"codeActionProvider": {
-					"codeActionKinds": ["source.formatAll.terraform"]
+					"codeActionKinds": ["refactor.extract.local.terraform", "refactor.extract.module.terraform", "refactor.extract.variable.terraform", "refactor.inline.local.terraform", "refactor.rewrite.forEach.terraform", "refactor.rewrite.import.terraform", "refactor.rewrite.moduleVersion.terraform", "source.formatAll.terraform"]
 				},
 				"codeLensProvider": {},
 				"documentLinkProvider": {},
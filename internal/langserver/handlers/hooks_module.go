@@ -115,7 +115,8 @@ func callRefreshClientCommand(clientRequester session.ClientCaller, commandId st
 func refreshCodeLens(clientRequester session.ClientCaller) notifier.Hook {
 	return func(ctx context.Context, changes state.Changes) error {
 		// TODO: avoid triggering for new targets outside of open module
-		if changes.ReferenceOrigins || changes.ReferenceTargets {
+		if changes.ReferenceOrigins || changes.ReferenceTargets || changes.State || changes.Plan ||
+			changes.RegistryModuleVersions {
 			_, err := clientRequester.Callback(ctx, "workspace/codeLens/refresh", nil)
 			if err != nil {
 				return err
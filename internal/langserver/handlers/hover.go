@@ -44,6 +44,7 @@ func (svc *service) TextDocumentHover(ctx context.Context, params lsp.TextDocume
 	if err != nil {
 		return nil, err
 	}
+	hoverData = svc.hoverWithValue(doc, pos, hoverData)
 
 	return ilsp.HoverData(hoverData, cc.TextDocument), nil
 }
@@ -6,15 +6,20 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
 	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/hashicorp/terraform-ls/internal/langserver"
 	"github.com/hashicorp/terraform-ls/internal/langserver/session"
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	tfschema "github.com/hashicorp/terraform-schema/schema"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -263,3 +268,348 @@ func TestVarsHover_withValidData(t *testing.T) {
 			}
 		}`)
 }
+
+func TestHover_withValue(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "locals {\n  cidr   = \"10.0.0.0/16\"\n  subnet = cidrsubnet(local.cidr, 8, 1)\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/hover",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 26,
+				"line": 2
+			}
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"contents": {
+					"kind": "plaintext",
+					"value": "local.cidr\nstring\n\n---\nValue\n\"10.0.0.0/16\"\n"
+				},
+				"range": {
+					"start": {
+						"line": 2,
+						"character": 22
+					},
+					"end": {
+						"line": 2,
+						"character": 32
+					}
+				}
+			}
+		}`)
+}
+
+func TestHover_withStateValue(t *testing.T) {
+	tmpDir := TempDir(t)
+	InitPluginCache(t, tmpDir.Path())
+
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "terraform.tfstate"), []byte(testStateFile), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"random_pet\" \"web\" {\n  count = 2\n}\n\noutput \"keepers\" {\n  value = random_pet.web[0].keepers\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/hover",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 14,
+				"line": 5
+			}
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"contents": {
+					"kind": "plaintext",
+					"value": "random_pet.web[0].keepers\n\n---\nValue in state\n{\n  secret = (sensitive value)\n}\n"
+				},
+				"range": {
+					"start": {
+						"line": 5,
+						"character": 10
+					},
+					"end": {
+						"line": 5,
+						"character": 35
+					}
+				}
+			}
+		}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/hover",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 24,
+				"line": 0
+			}
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": {
+				"contents": {
+					"kind": "plaintext",
+					"value": "\"web\" (name)\n\nReference Name\n\n---\nValue in state\nrandom_pet.web[0] = {\n  id = \"fond-fox\"\n  keepers = {\n    secret = (sensitive value)\n  }\n  length = 2\n}\nrandom_pet.web[1] = {\n  id      = \"brave-owl\"\n  keepers = null\n  length  = 2\n}\n"
+				},
+				"range": {
+					"start": {
+						"line": 0,
+						"character": 22
+					},
+					"end": {
+						"line": 0,
+						"character": 27
+					}
+				}
+			}
+		}`)
+}
+
+const testSchemaSensitiveStateFile = `{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "serial": 1,
+  "lineage": "0e1f0a4e-5d2b-4c3a-9f2d-1c1b7e1c9a0f",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "secrets_db",
+      "name": "main",
+      "provider": "provider[\"registry.terraform.io/hashicorp/secrets\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {"name": "main", "password": "s3cr3t"},
+          "sensitive_attributes": []
+        }
+      ]
+    }
+  ]
+}`
+
+func TestHover_withSchemaSensitiveStateValue(t *testing.T) {
+	tmpDir := TempDir(t)
+
+	err := os.WriteFile(filepath.Join(tmpDir.Path(), "terraform.tfstate"), []byte(testSchemaSensitiveStateFile), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ss, err := state.NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ss.ProviderSchemas.AddPreloadedSchema(tfaddr.MustParseProviderSource("hashicorp/secrets"),
+		version.Must(version.NewVersion("1.0.0")), &tfschema.ProviderSchema{
+			Resources: map[string]*schema.BodySchema{
+				"secrets_db": {
+					Attributes: map[string]*schema.AttributeSchema{
+						"name": {IsRequired: true},
+						"password": {
+							IsOptional:  true,
+							IsSensitive: true,
+						},
+					},
+				},
+			},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wc := walker.NewWalkerCollector()
+
+	ls := langserver.NewLangServerMock(t, NewMockSession(&MockSessionInput{
+		TerraformCalls: &exec.TerraformMockCalls{
+			PerWorkDir: map[string][]*mock.Call{
+				tmpDir.Path(): validTfMockCalls(),
+			},
+		},
+		StateStore:      ss,
+		WalkerCollector: wc,
+	}))
+	stop := ls.Start(t)
+	defer stop()
+
+	ls.Call(t, &langserver.CallRequest{
+		Method: "initialize",
+		ReqParams: fmt.Sprintf(`{
+		"capabilities": {},
+		"rootUri": %q,
+		"processId": 12345
+	}`, tmpDir.URI)})
+	waitForWalkerPath(t, ss, wc, tmpDir)
+	ls.Notify(t, &langserver.CallRequest{
+		Method:    "initialized",
+		ReqParams: "{}",
+	})
+	ls.Call(t, &langserver.CallRequest{
+		Method: "textDocument/didOpen",
+		ReqParams: fmt.Sprintf(`{
+		"textDocument": {
+			"version": 0,
+			"languageId": "terraform",
+			"text": "resource \"secrets_db\" \"main\" {\n  name = \"main\"\n}\n\noutput \"password\" {\n  value     = secrets_db.main.password\n  sensitive = true\n}\n",
+			"uri": "%s/main.tf"
+		}
+	}`, tmpDir.URI)})
+	waitForAllJobs(t, ss)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/hover",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 20,
+				"line": 5
+			}
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 3,
+			"result": {
+				"contents": {
+					"kind": "plaintext",
+					"value": "secrets_db.main.password\n\n---\nValue in state\n(sensitive value)\n"
+				},
+				"range": {
+					"start": {
+						"line": 5,
+						"character": 14
+					},
+					"end": {
+						"line": 5,
+						"character": 38
+					}
+				}
+			}
+		}`)
+
+	ls.CallAndExpectResponse(t, &langserver.CallRequest{
+		Method: "textDocument/hover",
+		ReqParams: fmt.Sprintf(`{
+			"textDocument": {
+				"uri": "%s/main.tf"
+			},
+			"position": {
+				"character": 24,
+				"line": 0
+			}
+		}`, tmpDir.URI)}, `{
+			"jsonrpc": "2.0",
+			"id": 4,
+			"result": {
+				"contents": {
+					"kind": "plaintext",
+					"value": "\"main\" (name)\n\nReference Name\n\n---\nValue in state\nsecrets_db.main = {\n  name     = \"main\"\n  password = (sensitive value)\n}\n"
+				},
+				"range": {
+					"start": {
+						"line": 0,
+						"character": 22
+					},
+					"end": {
+						"line": 0,
+						"character": 28
+					}
+				}
+			}
+		}`)
+}
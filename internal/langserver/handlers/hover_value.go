@@ -0,0 +1,328 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/decoder"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/features/modules/eval"
+	ilsp "github.com/hashicorp/terraform-ls/internal/lsp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// hoverWithValue adds the value of the local value, the reference
+// or the resource block at the given position to the hover data.
+//
+// Input variables and local values are evaluated statically, while
+// values of resources and data sources come from the state, if any.
+// Values which are not fully known are left out. References which
+// have no hover data on their own, e.g. due to an unknown schema,
+// get hover data just for the value.
+func (svc *service) hoverWithValue(doc *document.Document, pos hcl.Pos, hoverData *lang.HoverData) *lang.HoverData {
+	if doc.LanguageID != ilsp.Terraform.String() || svc.features == nil || svc.features.Modules == nil {
+		return hoverData
+	}
+
+	modPath := doc.Dir.Path()
+	pathCtx, err := svc.features.Modules.PathContext(lang.Path{
+		Path:       modPath,
+		LanguageID: ilsp.Terraform.String(),
+	})
+	if err != nil {
+		return hoverData
+	}
+
+	title, value, ok := svc.valueAtPos(pathCtx, modPath, doc.Filename, pos)
+	if !ok {
+		return hoverData
+	}
+
+	if hoverData == nil {
+		f, ok := pathCtx.Files[doc.Filename]
+		if !ok {
+			return nil
+		}
+		traversal, ok := traversalAtPos(f, pathCtx.ReferenceOrigins, doc.Filename, pos)
+		if !ok {
+			return nil
+		}
+		rng := traversal.SourceRange()
+		hoverData = &lang.HoverData{
+			Content: lang.Markdown(fmt.Sprintf("`%s`", rng.SliceBytes(f.Bytes))),
+			Range:   rng,
+		}
+	}
+
+	if hoverData.Content.Kind == lang.MarkdownKind {
+		hoverData.Content.Value += fmt.Sprintf("\n\n---\n**%s**\n```terraform\n%s\n```\n", title, value)
+	} else {
+		hoverData.Content.Value += fmt.Sprintf("\n\n%s: %s", title, value)
+	}
+	return hoverData
+}
+
+// valueAtPos returns the formatted value at the given position
+// along with the title describing where it comes from
+func (svc *service) valueAtPos(pathCtx *decoder.PathContext, modPath, filename string, pos hcl.Pos) (string, string, bool) {
+	if value, ok := svc.evaluatedValueAtPos(pathCtx, modPath, filename, pos); ok {
+		return "Value", value, true
+	}
+
+	if svc.features.RootModules == nil {
+		return "", "", false
+	}
+	st, ok := svc.features.RootModules.State(modPath)
+	if !ok {
+		return "", "", false
+	}
+	st = markSchemaSensitive(pathCtx.Schema, st)
+	if value, ok := stateValueAtPos(pathCtx, st, filename, pos); ok {
+		return "Value in state", value, true
+	}
+	return "", "", false
+}
+
+func (svc *service) evaluatedValueAtPos(pathCtx *decoder.PathContext, modPath, filename string, pos hcl.Pos) (string, bool) {
+	expr, ok := valueExpressionAtPos(pathCtx.Files, pathCtx.ReferenceOrigins, filename, pos)
+	if !ok {
+		return "", false
+	}
+
+	var varsFiles map[string]*hcl.File
+	if svc.features.Variables != nil {
+		varsCtx, err := svc.features.Variables.PathContext(lang.Path{
+			Path:       modPath,
+			LanguageID: ilsp.Tfvars.String(),
+		})
+		if err == nil {
+			varsFiles = varsCtx.Files
+		}
+	}
+
+	evaluator, err := svc.features.Modules.Evaluator(modPath, varsFiles)
+	if err != nil {
+		return "", false
+	}
+	val, diags := evaluator.Evaluate(expr)
+	if diags.HasErrors() {
+		return "", false
+	}
+	return eval.FormatValue(val)
+}
+
+// valueExpressionAtPos returns the expression of the local value
+// declared at pos, or the reference to an input variable or a local
+// value at pos
+func valueExpressionAtPos(files map[string]*hcl.File, origins reference.Origins, filename string, pos hcl.Pos) (hcl.Expression, bool) {
+	f, ok := files[filename]
+	if !ok {
+		return nil, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, false
+	}
+
+	if traversal, ok := traversalAtPos(f, origins, filename, pos); ok {
+		if root := traversal.RootName(); root != "var" && root != "local" {
+			return nil, false
+		}
+		return &hclsyntax.ScopeTraversalExpr{
+			Traversal: traversal,
+			SrcRange:  traversal.SourceRange(),
+		}, true
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "locals" || !block.Range().ContainsPos(pos) {
+			continue
+		}
+		for _, attr := range block.Body.Attributes {
+			if attr.NameRange.ContainsPos(pos) {
+				return attr.Expr, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// stateValueAtPos returns the value from the state of the reference
+// to a resource or a data source at pos, or all instances of the
+// resource or data source block whose header is at pos
+func stateValueAtPos(pathCtx *decoder.PathContext, st *datadir.State, filename string, pos hcl.Pos) (string, bool) {
+	f, ok := pathCtx.Files[filename]
+	if !ok {
+		return "", false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return "", false
+	}
+
+	if traversal, ok := traversalAtPos(f, pathCtx.ReferenceOrigins, filename, pos); ok {
+		val, diags := traversal.TraverseAbs(&hcl.EvalContext{
+			Variables: st.Variables(),
+		})
+		if diags.HasErrors() {
+			return "", false
+		}
+		return eval.FormatValue(val)
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "resource" && block.Type != "data" || len(block.Labels) != 2 {
+			continue
+		}
+		header := hcl.RangeBetween(block.TypeRange, block.OpenBraceRange)
+		if !header.ContainsPos(pos) {
+			continue
+		}
+
+		address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+		if block.Type == "data" {
+			address = "data." + address
+		}
+		r, ok := st.Resources[address]
+		if !ok || len(r.Instances) == 0 {
+			return "", false
+		}
+
+		lines := make([]string, 0, len(r.Instances))
+		for _, instance := range r.Instances {
+			value, ok := eval.FormatValue(instance.Value)
+			if !ok {
+				return "", false
+			}
+			lines = append(lines, fmt.Sprintf("%s%s = %s", address, instanceKeyString(instance.Key), value))
+		}
+		return strings.Join(lines, "\n"), true
+	}
+
+	return "", false
+}
+
+// markSchemaSensitive returns a copy of the state with values marked
+// as sensitive wherever the provider schema declares them sensitive,
+// because the state itself does not necessarily record them as such
+func markSchemaSensitive(moduleSchema *schema.BodySchema, st *datadir.State) *datadir.State {
+	if moduleSchema == nil {
+		return st
+	}
+
+	marked := &datadir.State{
+		Resources: make(map[string]*datadir.StateResource, len(st.Resources)),
+	}
+	for address, r := range st.Resources {
+		blockType := "resource"
+		if r.Mode == tfjson.DataResourceMode {
+			blockType = "data"
+		}
+		body, ok := resourceBodySchema(moduleSchema, blockType, r.Type)
+		if !ok {
+			marked.Resources[address] = r
+			continue
+		}
+
+		mr := *r
+		mr.Instances = make([]datadir.StateInstance, 0, len(r.Instances))
+		for _, instance := range r.Instances {
+			mr.Instances = append(mr.Instances, datadir.StateInstance{
+				Key:   instance.Key,
+				Value: markSensitiveAttributes(instance.Value, body),
+			})
+		}
+		marked.Resources[address] = &mr
+	}
+	return marked
+}
+
+func resourceBodySchema(moduleSchema *schema.BodySchema, blockType, resourceType string) (*schema.BodySchema, bool) {
+	blockSchema, ok := moduleSchema.Blocks[blockType]
+	if !ok {
+		return nil, false
+	}
+	body, ok := blockSchema.DependentBody[schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{
+			{Index: 0, Value: resourceType},
+		},
+	})]
+	return body, ok && body != nil
+}
+
+// markSensitiveAttributes marks values of sensitive attributes
+// within the value of a block, including any nested blocks
+func markSensitiveAttributes(val cty.Value, body *schema.BodySchema) cty.Value {
+	if val.IsMarked() || val.IsNull() || !val.IsKnown() {
+		return val
+	}
+
+	ty := val.Type()
+	switch {
+	case ty.IsObjectType():
+		attrs := make(map[string]cty.Value, len(ty.AttributeTypes()))
+		for name, attrVal := range val.AsValueMap() {
+			if attrSchema, ok := body.Attributes[name]; ok && attrSchema.IsSensitive {
+				attrVal = attrVal.Mark(datadir.SensitiveMark)
+			} else if blockSchema, ok := body.Blocks[name]; ok && blockSchema.Body != nil {
+				attrVal = markSensitiveAttributes(attrVal, blockSchema.Body)
+			}
+			attrs[name] = attrVal
+		}
+		return cty.ObjectVal(attrs)
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		if val.LengthInt() == 0 {
+			return val
+		}
+		elems := make([]cty.Value, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			elems = append(elems, markSensitiveAttributes(elem, body))
+		}
+		switch {
+		case ty.IsListType():
+			return cty.ListVal(elems)
+		case ty.IsSetType():
+			return cty.SetVal(elems)
+		}
+		return cty.TupleVal(elems)
+	}
+	return val
+}
+
+// traversalAtPos returns the traversal of the reference at pos
+func traversalAtPos(f *hcl.File, origins reference.Origins, filename string, pos hcl.Pos) (hcl.Traversal, bool) {
+	for _, origin := range origins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok || localOrigin.Range.Filename != filename || !localOrigin.Range.ContainsPos(pos) {
+			continue
+		}
+		traversal, diags := hclsyntax.ParseTraversalAbs(localOrigin.Range.SliceBytes(f.Bytes), filename, localOrigin.Range.Start)
+		if diags.HasErrors() {
+			continue
+		}
+		return traversal, true
+	}
+	return nil, false
+}
+
+func instanceKeyString(key cty.Value) string {
+	if key == cty.NilVal {
+		return ""
+	}
+	if key.Type() == cty.Number {
+		return fmt.Sprintf("[%s]", key.AsBigFloat().Text('f', -1))
+	}
+	return fmt.Sprintf("[%q]", key.AsString())
+}
@@ -204,6 +204,9 @@ func getTelemetryProperties(out *settings.DecodedOptions) map[string]interface{}
 		"options.terraform.path":                          false,
 		"options.terraform.timeout":                       "",
 		"options.terraform.logFilePath":                   false,
+		"options.terraform.engine":                        "",
+		"options.registry.offline":                        false,
+		"options.registry.cacheDir":                       false,
 		"options.validation.earlyValidation":              false,
 		"root_uri":                                        "dir",
 		"lsVersion":                                       "",
@@ -220,6 +223,10 @@ func getTelemetryProperties(out *settings.DecodedOptions) map[string]interface{}
 	properties["options.terraform.path"] = len(out.Options.Terraform.Path) > 0
 	properties["options.terraform.timeout"] = out.Options.Terraform.Timeout
 	properties["options.terraform.logFilePath"] = len(out.Options.Terraform.LogFilePath) > 0
+	properties["options.terraform.engine"] = out.Options.Terraform.Engine
+	properties["options.registry.offline"] = out.Options.Registry.Offline
+	properties["options.registry.cacheDir"] = len(out.Options.Registry.CacheDir) > 0
+	properties["options.providerSchemas.directories"] = len(out.Options.ProviderSchemas.Directories) > 0
 	properties["options.validation.earlyValidation"] = out.Options.Validation.EnableEnhancedValidation
 
 	return properties
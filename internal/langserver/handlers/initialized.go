@@ -5,6 +5,7 @@ package handlers
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/creachadair/jrpc2"
 	"github.com/hashicorp/go-uuid"
@@ -42,6 +43,11 @@ func (svc *service) setupWatchedFiles(ctx context.Context, caps lsp.DidChangeWat
 			Kind:        kindFromEventType(wp.EventType),
 		}
 	}
+	for _, dir := range svc.providerSchemaDirs {
+		watchers = append(watchers, lsp.FileSystemWatcher{
+			GlobPattern: filepath.ToSlash(dir) + "/*.{json,json.gz}",
+		})
+	}
 
 	srv := jrpc2.ServerFromContext(ctx)
 	_, err = srv.Callback(ctx, "client/registerCapability", lsp.RegistrationParams{
@@ -0,0 +1,44 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+
+	lsctx "github.com/hashicorp/terraform-ls/internal/context"
+	"github.com/hashicorp/terraform-ls/internal/document"
+	"github.com/hashicorp/terraform-ls/internal/job"
+	"github.com/hashicorp/terraform-ls/internal/state"
+	op "github.com/hashicorp/terraform-ls/internal/terraform/module/operation"
+)
+
+// loadProviderSchemaFiles schedules (re)loading of provider schemas
+// from files of the given directory, as configured by the user
+func (svc *service) loadProviderSchemaFiles(ctx context.Context, dir string) (job.ID, error) {
+	// schemas are shared by all modules, not tied to any document
+	ctx = lsctx.WithDocumentContext(ctx, lsctx.Document{})
+
+	return svc.stateStore.JobStore.EnqueueJob(ctx, job.Job{
+		Dir: document.DirHandleFromPath(dir),
+		Func: func(ctx context.Context) error {
+			return state.LoadProviderSchemaFiles(ctx, dir, svc.stateStore.ProviderSchemas, svc.logger)
+		},
+		Type: op.OpTypeLoadProviderSchemaFiles.String(),
+	})
+}
+
+// providerSchemaDirForFile returns the configured provider schemas
+// directory containing the given file, if any
+func (svc *service) providerSchemaDirForFile(path string) (string, bool) {
+	if !state.IsProviderSchemaFile(filepath.Base(path)) {
+		return "", false
+	}
+	for _, dir := range svc.providerSchemaDirs {
+		if filepath.Dir(path) == filepath.Clean(dir) {
+			return dir, true
+		}
+	}
+	return "", false
+}
@@ -15,6 +15,7 @@ import (
 	rpch "github.com/creachadair/jrpc2/handler"
 	"github.com/hashicorp/hcl-lang/decoder"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/terraform-ls/internal/codelens"
 	lsctx "github.com/hashicorp/terraform-ls/internal/context"
 	idecoder "github.com/hashicorp/terraform-ls/internal/decoder"
 	"github.com/hashicorp/terraform-ls/internal/document"
@@ -40,6 +41,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/state"
 	"github.com/hashicorp/terraform-ls/internal/telemetry"
 	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/walker"
 	"go.opentelemetry.io/otel"
@@ -76,6 +78,7 @@ type service struct {
 	openDirWalker   *walker.Walker
 
 	fs             *filesystem.Filesystem
+	tfDiscovery    *discovery.Discovery
 	tfDiscoFunc    discovery.DiscoveryFunc
 	tfExecFactory  exec.ExecutorFactory
 	tfExecOpts     *exec.ExecutorOpts
@@ -94,6 +97,10 @@ type service struct {
 	additionalHandlers map[string]rpch.Func
 
 	singleFileMode bool
+
+	// providerSchemaDirs are directories of provider schema
+	// files supplied by the user, see settings.ProviderSchemas
+	providerSchemaDirs []string
 }
 
 var discardLogs = log.New(io.Discard, "", 0)
@@ -107,6 +114,7 @@ func NewSession(srvCtx context.Context) session.Session {
 		srvCtx:         srvCtx,
 		sessCtx:        sessCtx,
 		stopSession:    stopSession,
+		tfDiscovery:    d,
 		tfDiscoFunc:    d.LookPath,
 		tfExecFactory:  exec.NewExecutor,
 		telemetry:      &telemetry.NoopSender{},
@@ -289,6 +297,7 @@ func (svc *service) Assigner() (jrpc2.Assigner, error) {
 			}
 
 			ctx = ilsp.WithClientCapabilities(ctx, cc)
+			ctx = lsctx.WithCommandPrefix(ctx, &commandPrefix)
 
 			return handle(ctx, req, svc.TextDocumentCodeLens)
 		},
@@ -448,6 +457,14 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 		})
 	}
 
+	eng, err := engine.ParseEngine(cfgOpts.Terraform.Engine)
+	if err != nil {
+		return err
+	}
+	if svc.tfDiscovery != nil {
+		svc.tfDiscovery.Engine = eng
+	}
+
 	// The following is set via CLI flags, hence available in the server context
 	execOpts := &exec.ExecutorOpts{}
 	if len(cfgOpts.Terraform.Path) > 0 {
@@ -460,6 +477,52 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	}
 	svc.srvCtx = lsctx.WithTerraformExecPath(svc.srvCtx, execOpts.ExecPath)
 
+	// With both executables installed, terraform is found first
+	// and modules written for OpenTofu are run with tofu instead
+	if eng == engine.Auto && len(cfgOpts.Terraform.Path) == 0 && svc.tfDiscovery != nil &&
+		engine.FromExecPath(execOpts.ExecPath) == engine.Terraform {
+		path, err := svc.tfDiscovery.LookPathForEngine(engine.OpenTofu)
+		if err == nil {
+			execOpts.OpenTofuExecPath = path
+		}
+	}
+
+	// Without explicit configuration we only switch the whole session
+	// to OpenTofu if that is the only executable we could find.
+	// Otherwise the engine is detected per module based on *.tofu files.
+	if eng == engine.Auto && engine.FromExecPath(execOpts.ExecPath) == engine.OpenTofu {
+		eng = engine.OpenTofu
+	}
+	if eng == engine.OpenTofu {
+		svc.registryClient.BaseURL = registry.OpenTofuBaseURL
+	}
+
+	// Credentials enable access to private registries,
+	// such as HCP Terraform and Terraform Enterprise
+	creds, err := registry.LoadCLICredentials()
+	if err != nil {
+		svc.logger.Printf("failed to load registry credentials: %s", err)
+	}
+	if creds != nil {
+		svc.registryClient.Credentials = creds
+	}
+
+	cacheDir := cfgOpts.Registry.CacheDir
+	if cacheDir == "" {
+		cacheDir, err = registry.DefaultCacheDir()
+		if err != nil {
+			svc.logger.Printf("registry metadata will not be cached: %s", err)
+		}
+	}
+	if cacheDir != "" {
+		svc.registryClient.Cache = registry.NewDiskCache(cacheDir)
+	}
+	svc.registryClient.Offline = cfgOpts.Registry.Offline
+	if svc.registryClient.Offline {
+		svc.logger.Printf("registry offline mode enabled, using cached metadata only")
+	}
+	svc.logger.Printf("using engine: %s", eng)
+
 	if len(cfgOpts.Terraform.LogFilePath) > 0 {
 		execOpts.ExecLogPath = cfgOpts.Terraform.LogFilePath
 	}
@@ -488,6 +551,7 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	}
 
 	svc.stateStore.SetLogger(svc.logger)
+	svc.registryClient.Services = svc.stateStore.RegistryModules
 
 	svc.lowPrioIndexer = scheduler.NewScheduler(svc.stateStore.JobStore, 1, job.LowPriority)
 	svc.lowPrioIndexer.SetLogger(svc.logger)
@@ -519,6 +583,14 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 	svc.closedDirWalker.Collector = svc.walkerCollector
 	svc.openDirWalker.SetLogger(svc.logger)
 
+	svc.providerSchemaDirs = cfgOpts.ProviderSchemas.Directories
+	for _, dir := range svc.providerSchemaDirs {
+		_, err = svc.loadProviderSchemaFiles(svc.sessCtx, dir)
+		if err != nil {
+			svc.logger.Printf("failed to schedule loading of provider schemas from %q: %s", dir, err)
+		}
+	}
+
 	if svc.features == nil {
 		rootModulesFeature, err := frootmodules.NewRootModulesFeature(svc.eventBus, svc.stateStore, svc.fs,
 			svc.tfExecFactory)
@@ -544,7 +616,8 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 		variablesFeature.SetLogger(svc.logger)
 		variablesFeature.Start(svc.sessCtx)
 
-		stacksFeature, err := stacks.NewStacksFeature(svc.eventBus, svc.stateStore, svc.fs, modulesFeature, rootModulesFeature)
+		stacksFeature, err := stacks.NewStacksFeature(svc.eventBus, svc.stateStore, svc.fs, modulesFeature, rootModulesFeature,
+			svc.registryClient)
 		if err != nil {
 			return err
 		}
@@ -591,6 +664,12 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 		}
 	}
 
+	svc.features.Modules.SetEngine(eng)
+	execOpts.ModuleEngine = svc.features.Modules.ModuleEngine
+	if svc.features.Tests != nil {
+		svc.features.Tests.SetEngine(eng)
+	}
+
 	svc.decoder = decoder.NewDecoder(&idecoder.GlobalPathReader{
 		PathReaderMap: idecoder.PathReaderMap{
 			"terraform":            svc.features.Modules,
@@ -605,6 +684,9 @@ func (svc *service) configureSessionDependencies(ctx context.Context, cfgOpts *s
 		},
 	})
 	decoderContext := idecoder.DecoderContext(ctx)
+	decoderContext.CodeLenses = append(decoderContext.CodeLenses, codelens.StateInstances(svc.features.RootModules))
+	decoderContext.CodeLenses = append(decoderContext.CodeLenses, codelens.PlanChanges(svc.features.RootModules))
+	decoderContext.CodeLenses = append(decoderContext.CodeLenses, codelens.ModuleUpgrades(svc.features.Modules))
 	svc.features.Modules.AppendCompletionHooks(svc.srvCtx, decoderContext)
 	svc.decoder.SetContext(decoderContext)
 
@@ -5,6 +5,7 @@ package lsp
 
 import (
 	"sort"
+	"strings"
 
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
@@ -12,6 +13,34 @@ import (
 const (
 	// SourceFormatAllTerraform is a Terraform specific format code action.
 	SourceFormatAllTerraform = "source.formatAll.terraform"
+
+	// RefactorRewriteImportTerraform generates import and resource blocks
+	// for resources found by a list block of a query file.
+	RefactorRewriteImportTerraform = "refactor.rewrite.import.terraform"
+
+	// RefactorExtractModuleTerraform moves selected resource and data
+	// blocks into a new local module.
+	RefactorExtractModuleTerraform = "refactor.extract.module.terraform"
+
+	// RefactorExtractLocalTerraform turns the selected expression
+	// into a local value.
+	RefactorExtractLocalTerraform = "refactor.extract.local.terraform"
+
+	// RefactorExtractVariableTerraform turns the selected literal
+	// into an input variable with the literal as default.
+	RefactorExtractVariableTerraform = "refactor.extract.variable.terraform"
+
+	// RefactorInlineLocalTerraform replaces all references
+	// to a local value with its expression.
+	RefactorInlineLocalTerraform = "refactor.inline.local.terraform"
+
+	// RefactorRewriteForEachTerraform converts count of a resource
+	// or module block into for_each.
+	RefactorRewriteForEachTerraform = "refactor.rewrite.forEach.terraform"
+
+	// RefactorRewriteModuleVersionTerraform bumps the version constraint
+	// of a registry module call to a newer version.
+	RefactorRewriteModuleVersionTerraform = "refactor.rewrite.moduleVersion.terraform"
 )
 
 type CodeActions map[lsp.CodeActionKind]bool
@@ -34,7 +63,14 @@ var (
 	// A user should be able to set `source.formatAll` to true, and source.formatAll.terraform to false to allow all
 	// files to be formatted, but not terraform files (or vice versa).
 	SupportedCodeActions = CodeActions{
-		SourceFormatAllTerraform: true,
+		SourceFormatAllTerraform:              true,
+		RefactorRewriteImportTerraform:        true,
+		RefactorExtractModuleTerraform:        true,
+		RefactorExtractLocalTerraform:         true,
+		RefactorExtractVariableTerraform:      true,
+		RefactorInlineLocalTerraform:          true,
+		RefactorRewriteForEachTerraform:       true,
+		RefactorRewriteModuleVersionTerraform: true,
 	}
 )
 
@@ -50,12 +86,30 @@ func (c CodeActions) AsSlice() []lsp.CodeActionKind {
 	return s
 }
 
+// Only returns the actions of the requested kinds, including
+// any more specific kinds, e.g. refactor.rewrite for refactor
 func (ca CodeActions) Only(only []lsp.CodeActionKind) CodeActions {
 	wanted := make(CodeActions, 0)
 
 	for _, kind := range only {
-		if v, ok := ca[kind]; ok {
-			wanted[kind] = v
+		for action, v := range ca {
+			if action == kind || (kind != "" && strings.HasPrefix(string(action), string(kind)+".")) {
+				wanted[action] = v
+			}
+		}
+	}
+
+	return wanted
+}
+
+// WithoutSource returns the actions which are not source actions,
+// i.e. those which can be offered without the client asking for them
+func (ca CodeActions) WithoutSource() CodeActions {
+	wanted := make(CodeActions, 0)
+
+	for action, v := range ca {
+		if action != lsp.Source && !strings.HasPrefix(string(action), string(lsp.Source)+".") {
+			wanted[action] = v
 		}
 	}
 
@@ -8,6 +8,13 @@ import (
 	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
 
+// informationalDiagnostic is implemented by extra information
+// of diagnostics which are reported with the information severity,
+// since HCL diagnostics can only be errors or warnings
+type informationalDiagnostic interface {
+	DiagnosticInformational() bool
+}
+
 func HCLSeverityToLSP(severity hcl.DiagnosticSeverity) lsp.DiagnosticSeverity {
 	var sev lsp.DiagnosticSeverity
 	switch severity {
@@ -33,9 +40,13 @@ func HCLDiagsToLSP(hclDiags hcl.Diagnostics, source string) []lsp.Diagnostic {
 		if hclDiag.Subject != nil {
 			rnge = HCLRangeToLSP(*hclDiag.Subject)
 		}
+		severity := HCLSeverityToLSP(hclDiag.Severity)
+		if extra, ok := hcl.DiagnosticExtra[informationalDiagnostic](hclDiag); ok && extra.DiagnosticInformational() {
+			severity = lsp.SeverityInformation
+		}
 		diags = append(diags, lsp.Diagnostic{
 			Range:    rnge,
-			Severity: HCLSeverityToLSP(hclDiag.Severity),
+			Severity: severity,
 			Source:   source,
 			Message:  msg,
 		})
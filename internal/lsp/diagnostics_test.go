@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
+	lsp "github.com/hashicorp/terraform-ls/internal/protocol"
 )
 
 func TestHCLDiagsToLSP_NeverReturnsNil(t *testing.T) {
@@ -29,3 +30,30 @@ func TestHCLDiagsToLSP_NeverReturnsNil(t *testing.T) {
 		t.Fatal("diags should not be nil")
 	}
 }
+
+type testInformational struct{}
+
+func (testInformational) DiagnosticInformational() bool {
+	return true
+}
+
+func TestHCLDiagsToLSP_informational(t *testing.T) {
+	diags := HCLDiagsToLSP(hcl.Diagnostics{
+		{
+			Severity: hcl.DiagWarning,
+			Summary:  "informational",
+			Extra:    testInformational{},
+		},
+		{
+			Severity: hcl.DiagWarning,
+			Summary:  "warning",
+		},
+	}, "source")
+
+	if diags[0].Severity != lsp.SeverityInformation {
+		t.Fatalf("expected information severity, %v given", diags[0].Severity)
+	}
+	if diags[1].Severity != lsp.SeverityWarning {
+		t.Fatalf("expected warning severity, %v given", diags[1].Severity)
+	}
+}
@@ -17,6 +17,10 @@ const (
 	Search     LanguageID = "terraform-search"
 	Policy     LanguageID = "terraform-policy"
 	PolicyTest LanguageID = "terraform-policytest"
+
+	// OpenTofu is the language ID some clients use for *.tofu files,
+	// which we treat the same way as Terraform
+	OpenTofu LanguageID = "opentofu"
 )
 
 func (l LanguageID) String() string {
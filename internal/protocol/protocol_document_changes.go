@@ -8,10 +8,16 @@ import (
 	"fmt"
 )
 
-// DocumentChanges is a union of a file edit and directory rename operations
-// for package renaming feature. At most one field of this struct is non-nil.
+// NullVersion is the version of an OptionalVersionedTextDocumentIdentifier
+// which is sent as null, i.e. the document is not open in the client
+// and its content on disk is the truth
+const NullVersion int32 = -1
+
+// DocumentChanges is a union of a file edit, file creation and directory
+// rename operations. At most one field of this struct is non-nil.
 type DocumentChanges struct {
 	TextDocumentEdit *TextDocumentEdit
+	CreateFile       *CreateFile
 	RenameFile       *RenameFile
 }
 
@@ -22,9 +28,21 @@ func (d *DocumentChanges) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if _, ok := m["textDocument"]; ok {
+	if textDocument, ok := m["textDocument"]; ok {
 		d.TextDocumentEdit = new(TextDocumentEdit)
-		return json.Unmarshal(data, d.TextDocumentEdit)
+		err := json.Unmarshal(data, d.TextDocumentEdit)
+		if err != nil {
+			return err
+		}
+		if td, ok := textDocument.(map[string]interface{}); ok && td["version"] == nil {
+			d.TextDocumentEdit.TextDocument.Version = NullVersion
+		}
+		return nil
+	}
+
+	if kind, ok := m["kind"]; ok && kind == "create" {
+		d.CreateFile = new(CreateFile)
+		return json.Unmarshal(data, d.CreateFile)
 	}
 
 	d.RenameFile = new(RenameFile)
@@ -33,9 +51,31 @@ func (d *DocumentChanges) UnmarshalJSON(data []byte) error {
 
 func (d *DocumentChanges) MarshalJSON() ([]byte, error) {
 	if d.TextDocumentEdit != nil {
+		if d.TextDocumentEdit.TextDocument.Version == NullVersion {
+			return json.Marshal(unversionedTextDocumentEdit{
+				TextDocument: unversionedTextDocumentIdentifier{
+					URI: d.TextDocumentEdit.TextDocument.URI,
+				},
+				Edits: d.TextDocumentEdit.Edits,
+			})
+		}
 		return json.Marshal(d.TextDocumentEdit)
+	} else if d.CreateFile != nil {
+		return json.Marshal(d.CreateFile)
 	} else if d.RenameFile != nil {
 		return json.Marshal(d.RenameFile)
 	}
 	return nil, fmt.Errorf("Empty DocumentChanges union value")
 }
+
+// unversionedTextDocumentEdit is a TextDocumentEdit with null version,
+// which OptionalVersionedTextDocumentIdentifier cannot represent
+type unversionedTextDocumentEdit struct {
+	TextDocument unversionedTextDocumentIdentifier `json:"textDocument"`
+	Edits        []TextEdit                        `json:"edits"`
+}
+
+type unversionedTextDocumentIdentifier struct {
+	Version *int32      `json:"version"`
+	URI     DocumentURI `json:"uri"`
+}
@@ -0,0 +1,322 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+)
+
+const (
+	// moduleVersionsTTL reflects that new versions of modules
+	// get published at any time
+	moduleVersionsTTL = 1 * time.Hour
+	// moduleDataTTL is long, as data of a particular
+	// module version practically never changes
+	moduleDataTTL = 30 * 24 * time.Hour
+	// providerVersionsTTL reflects that new versions of providers
+	// get published at any time
+	providerVersionsTTL = 1 * time.Hour
+
+	// unreachableBackoff represents how long we avoid any network
+	// requests to a host, after a request to it failed
+	unreachableBackoff = 1 * time.Minute
+)
+
+// ErrOffline is returned when data is not available in the cache
+// and network requests are not possible in offline mode
+// (or the registry was recently unreachable).
+var ErrOffline = errors.New("registry data not cached and network access is disabled")
+
+// DiskCache persists registry responses on disk,
+// such that they remain available across sessions
+// and without network access.
+type DiskCache struct {
+	dir string
+	now func() time.Time
+}
+
+type cacheEntry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// NewDiskCache returns cache storing data within the given directory
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{
+		dir: dir,
+		now: time.Now,
+	}
+}
+
+// DefaultCacheDir returns the default location of the cache
+// within the user's cache directory
+func DefaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "terraform-ls", "registry"), nil
+}
+
+// Get decodes a cached entry under the given key into v.
+// fresh reports whether the entry is younger than ttl.
+func (dc *DiskCache) Get(key string, ttl time.Duration, v interface{}) (fresh bool, ok bool) {
+	if dc == nil {
+		return false, false
+	}
+
+	b, err := os.ReadFile(dc.path(key))
+	if err != nil {
+		return false, false
+	}
+
+	var entry cacheEntry
+	err = json.Unmarshal(b, &entry)
+	if err != nil {
+		return false, false
+	}
+	err = json.Unmarshal(entry.Data, v)
+	if err != nil {
+		return false, false
+	}
+
+	return dc.now().Sub(entry.StoredAt) < ttl, true
+}
+
+// Put stores v under the given key
+func (dc *DiskCache) Put(key string, v interface{}) error {
+	if dc == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(cacheEntry{
+		StoredAt: dc.now(),
+		Data:     data,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dc.dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	// write via a temporary file, so that concurrent readers
+	// (e.g. from other language server instances) never see
+	// a partially written entry
+	tmpFile, err := os.CreateTemp(dc.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmpFile.Write(b)
+	if err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	err = tmpFile.Close()
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), dc.path(key))
+}
+
+func (dc *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dc.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// registryOrigin returns an identifier of the registry serving
+// the given host, which also distinguishes the public registries
+// of Terraform and OpenTofu
+func (c Client) registryOrigin(host svchost.Hostname) string {
+	if host == "" || isDefaultHost(host) {
+		return c.BaseURL
+	}
+	return host.String()
+}
+
+// cachedFetch returns fresh data from the disk cache if available
+// and calls fetch otherwise. Stale data is returned right away and
+// refreshed in the background, and it's the only data returned when
+// in offline mode, or when the registry is (recently) unreachable,
+// such that we never block on network requests if there is any data.
+func cachedFetch[T any](ctx context.Context, c Client, host svchost.Hostname, key string, ttl time.Duration, fetch func(context.Context) (T, error)) (T, error) {
+	origin := c.registryOrigin(host)
+	key = origin + "|" + key
+
+	var cached T
+	fresh, found := c.Cache.Get(key, ttl, &cached)
+	if found && fresh {
+		return cached, nil
+	}
+
+	if c.Offline || !c.reachability.isReachable(origin) {
+		if found {
+			return cached, nil
+		}
+		var zero T
+		return zero, ErrOffline
+	}
+
+	if found {
+		// the refresh outlives the request it originates from
+		refreshCtx := context.WithoutCancel(ctx)
+		c.refreshes.start(key, func() {
+			_, _ = fetchAndCache(refreshCtx, c, origin, key, fetch)
+		})
+		return cached, nil
+	}
+
+	return fetchAndCache(ctx, c, origin, key, fetch)
+}
+
+func fetchAndCache[T any](ctx context.Context, c Client, origin, key string, fetch func(context.Context) (T, error)) (T, error) {
+	data, err := fetch(ctx)
+	if err != nil {
+		clientErr := ClientError{}
+		if errors.As(err, &clientErr) {
+			// the registry is reachable, it just doesn't like the request
+			return data, err
+		}
+
+		if isConnectionError(err) {
+			c.reachability.markFailed(origin)
+		}
+		return data, err
+	}
+	c.reachability.markReachable(origin)
+
+	// failing to cache isn't critical, we can still fetch again later
+	_ = c.Cache.Put(key, data)
+
+	return data, nil
+}
+
+// refreshes keeps track of stale cache entries being refreshed
+// in the background, so that each is only refreshed once at a time
+type refreshes struct {
+	mu      sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+}
+
+func newRefreshes() *refreshes {
+	return &refreshes{
+		running: make(map[string]bool, 0),
+	}
+}
+
+func (r *refreshes) start(key string, refresh func()) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running[key] {
+		return
+	}
+	r.running[key] = true
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		refresh()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.running, key)
+	}()
+}
+
+// wait blocks until all running refreshes are finished
+func (r *refreshes) wait() {
+	if r == nil {
+		return
+	}
+	r.wg.Wait()
+}
+
+// isConnectionError returns true if the host could not be reached,
+// i.e. it could not be resolved, connected to or did not respond in time
+func isConnectionError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// reachability keeps track of recent network failures per host,
+// so that we can avoid repeatedly waiting for timeouts
+type reachability struct {
+	mu       sync.RWMutex
+	failedAt map[string]time.Time
+	now      func() time.Time
+}
+
+func newReachability() *reachability {
+	return &reachability{
+		failedAt: make(map[string]time.Time, 0),
+		now:      time.Now,
+	}
+}
+
+func (r *reachability) isReachable(host string) bool {
+	if r == nil {
+		return true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	failedAt, ok := r.failedAt[host]
+	if !ok {
+		return true
+	}
+	return r.now().Sub(failedAt) > unreachableBackoff
+}
+
+func (r *reachability) markFailed(host string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failedAt[host] = r.now()
+}
+
+func (r *reachability) markReachable(host string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failedAt, host)
+}
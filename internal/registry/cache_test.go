@@ -0,0 +1,267 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+func TestGetModuleData_cached(t *testing.T) {
+	ctx := context.Background()
+	addr, err := tfaddr.ParseModuleSource("puppetlabs/deployment/ec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cons := version.MustConstraints(version.NewConstraint("0.0.8"))
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.RequestURI == "/v1/modules/puppetlabs/deployment/ec/versions" {
+			w.Write([]byte(moduleVersionsMockResponse))
+			return
+		}
+		if r.RequestURI == "/v1/modules/puppetlabs/deployment/ec/0.0.8" {
+			w.Write([]byte(moduleDataMockResponse))
+			return
+		}
+		http.Error(w, fmt.Sprintf("unexpected request: %q", r.RequestURI), 400)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.BaseURL = srv.URL
+	client.Cache = NewDiskCache(t.TempDir())
+
+	_, err = client.GetModuleData(ctx, addr, cons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, %d given", requests)
+	}
+
+	data, err := client.GetModuleData(ctx, addr, cons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected data to be served from cache, %d requests made", requests)
+	}
+	if data.Version != "0.0.8" {
+		t.Fatalf("unexpected version: %q", data.Version)
+	}
+
+	// a new session (i.e. client) should reuse data persisted on disk
+	offlineClient := NewClient()
+	offlineClient.BaseURL = srv.URL
+	offlineClient.Cache = client.Cache
+	offlineClient.Offline = true
+
+	data, err = offlineClient.GetModuleData(ctx, addr, cons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Version != "0.0.8" {
+		t.Fatalf("unexpected version: %q", data.Version)
+	}
+	if requests != 2 {
+		t.Fatalf("expected no requests in offline mode, %d requests made", requests)
+	}
+}
+
+func TestGetModuleVersions_offlineWithoutCache(t *testing.T) {
+	ctx := context.Background()
+	addr, err := tfaddr.ParseModuleSource("puppetlabs/deployment/ec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request in offline mode: %q", r.RequestURI)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.BaseURL = srv.URL
+	client.Cache = NewDiskCache(t.TempDir())
+	client.Offline = true
+
+	_, err = client.GetModuleVersions(ctx, addr)
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected offline error, %#v given", err)
+	}
+}
+
+func TestGetModuleVersions_staleCacheWhenUnreachable(t *testing.T) {
+	ctx := context.Background()
+	addr, err := tfaddr.ParseModuleSource("puppetlabs/deployment/ec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(moduleVersionsMockResponse))
+	}))
+	baseURL := srv.URL
+
+	cacheDir := t.TempDir()
+	client := NewClient()
+	client.BaseURL = baseURL
+	client.Cache = NewDiskCache(cacheDir)
+
+	expectedVersions, err := client.GetModuleVersions(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// make the cached entry stale and the registry unreachable
+	srv.Close()
+	client = NewClient()
+	client.BaseURL = baseURL
+	client.Cache = NewDiskCache(cacheDir)
+	client.Cache.now = func() time.Time {
+		return time.Now().Add(2 * moduleVersionsTTL)
+	}
+
+	versions, err := client.GetModuleVersions(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != len(expectedVersions) {
+		t.Fatalf("expected %d stale versions, %d given", len(expectedVersions), len(versions))
+	}
+	client.refreshes.wait()
+	if client.reachability.isReachable(baseURL) {
+		t.Fatal("expected registry to be marked as unreachable")
+	}
+}
+
+func TestGetModuleVersions_timeoutMarksUnreachable(t *testing.T) {
+	ctx := context.Background()
+	addr, err := tfaddr.ParseModuleSource("puppetlabs/deployment/ec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.BaseURL = srv.URL
+	client.Cache = NewDiskCache(t.TempDir())
+	client.httpClient.Timeout = 50 * time.Millisecond
+
+	_, err = client.GetModuleVersions(ctx, addr)
+	if err == nil {
+		t.Fatal("expected request to time out")
+	}
+	if client.reachability.isReachable(srv.URL) {
+		t.Fatal("expected registry to be marked as unreachable")
+	}
+}
+
+func TestGetModuleVersions_staleCacheRefreshedInBackground(t *testing.T) {
+	ctx := context.Background()
+	addr, err := tfaddr.ParseModuleSource("puppetlabs/deployment/ec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests int32
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 1 {
+			// the registry is slow to respond to any refresh
+			<-unblock
+		}
+		w.Write([]byte(moduleVersionsMockResponse))
+	}))
+	t.Cleanup(srv.Close)
+
+	cacheDir := t.TempDir()
+	client := NewClient()
+	client.BaseURL = srv.URL
+	client.Cache = NewDiskCache(cacheDir)
+
+	expectedVersions, err := client.GetModuleVersions(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Cache.now = func() time.Time {
+		return time.Now().Add(2 * moduleVersionsTTL)
+	}
+	for i := 0; i < 2; i++ {
+		versions, err := client.GetModuleVersions(ctx, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(versions) != len(expectedVersions) {
+			t.Fatalf("expected %d stale versions, %d given", len(expectedVersions), len(versions))
+		}
+	}
+
+	close(unblock)
+	client.refreshes.wait()
+	if requests != 2 {
+		t.Fatalf("expected a single refresh request, %d requests given", requests)
+	}
+
+	// the refreshed entry is fresh again
+	client.Cache.now = time.Now
+	var cached ModuleVersionsResponse
+	fresh, ok := client.Cache.Get(srv.URL+"|module-versions:"+addr.Package.ForRegistryProtocol(), moduleVersionsTTL, &cached)
+	if !ok || !fresh {
+		t.Fatal("expected refreshed entry to be cached")
+	}
+}
+
+func TestGetModuleVersions_clientErrorNotCached(t *testing.T) {
+	ctx := context.Background()
+	addr, err := tfaddr.ParseModuleSource("puppetlabs/deployment/ec")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		http.Error(w, "not found", 404)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient()
+	client.BaseURL = srv.URL
+	client.Cache = NewDiskCache(t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		_, err = client.GetModuleVersions(ctx, addr)
+		clientErr := ClientError{}
+		if !errors.As(err, &clientErr) {
+			t.Fatalf("expected client error, %#v given", err)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, %d given", requests)
+	}
+	if !client.reachability.isReachable(srv.URL) {
+		t.Fatal("expected registry to remain reachable")
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	svchost "github.com/hashicorp/terraform-svchost"
+	"github.com/mitchellh/go-homedir"
+)
+
+const tokenEnvVarPrefix = "TF_TOKEN_"
+
+// CredentialsSource provides API tokens for registry hosts
+type CredentialsSource interface {
+	TokenForHost(host svchost.Hostname) (string, bool)
+}
+
+// CLICredentials represents API tokens for registry hosts configured
+// in the same way as for Terraform CLI, i.e. via TF_TOKEN_* environment
+// variables, credentials blocks in the CLI config file
+// and the credentials file managed by "terraform login".
+type CLICredentials struct {
+	tokens  map[svchost.Hostname]string
+	environ func() []string
+}
+
+var _ CredentialsSource = &CLICredentials{}
+
+// LoadCLICredentials reads tokens from the default locations
+// of the CLI config file and the credentials file.
+//
+// Environment variables are looked up lazily, i.e. on every call
+// of TokenForHost, such that they always take precedence.
+func LoadCLICredentials() (*CLICredentials, error) {
+	configDir, err := cliConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	cliConfigPath := os.Getenv("TF_CLI_CONFIG_FILE")
+	if cliConfigPath == "" {
+		cliConfigPath, err = defaultCLIConfigPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return loadCLICredentials(cliConfigPath,
+		filepath.Join(configDir, "credentials.tfrc.json"), os.Environ)
+}
+
+func loadCLICredentials(cliConfigPath, credsFilePath string, environ func() []string) (*CLICredentials, error) {
+	creds := &CLICredentials{
+		tokens:  make(map[svchost.Hostname]string, 0),
+		environ: environ,
+	}
+
+	var errs []error
+
+	// The credentials file is written by "terraform login"
+	// and any explicit configuration in the CLI config file
+	// takes precedence over it.
+	fileTokens, err := readCredentialsFile(credsFilePath)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for host, token := range fileTokens {
+		creds.tokens[host] = token
+	}
+
+	cfgTokens, err := readCLIConfigCredentials(cliConfigPath)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for host, token := range cfgTokens {
+		creds.tokens[host] = token
+	}
+
+	return creds, errors.Join(errs...)
+}
+
+// TokenForHost returns the token for the given hostname, if any
+func (c *CLICredentials) TokenForHost(host svchost.Hostname) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	if c.environ != nil {
+		if token, ok := tokenFromEnv(c.environ(), host); ok {
+			return token, true
+		}
+	}
+
+	token, ok := c.tokens[host]
+	return token, ok
+}
+
+// tokenFromEnv looks up the token for the given host within TF_TOKEN_*
+// environment variables, where dots in the hostname are encoded
+// as underscores and dashes as double underscores,
+// e.g. TF_TOKEN_app_example__corp_com for app.example-corp.com
+func tokenFromEnv(environ []string, host svchost.Hostname) (string, bool) {
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, tokenEnvVarPrefix) || value == "" {
+			continue
+		}
+
+		rawHost := strings.TrimPrefix(name, tokenEnvVarPrefix)
+		rawHost = strings.ReplaceAll(rawHost, "__", "-")
+		rawHost = strings.ReplaceAll(rawHost, "_", ".")
+
+		envHost, err := svchost.ForComparison(rawHost)
+		if err != nil {
+			continue
+		}
+		if envHost == host {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+type credentialsFile struct {
+	Credentials map[string]struct {
+		Token string `json:"token"`
+	} `json:"credentials"`
+}
+
+func readCredentialsFile(path string) (map[svchost.Hostname]string, error) {
+	tokens := make(map[svchost.Hostname]string, 0)
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return tokens, nil
+		}
+		return tokens, err
+	}
+
+	var cf credentialsFile
+	err = json.Unmarshal(b, &cf)
+	if err != nil {
+		return tokens, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for rawHost, entry := range cf.Credentials {
+		host, err := svchost.ForComparison(rawHost)
+		if err != nil || entry.Token == "" {
+			continue
+		}
+		tokens[host] = entry.Token
+	}
+
+	return tokens, nil
+}
+
+type cliConfig struct {
+	Credentials []cliConfigCredentials `hcl:"credentials,block"`
+	Remain      hcl.Body               `hcl:",remain"`
+}
+
+type cliConfigCredentials struct {
+	Host   string   `hcl:"host,label"`
+	Token  string   `hcl:"token,optional"`
+	Remain hcl.Body `hcl:",remain"`
+}
+
+func readCLIConfigCredentials(path string) (map[svchost.Hostname]string, error) {
+	tokens := make(map[svchost.Hostname]string, 0)
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return tokens, nil
+		}
+		return tokens, err
+	}
+
+	p := hclparse.NewParser()
+	var f *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(path, ".json") {
+		f, diags = p.ParseJSON(src, path)
+	} else {
+		f, diags = p.ParseHCL(src, path)
+	}
+	if diags.HasErrors() {
+		return tokens, diags
+	}
+
+	var cfg cliConfig
+	diags = gohcl.DecodeBody(f.Body, nil, &cfg)
+	if diags.HasErrors() {
+		return tokens, diags
+	}
+
+	for _, c := range cfg.Credentials {
+		host, err := svchost.ForComparison(c.Host)
+		if err != nil || c.Token == "" {
+			continue
+		}
+		tokens[host] = c.Token
+	}
+
+	return tokens, nil
+}
+
+func cliConfigDir() (string, error) {
+	if runtime.GOOS == "windows" {
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			return "", errors.New("APPDATA environment variable not set")
+		}
+		return filepath.Join(appData, "terraform.d"), nil
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".terraform.d"), nil
+}
+
+func defaultCLIConfigPath() (string, error) {
+	if runtime.GOOS == "windows" {
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			return "", errors.New("APPDATA environment variable not set")
+		}
+		return filepath.Join(appData, "terraform.rc"), nil
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".terraformrc"), nil
+}
@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+)
+
+func TestCLICredentials(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cliConfigPath := filepath.Join(tmpDir, ".terraformrc")
+	err := os.WriteFile(cliConfigPath, []byte(`
+plugin_cache_dir = "$HOME/.terraform.d/plugin-cache"
+
+credentials "app.example.com" {
+  token = "from-cli-config"
+}
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credsFilePath := filepath.Join(tmpDir, "credentials.tfrc.json")
+	err = os.WriteFile(credsFilePath, []byte(`{
+  "credentials": {
+    "app.example.com": {"token": "from-credentials-file"},
+    "tfe.example-corp.com": {"token": "from-credentials-file"}
+  }
+}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	environ := func() []string {
+		return []string{
+			"HOME=/home/user",
+			"TF_TOKEN_tfe_example__corp_com=from-env",
+			"TF_TOKEN_empty_example_com=",
+		}
+	}
+
+	creds, err := loadCLICredentials(cliConfigPath, credsFilePath, environ)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		host          svchost.Hostname
+		expectedToken string
+		expectedFound bool
+	}{
+		{"app.example.com", "from-cli-config", true},
+		{"tfe.example-corp.com", "from-env", true},
+		{"empty.example.com", "", false},
+		{"registry.terraform.io", "", false},
+	}
+
+	for _, tc := range testCases {
+		token, ok := creds.TokenForHost(tc.host)
+		if ok != tc.expectedFound {
+			t.Fatalf("%s: expected found: %t, given: %t", tc.host, tc.expectedFound, ok)
+		}
+		if token != tc.expectedToken {
+			t.Fatalf("%s: expected token %q, given: %q", tc.host, tc.expectedToken, token)
+		}
+	}
+}
+
+func TestCLICredentials_missingFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	creds, err := loadCLICredentials(filepath.Join(tmpDir, "missing.rc"),
+		filepath.Join(tmpDir, "missing.json"), func() []string { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := creds.TokenForHost("app.terraform.io")
+	if ok {
+		t.Fatal("expected no token to be found")
+	}
+}
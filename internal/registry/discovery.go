@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	svchost "github.com/hashicorp/terraform-svchost"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	discoveryPath    = "/.well-known/terraform.json"
+	modulesServiceID = "modules.v1"
+)
+
+// HostServices represents the result of service discovery
+// for a single registry host
+type HostServices struct {
+	Host       svchost.Hostname
+	ModulesURL *url.URL
+	// Err is the error reported by the host during discovery
+	Err error
+}
+
+// ServiceStore keeps results of service discovery per hostname,
+// so we only ever query each host once per session
+type ServiceStore interface {
+	HostServices(host svchost.Hostname) (*HostServices, bool)
+	CacheHostServices(hs *HostServices) error
+}
+
+// isDefaultHost returns true if the given host is served by BaseURL,
+// i.e. it's the public registry, which does not require discovery
+func isDefaultHost(host svchost.Hostname) bool {
+	return host == tfaddr.DefaultModuleRegistryHost
+}
+
+// modulesBaseURL returns the URL of the module registry API
+// for the given host, e.g. https://registry.terraform.io/v1/modules/
+func (c Client) modulesBaseURL(ctx context.Context, host svchost.Hostname) (string, error) {
+	if host == "" || isDefaultHost(host) {
+		return c.BaseURL + "/v1/modules/", nil
+	}
+
+	if c.Services == nil {
+		hs := c.discoverServices(ctx, host)
+		if hs.Err != nil {
+			return "", hs.Err
+		}
+		return hs.ModulesURL.String(), nil
+	}
+
+	hs, ok := c.Services.HostServices(host)
+	if !ok {
+		hs = c.discoverServices(ctx, host)
+
+		// Network errors may be transient, so we only cache
+		// successful results and errors reported by the host
+		clientErr := ClientError{}
+		if hs.Err == nil || errors.As(hs.Err, &clientErr) {
+			err := c.Services.CacheHostServices(hs)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	if hs.Err != nil {
+		return "", hs.Err
+	}
+
+	return hs.ModulesURL.String(), nil
+}
+
+// discoverServices implements the Terraform remote service discovery
+// protocol, see https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func (c Client) discoverServices(ctx context.Context, host svchost.Hostname) *HostServices {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "registry:discoverServices")
+	defer span.End()
+
+	ctx = httptrace.WithClientTrace(ctx, otelhttptrace.NewClientTrace(ctx, otelhttptrace.WithoutSubSpans()))
+
+	discoURL := &url.URL{
+		Scheme: "https",
+		Host:   string(host),
+		Path:   discoveryPath,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", discoURL.String(), nil)
+	if err != nil {
+		return &HostServices{Host: host, Err: err}
+	}
+	req.Header.Set("Accept", "application/json")
+	c.authenticate(req, host)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &HostServices{Host: host, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		bodyBytes, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return &HostServices{Host: host, Err: err}
+		}
+		return &HostServices{Host: host, Err: ClientError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}}
+	}
+
+	var services map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&services)
+	if err != nil {
+		return &HostServices{Host: host, Err: fmt.Errorf("failed to decode discovery document for %s: %w", host, err)}
+	}
+
+	rawURL, ok := services[modulesServiceID].(string)
+	if !ok {
+		return &HostServices{Host: host, Err: fmt.Errorf("host %s does not provide a module registry", host)}
+	}
+
+	modulesURL, err := url.Parse(rawURL)
+	if err != nil {
+		return &HostServices{Host: host, Err: fmt.Errorf("invalid %s URL for %s: %w", modulesServiceID, host, err)}
+	}
+	modulesURL = discoURL.ResolveReference(modulesURL)
+	if modulesURL.Path == "" || modulesURL.Path[len(modulesURL.Path)-1] != '/' {
+		modulesURL.Path += "/"
+	}
+
+	return &HostServices{Host: host, ModulesURL: modulesURL}
+}
+
+// authenticate adds a bearer token to the request,
+// if we have any credentials for the given host
+func (c Client) authenticate(req *http.Request, host svchost.Hostname) {
+	if c.Credentials == nil {
+		return
+	}
+	token, ok := c.Credentials.TokenForHost(host)
+	if ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	svchost "github.com/hashicorp/terraform-svchost"
+)
+
+type staticCredentials map[svchost.Hostname]string
+
+func (sc staticCredentials) TokenForHost(host svchost.Hostname) (string, bool) {
+	token, ok := sc[host]
+	return token, ok
+}
+
+type mapServiceStore map[svchost.Hostname]*HostServices
+
+func (ms mapServiceStore) HostServices(host svchost.Hostname) (*HostServices, bool) {
+	hs, ok := ms[host]
+	return hs, ok
+}
+
+func (ms mapServiceStore) CacheHostServices(hs *HostServices) error {
+	ms[hs.Host] = hs
+	return nil
+}
+
+func TestGetModuleVersions_privateRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	var discoveryRequests int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/.well-known/terraform.json" {
+			atomic.AddInt32(&discoveryRequests, 1)
+			w.Write([]byte(`{"modules.v1": "/api/registry/v1/modules/"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			http.Error(w, "unauthorized", 401)
+			return
+		}
+		if r.RequestURI == "/api/registry/v1/modules/puppetlabs/deployment/ec/versions" {
+			w.Write([]byte(moduleVersionsMockResponse))
+			return
+		}
+		http.Error(w, fmt.Sprintf("unexpected request: %q", r.RequestURI), 400)
+	}))
+	t.Cleanup(srv.Close)
+
+	host := svchost.Hostname(strings.TrimPrefix(srv.URL, "https://"))
+	addr := tfaddr.Module{
+		Package: tfaddr.ModulePackage{
+			Host:         host,
+			Namespace:    "puppetlabs",
+			Name:         "deployment",
+			TargetSystem: "ec",
+		},
+	}
+
+	client := NewClient()
+	client.httpClient = srv.Client()
+	client.Credentials = staticCredentials{
+		host: "secret-token",
+	}
+	services := mapServiceStore{}
+	client.Services = services
+
+	for i := 0; i < 2; i++ {
+		versions, err := client.GetModuleVersions(ctx, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedVersion := version.Must(version.NewVersion("0.0.8"))
+		if len(versions) == 0 || !versions[0].Equal(expectedVersion) {
+			t.Fatalf("expected latest version %s, given: %v", expectedVersion, versions)
+		}
+	}
+
+	if discoveryRequests != 1 {
+		t.Fatalf("expected exactly 1 discovery request, given %d", discoveryRequests)
+	}
+	hs, ok := services[host]
+	if !ok || hs.ModulesURL.Path != "/api/registry/v1/modules/" {
+		t.Fatalf("expected discovered services to be cached, given: %#v", services)
+	}
+}
+
+func TestGetModuleVersions_privateRegistryUnauthorized(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/.well-known/terraform.json" {
+			w.Write([]byte(`{"modules.v1": "https://` + r.Host + `/api/registry/v1/modules"}`))
+			return
+		}
+		http.Error(w, "unauthorized", 401)
+	}))
+	t.Cleanup(srv.Close)
+
+	addr := tfaddr.Module{
+		Package: tfaddr.ModulePackage{
+			Host:         svchost.Hostname(strings.TrimPrefix(srv.URL, "https://")),
+			Namespace:    "puppetlabs",
+			Name:         "deployment",
+			TargetSystem: "ec",
+		},
+	}
+
+	client := NewClient()
+	client.httpClient = srv.Client()
+
+	_, err := client.GetModuleVersions(ctx, addr)
+	clientErr, ok := err.(ClientError)
+	if !ok {
+		t.Fatalf("expected ClientError, given: %#v", err)
+	}
+	if clientErr.StatusCode != 401 {
+		t.Fatalf("expected 401 status code, given: %d", clientErr.StatusCode)
+	}
+}
+
+func TestGetModuleVersions_noModuleRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/.well-known/terraform.json" {
+			w.Write([]byte(`{"providers.v1": "/v1/providers/"}`))
+			return
+		}
+		http.Error(w, fmt.Sprintf("unexpected request: %q", r.RequestURI), 400)
+	}))
+	t.Cleanup(srv.Close)
+
+	addr := tfaddr.Module{
+		Package: tfaddr.ModulePackage{
+			Host:         svchost.Hostname(strings.TrimPrefix(srv.URL, "https://")),
+			Namespace:    "puppetlabs",
+			Name:         "deployment",
+			TargetSystem: "ec",
+		},
+	}
+
+	client := NewClient()
+	client.httpClient = srv.Client()
+
+	_, err := client.GetModuleVersions(ctx, addr)
+	if err == nil || !strings.Contains(err.Error(), "does not provide a module registry") {
+		t.Fatalf("expected discovery error, given: %v", err)
+	}
+}
@@ -76,16 +76,34 @@ func (rce ClientError) Error() string {
 func (c Client) GetModuleData(ctx context.Context, addr tfaddr.Module, cons version.Constraints) (*ModuleResponse, error) {
 	ctx, span := otel.Tracer(tracerName).Start(ctx, "registry:GetModuleData")
 	defer span.End()
-	var response ModuleResponse
 
 	v, err := c.GetMatchingModuleVersion(ctx, addr, cons)
 	if err != nil {
 		return nil, err
 	}
 
+	cacheKey := fmt.Sprintf("module-data:%s@%s", addr.Package.ForRegistryProtocol(), v.String())
+	response, err := cachedFetch(ctx, c, addr.Package.Host, cacheKey, moduleDataTTL, func(ctx context.Context) (ModuleResponse, error) {
+		return c.fetchModuleData(ctx, addr, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c Client) fetchModuleData(ctx context.Context, addr tfaddr.Module, v *version.Version) (ModuleResponse, error) {
+	var response ModuleResponse
+
 	ctx = httptrace.WithClientTrace(ctx, otelhttptrace.NewClientTrace(ctx, otelhttptrace.WithoutSubSpans()))
 
-	url := fmt.Sprintf("%s/v1/modules/%s/%s/%s/%s", c.BaseURL,
+	baseURL, err := c.modulesBaseURL(ctx, addr.Package.Host)
+	if err != nil {
+		return response, err
+	}
+
+	url := fmt.Sprintf("%s%s/%s/%s/%s", baseURL,
 		addr.Package.Namespace,
 		addr.Package.Name,
 		addr.Package.TargetSystem,
@@ -93,30 +111,31 @@ func (c Client) GetModuleData(ctx context.Context, addr tfaddr.Module, cons vers
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return response, err
 	}
+	c.authenticate(req, addr.Package.Host)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return response, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
 		bodyBytes, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return nil, err
+			return response, err
 		}
 
-		return nil, ClientError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+		return response, ClientError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	err = json.NewDecoder(resp.Body).Decode(&response)
 	if err != nil {
-		return nil, err
+		return response, err
 	}
 
-	return &response, nil
+	return response, nil
 }
 
 func (c Client) GetMatchingModuleVersion(ctx context.Context, addr tfaddr.Module, con version.Constraints) (*version.Version, error) {
@@ -140,7 +159,43 @@ func (c Client) GetModuleVersions(ctx context.Context, addr tfaddr.Module) (vers
 	ctx, span := otel.Tracer(tracerName).Start(ctx, "registry:GetModuleVersions")
 	defer span.End()
 
-	url := fmt.Sprintf("%s/v1/modules/%s/%s/%s/versions", c.BaseURL,
+	cacheKey := fmt.Sprintf("module-versions:%s", addr.Package.ForRegistryProtocol())
+	response, err := cachedFetch(ctx, c, addr.Package.Host, cacheKey, moduleVersionsTTL, func(ctx context.Context) (ModuleVersionsResponse, error) {
+		return c.fetchModuleVersions(ctx, addr)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var foundVersions version.Collection
+	for _, module := range response.Modules {
+		for _, entry := range module.Versions {
+			ver, err := version.NewVersion(entry.Version)
+			if err == nil {
+				foundVersions = append(foundVersions, ver)
+			}
+		}
+	}
+	span.AddEvent("registry:foundModuleVersions",
+		trace.WithAttributes(attribute.KeyValue{
+			Key:   attribute.Key("moduleVersionCount"),
+			Value: attribute.IntValue(len(foundVersions)),
+		}))
+
+	sort.Sort(sort.Reverse(foundVersions))
+
+	return foundVersions, nil
+}
+
+func (c Client) fetchModuleVersions(ctx context.Context, addr tfaddr.Module) (ModuleVersionsResponse, error) {
+	var response ModuleVersionsResponse
+
+	baseURL, err := c.modulesBaseURL(ctx, addr.Package.Host)
+	if err != nil {
+		return response, err
+	}
+
+	url := fmt.Sprintf("%s%s/%s/%s/versions", baseURL,
 		addr.Package.Namespace,
 		addr.Package.Name,
 		addr.Package.TargetSystem)
@@ -149,48 +204,31 @@ func (c Client) GetModuleVersions(ctx context.Context, addr tfaddr.Module) (vers
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return response, err
 	}
+	c.authenticate(req, addr.Package.Host)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return response, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
 		bodyBytes, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return nil, err
+			return response, err
 		}
 
-		return nil, ClientError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+		return response, ClientError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	_, decodeSpan := otel.Tracer(tracerName).Start(ctx, "registry:GetModuleVersions:decodeJson")
-	var response ModuleVersionsResponse
+	defer decodeSpan.End()
 	err = json.NewDecoder(resp.Body).Decode(&response)
 	if err != nil {
-		return nil, err
-	}
-	decodeSpan.End()
-
-	var foundVersions version.Collection
-	for _, module := range response.Modules {
-		for _, entry := range module.Versions {
-			ver, err := version.NewVersion(entry.Version)
-			if err == nil {
-				foundVersions = append(foundVersions, ver)
-			}
-		}
+		return response, err
 	}
-	span.AddEvent("registry:foundModuleVersions",
-		trace.WithAttributes(attribute.KeyValue{
-			Key:   attribute.Key("moduleVersionCount"),
-			Value: attribute.IntValue(len(foundVersions)),
-		}))
-
-	sort.Sort(sort.Reverse(foundVersions))
 
-	return foundVersions, nil
+	return response, nil
 }
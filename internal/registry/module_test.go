@@ -14,6 +14,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 )
 
@@ -191,3 +192,17 @@ func TestCancellationThroughContext(t *testing.T) {
 		t.Fatalf("expected error: %#v, given: %#v", context.DeadlineExceeded, e.Err)
 	}
 }
+
+func TestClient_ForEngine(t *testing.T) {
+	client := NewClient()
+
+	if given := client.ForEngine(engine.OpenTofu).BaseURL; given != OpenTofuBaseURL {
+		t.Fatalf("expected OpenTofu registry, given %q", given)
+	}
+	if given := client.ForEngine(engine.Terraform).BaseURL; given != defaultBaseURL {
+		t.Fatalf("expected Terraform registry, given %q", given)
+	}
+	if client.BaseURL != defaultBaseURL {
+		t.Fatalf("expected original client to remain unchanged, given %q", client.BaseURL)
+	}
+}
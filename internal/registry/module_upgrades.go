@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"github.com/hashicorp/go-version"
+)
+
+// ModuleUpgrades describes versions of a module newer than the one
+// selected by version constraints of a module call
+type ModuleUpgrades struct {
+	// Current is the newest version matching the constraints
+	Current *version.Version
+	// Compatible is the newest version of the same major version as
+	// Current, or of the same minor version for 0.x versions, which
+	// are not expected to be backwards compatible between minor versions
+	Compatible *version.Version
+	// Major is the newest version of any newer major version
+	Major *version.Version
+}
+
+// FindModuleUpgrades finds versions newer than the newest version
+// matching the given constraints. Pre-releases are never offered
+// as upgrades.
+//
+// It returns false if none of the versions matches the constraints.
+func FindModuleUpgrades(versions version.Collection, cons version.Constraints) (ModuleUpgrades, bool) {
+	var upgrades ModuleUpgrades
+
+	for _, v := range versions {
+		if cons.Check(v) && (upgrades.Current == nil || v.GreaterThan(upgrades.Current)) {
+			upgrades.Current = v
+		}
+	}
+	if upgrades.Current == nil {
+		return upgrades, false
+	}
+
+	for _, v := range versions {
+		if v.Prerelease() != "" || !v.GreaterThan(upgrades.Current) {
+			continue
+		}
+		if sameReleaseLine(v, upgrades.Current) {
+			if upgrades.Compatible == nil || v.GreaterThan(upgrades.Compatible) {
+				upgrades.Compatible = v
+			}
+			continue
+		}
+		if upgrades.Major == nil || v.GreaterThan(upgrades.Major) {
+			upgrades.Major = v
+		}
+	}
+
+	return upgrades, true
+}
+
+func sameReleaseLine(a, b *version.Version) bool {
+	aSegments, bSegments := a.Segments(), b.Segments()
+	if aSegments[0] != bSegments[0] {
+		return false
+	}
+	if aSegments[0] == 0 {
+		return aSegments[1] == bSegments[1]
+	}
+	return true
+}
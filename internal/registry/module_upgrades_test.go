@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+)
+
+func TestFindModuleUpgrades(t *testing.T) {
+	versions := version.Collection{}
+	for _, raw := range []string{"6.0.0-beta1", "5.2.0", "5.1.3", "5.1.0", "4.9.0", "4.2.1", "0.4.0", "0.3.2", "0.3.1"} {
+		versions = append(versions, version.Must(version.NewVersion(raw)))
+	}
+
+	testCases := []struct {
+		constraint         string
+		expectedFound      bool
+		expectedCurrent    string
+		expectedCompatible string
+		expectedMajor      string
+	}{
+		{"~> 5.1.0", true, "5.1.3", "5.2.0", ""},
+		{"5.2.0", true, "5.2.0", "", ""},
+		{"~> 4.0", true, "4.9.0", "", "5.2.0"},
+		{"4.2.1", true, "4.2.1", "4.9.0", "5.2.0"},
+		{"0.3.1", true, "0.3.1", "0.3.2", "5.2.0"},
+		{">= 7.0", false, "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			cons := version.MustConstraints(version.NewConstraint(tc.constraint))
+			upgrades, ok := FindModuleUpgrades(versions, cons)
+			if ok != tc.expectedFound {
+				t.Fatalf("expected found: %t, given: %t", tc.expectedFound, ok)
+			}
+
+			given := []string{versionString(upgrades.Current), versionString(upgrades.Compatible), versionString(upgrades.Major)}
+			expected := []string{tc.expectedCurrent, tc.expectedCompatible, tc.expectedMajor}
+			if diff := cmp.Diff(expected, given); diff != "" {
+				t.Fatalf("unexpected upgrades: %s", diff)
+			}
+		})
+	}
+}
+
+func versionString(v *version.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
@@ -4,6 +4,7 @@
 package registry
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -87,29 +88,46 @@ type ProviderVersionAttributes struct {
 	Version string `json:"version"`
 }
 
-func (c Client) GetLatestProviderVersion(id string) (*ProviderVersionResponse, error) {
+func (c Client) GetLatestProviderVersion(ctx context.Context, id string) (*ProviderVersionResponse, error) {
+	cacheKey := fmt.Sprintf("provider-latest-version:%s", id)
+	response, err := cachedFetch(ctx, c, "", cacheKey, providerVersionsTTL, func(ctx context.Context) (ProviderVersionResponse, error) {
+		return c.fetchLatestProviderVersion(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c Client) fetchLatestProviderVersion(ctx context.Context, id string) (ProviderVersionResponse, error) {
+	var response ProviderVersionResponse
+
 	url := fmt.Sprintf("%s/v2/providers/%s/provider-versions/latest?include=provider-platforms",
 		c.BaseURL, id)
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return response, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return response, err
 	}
 
 	if resp.StatusCode != 200 {
 		bodyBytes, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return nil, err
+			return response, err
 		}
 		defer resp.Body.Close()
 
-		return nil, fmt.Errorf("unexpected response %s: %s", resp.Status, string(bodyBytes))
+		return response, ClientError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
-	var response ProviderVersionResponse
 	err = json.NewDecoder(resp.Body).Decode(&response)
 	if err != nil {
-		return nil, err
+		return response, err
 	}
 
-	return &response, nil
+	return response, nil
 }
@@ -4,6 +4,7 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -233,7 +234,7 @@ func TestGetLatestProviderVersion(t *testing.T) {
 	client.BaseURL = srv.URL
 	t.Cleanup(srv.Close)
 
-	resp, err := client.GetLatestProviderVersion("370")
+	resp, err := client.GetLatestProviderVersion(context.Background(), "370")
 	if err != nil {
 		t.Fatal(err)
 	}
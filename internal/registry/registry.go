@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
@@ -15,24 +16,66 @@ const (
 	defaultBaseURL = "https://registry.terraform.io"
 	defaultTimeout = 5 * time.Second
 	tracerName     = "github.com/hashicorp/terraform-ls/internal/registry"
+
+	// OpenTofuBaseURL is the base URL of the public OpenTofu registry
+	OpenTofuBaseURL = "https://registry.opentofu.org"
 )
 
 type Client struct {
+	// BaseURL is the URL of the public registry, which serves
+	// all modules without an explicit hostname in their source
 	BaseURL          string
 	Timeout          time.Duration
 	ProviderPageSize int
 	httpClient       *http.Client
+
+	// Credentials provides tokens for private registries,
+	// such as HCP Terraform or Terraform Enterprise
+	Credentials CredentialsSource
+
+	// Offline prevents any network requests, so that only
+	// data from Cache is used
+	Offline bool
+	// Cache persists responses on disk, to make them available
+	// when offline or when the registry is unreachable
+	Cache *DiskCache
+
+	// Services keeps results of service discovery for private
+	// registries. Hosts are discovered on every request if nil.
+	Services ServiceStore
+
+	// reachability and refreshes are shared
+	// between copies of the client
+	reachability *reachability
+	refreshes    *refreshes
 }
 
 func NewClient() Client {
+	return NewClientWithBaseURL(defaultBaseURL)
+}
+
+// NewClientWithBaseURL returns a client querying a registry
+// other than the public Terraform Registry, e.g. OpenTofuBaseURL
+func NewClientWithBaseURL(baseURL string) Client {
 	client := cleanhttp.DefaultClient()
 	client.Timeout = defaultTimeout
 	client.Transport = otelhttp.NewTransport(client.Transport)
 
 	return Client{
-		BaseURL:          defaultBaseURL,
+		BaseURL:          baseURL,
 		Timeout:          defaultTimeout,
 		ProviderPageSize: 100,
 		httpClient:       client,
+		reachability:     newReachability(),
+		refreshes:        newRefreshes(),
+	}
+}
+
+// ForEngine returns a copy of the client which queries the public
+// registry of the given engine, i.e. the OpenTofu Registry for OpenTofu
+func (c Client) ForEngine(e engine.Engine) Client {
+	if e == engine.OpenTofu {
+		c.BaseURL = OpenTofuBaseURL
 	}
+	return c
 }
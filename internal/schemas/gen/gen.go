@@ -237,7 +237,7 @@ func schemaForProvider(ctx context.Context, client registry.Client, input Inputs
 	if input.Provider.Addr.IsBuiltIn() {
 		pVersion = input.CoreVersion
 	} else {
-		resp, err := client.GetLatestProviderVersion(input.Provider.ID)
+		resp, err := client.GetLatestProviderVersion(ctx, input.Provider.ID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get latest version: %w", err)
 		}
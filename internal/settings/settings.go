@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-ls/internal/terraform/datadir"
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"github.com/mcuadros/go-defaults"
 	"github.com/mitchellh/mapstructure"
 )
@@ -32,6 +33,29 @@ type Terraform struct {
 	Path        string `mapstructure:"path"`
 	Timeout     string `mapstructure:"timeout"`
 	LogFilePath string `mapstructure:"logFilePath"`
+
+	// Engine selects between Terraform and OpenTofu, which affects
+	// the executable being discovered, the registry being queried
+	// and the recognised language constructs. Empty value or "auto"
+	// means the engine is detected automatically.
+	Engine string `mapstructure:"engine"`
+}
+
+type Registry struct {
+	// Offline prevents any requests to registries, such that
+	// only previously cached metadata is used
+	Offline bool `mapstructure:"offline"`
+
+	// CacheDir overrides the location of the on-disk cache
+	// of registry metadata
+	CacheDir string `mapstructure:"cacheDir"`
+}
+
+type ProviderSchemas struct {
+	// Directories contain files produced by terraform providers schema -json,
+	// optionally gzip-compressed, which are loaded in addition to the bundled
+	// schemas, e.g. for providers not published in the public registry
+	Directories []string `mapstructure:"directories"`
 }
 
 type Options struct {
@@ -47,6 +71,10 @@ type Options struct {
 
 	Terraform Terraform `mapstructure:"terraform"`
 
+	Registry Registry `mapstructure:"registry"`
+
+	ProviderSchemas ProviderSchemas `mapstructure:"providerSchemas"`
+
 	XLegacyModulePaths              []string `mapstructure:"rootModulePaths"`
 	XLegacyExcludeModulePaths       []string `mapstructure:"excludeModulePaths"`
 	XLegacyIgnoreDirectoryNames     []string `mapstructure:"ignoreDirectoryNames"`
@@ -70,6 +98,20 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	if _, err := engine.ParseEngine(o.Terraform.Engine); err != nil {
+		return err
+	}
+
+	if o.Registry.CacheDir != "" && !filepath.IsAbs(o.Registry.CacheDir) {
+		return fmt.Errorf("Expected absolute path for registry cache directory, got %q", o.Registry.CacheDir)
+	}
+
+	for _, dir := range o.ProviderSchemas.Directories {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("Expected absolute path for provider schemas directory, got %q", dir)
+		}
+	}
+
 	if len(o.Indexing.IgnoreDirectoryNames) > 0 {
 		for _, directory := range o.Indexing.IgnoreDirectoryNames {
 			if directory == datadir.DataDirName {
@@ -107,3 +107,19 @@ func TestValidate_relativePath(t *testing.T) {
 		t.Fatal("expected decoding of relative path to result in error")
 	}
 }
+
+func TestValidate_providerSchemasRelativePath(t *testing.T) {
+	out, err := DecodeOptions(map[string]interface{}{
+		"providerSchemas": map[string]interface{}{
+			"directories": []string{"relative/path"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := out.Options.Validate()
+	if result == nil {
+		t.Fatal("expected decoding of relative path to result in error")
+	}
+}
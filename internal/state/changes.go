@@ -41,6 +41,12 @@ type Changes struct {
 	Diagnostics          bool
 	ReferenceOrigins     bool
 	ReferenceTargets     bool
+	State                bool
+	Plan                 bool
+
+	// RegistryModuleVersions indicates that versions of registry
+	// modules called from the module were (re)fetched
+	RegistryModuleVersions bool
 }
 
 const maxTimespan = 1 * time.Second
@@ -72,6 +78,10 @@ func (s *ChangeStore) QueueChange(dir document.DirHandle, changes Changes) error
 			Diagnostics:          cb.Changes.Diagnostics || changes.Diagnostics,
 			ReferenceOrigins:     cb.Changes.ReferenceOrigins || changes.ReferenceOrigins,
 			ReferenceTargets:     cb.Changes.ReferenceTargets || changes.ReferenceTargets,
+			State:                cb.Changes.State || changes.State,
+			Plan:                 cb.Changes.Plan || changes.Plan,
+
+			RegistryModuleVersions: cb.Changes.RegistryModuleVersions || changes.RegistryModuleVersions,
 		}
 	} else {
 		// create new change batch
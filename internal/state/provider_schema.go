@@ -4,6 +4,7 @@
 package state
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,7 +12,10 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-memdb"
@@ -399,6 +403,93 @@ func (s *ProviderSchemaStore) ProviderSchema(modPath string, addr tfaddr.Provide
 	return ss.schemas[0].Schema, nil
 }
 
+// ProviderSchemaVersions returns all versioned schemas of the given provider,
+// ranked in the same way as in [ProviderSchemaStore.ProviderSchema], i.e.
+// by how well they match the version constraints and by their source.
+func (s *ProviderSchemaStore) ProviderSchemaVersions(modPath string, addr tfaddr.Provider, vc version.Constraints) ([]*ProviderSchema, error) {
+	txn := s.db.Txn(false)
+
+	it, err := txn.Get(s.tableName, "id_prefix", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]*ProviderSchema, 0)
+	for item := it.Next(); item != nil; item = it.Next() {
+		ps, ok := item.(*ProviderSchema)
+		if !ok || ps.Schema == nil || ps.Version == nil || !providerAddrEquals(ps.Address, addr) {
+			continue
+		}
+		schemas = append(schemas, ps)
+	}
+
+	ss := sortableSchemas{
+		schemas:         schemas,
+		requiredModPath: modPath,
+		requiredVersion: vc,
+	}
+	sort.Stable(ss)
+
+	return ss.schemas, nil
+}
+
+// ProviderSchemaForVersion returns the schema of the given version of
+// the provider, preferring schemas obtained locally over preloaded ones
+func (s *ProviderSchemaStore) ProviderSchemaForVersion(addr tfaddr.Provider, v *version.Version) (*ProviderSchema, error) {
+	vc := version.Constraints{}
+	if c, err := version.NewConstraint(v.String()); err == nil {
+		vc = c
+	}
+
+	schemas, err := s.ProviderSchemaVersions("", addr, vc)
+	if err != nil {
+		return nil, err
+	}
+	for _, ps := range schemas {
+		if ps.Version.Equal(v) {
+			return ps, nil
+		}
+	}
+
+	return nil, &NoSchemaError{}
+}
+
+// ReplaceFileSchemas replaces all schemas loaded from files
+// of the given directory with the provided ones
+func (s *ProviderSchemaStore) ReplaceFileSchemas(dir string, pss []*ProviderSchema) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	it, err := txn.Get(s.tableName, "id")
+	if err != nil {
+		return err
+	}
+	stale := make([]*ProviderSchema, 0)
+	for item := it.Next(); item != nil; item = it.Next() {
+		ps := item.(*ProviderSchema)
+		src, ok := ps.Source.(FileSchemaSource)
+		if ok && filepath.Dir(src.Path) == dir {
+			stale = append(stale, ps)
+		}
+	}
+	for _, ps := range stale {
+		err = txn.Delete(s.tableName, ps)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, ps := range pss {
+		err = txn.Insert(s.tableName, ps)
+		if err != nil {
+			return err
+		}
+	}
+
+	txn.Commit()
+	return nil
+}
+
 // type ModuleLookupFunc func(string) (*RootRecord, error)
 
 func NewDefaultProvider(name string) tfaddr.Provider {
@@ -439,8 +530,8 @@ func (ss sortableSchemas) Len() int {
 func (ss sortableSchemas) Less(i, j int) bool {
 	var leftRank, rightRank int
 
-	leftRank += ss.rankByVersionMatch(ss.schemas[i].Version)
-	rightRank += ss.rankByVersionMatch(ss.schemas[j].Version)
+	leftRank += ss.rankByVersionMatch(ss.schemas[i])
+	rightRank += ss.rankByVersionMatch(ss.schemas[j])
 
 	// TODO: Rank by hierarchy proximity
 
@@ -455,10 +546,15 @@ func (ss sortableSchemas) Less(i, j int) bool {
 func (ss sortableSchemas) rankBySource(src SchemaSource) int {
 	switch s := src.(type) {
 	case PreloadedSchemaSource:
+		return -2
+	case FileSchemaSource:
+		// schemas supplied by the user are preferred over
+		// the bundled ones, but not over the ones obtained
+		// from an initialized module
 		return -1
 	case LocalSchemaSource:
 		if s.ModulePath == ss.requiredModPath {
-			return 2
+			return 4
 		}
 
 		// mod, err := ss.lookupModule(s.ModulePath)
@@ -471,9 +567,15 @@ func (ss sortableSchemas) rankBySource(src SchemaSource) int {
 	return 0
 }
 
-func (ss sortableSchemas) rankByVersionMatch(v *version.Version) int {
-	if v != nil && ss.requiredVersion.Check(v) {
-		return 2
+func (ss sortableSchemas) rankByVersionMatch(ps *ProviderSchema) int {
+	if _, ok := ps.Source.(FileSchemaSource); ok && ps.Version == nil {
+		// files produced by terraform providers schema -json
+		// do not contain versions, so we trust the user
+		// to supply schemas of matching versions
+		return 4
+	}
+	if ps.Version != nil && ss.requiredVersion.Check(ps.Version) {
+		return 4
 	}
 
 	return 0
@@ -606,3 +708,87 @@ func PreloadSchemaForProviderAddr(ctx context.Context, pAddr tfaddr.Provider, fs
 
 	return nil
 }
+
+// LoadProviderSchemaFiles loads schemas from files of the given directory,
+// as produced by terraform providers schema -json and optionally
+// gzip-compressed, replacing any schemas loaded from the directory before.
+//
+// Files which cannot be decoded are skipped.
+func LoadProviderSchemaFiles(ctx context.Context, dir string, schemaStore *ProviderSchemaStore, logger *log.Logger) error {
+	dir = filepath.Clean(dir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		logger.Printf("provider schema directory %q does not exist", dir)
+		entries = []os.DirEntry{}
+	}
+
+	pss := make([]*ProviderSchema, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !IsProviderSchemaFile(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+
+		jsonSchemas, err := readProviderSchemaFile(path)
+		if err != nil {
+			logger.Printf("failed to read provider schema file %q: %s", path, err)
+			continue
+		}
+
+		for rawAddr, ps := range jsonSchemas.Schemas {
+			pAddr, err := tfaddr.ParseProviderSource(rawAddr)
+			if err != nil {
+				logger.Printf("invalid provider address %q in %q: %s", rawAddr, path, err)
+				continue
+			}
+			pss = append(pss, &ProviderSchema{
+				Address: pAddr,
+				Source:  FileSchemaSource{Path: path},
+				Schema:  tfschema.ProviderSchemaFromJson(ps, pAddr),
+			})
+		}
+	}
+
+	err = schemaStore.ReplaceFileSchemas(dir, pss)
+	if err != nil {
+		return err
+	}
+	logger.Printf("loaded %d provider schemas from %q", len(pss), dir)
+
+	return nil
+}
+
+// IsProviderSchemaFile reports whether the file name suggests
+// that the file contains provider schemas in JSON
+func IsProviderSchemaFile(name string) bool {
+	return strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.gz")
+}
+
+func readProviderSchemaFile(path string) (*tfjson.ProviderSchemas, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gzipReader, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		r = gzipReader
+	}
+
+	jsonSchemas := &tfjson.ProviderSchemas{}
+	err = json.NewDecoder(r).Decode(jsonSchemas)
+	if err != nil {
+		return nil, err
+	}
+	return jsonSchemas, nil
+}
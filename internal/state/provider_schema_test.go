@@ -4,11 +4,20 @@
 package state
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	tfschema "github.com/hashicorp/terraform-schema/schema"
 )
@@ -87,6 +96,218 @@ func TestStateStore_AddLocalSchema_duplicate(t *testing.T) {
 	}
 }
 
+func TestStateStore_ProviderSchemaVersions(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	addr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	preloadedSchema := &tfschema.ProviderSchema{}
+	localSchema := &tfschema.ProviderSchema{}
+
+	err = s.ProviderSchemas.AddPreloadedSchema(addr, testVersion(t, "6.0.0"), preloadedSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.AddPreloadedSchema(tfaddr.MustParseProviderSource("hashicorp/awscc"),
+		testVersion(t, "1.0.0"), &tfschema.ProviderSchema{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.AddLocalSchema(modPath, addr, localSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.UpdateProviderVersions(modPath, map[tfaddr.Provider]*version.Version{
+		addr: testVersion(t, "5.2.0"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := s.ProviderSchemas.ProviderSchemaVersions(modPath, addr, version.MustConstraints(version.NewConstraint("~> 5.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := make([]string, 0, len(schemas))
+	for _, ps := range schemas {
+		versions = append(versions, ps.Version.String())
+	}
+	if diff := cmp.Diff([]string{"5.2.0", "6.0.0"}, versions); diff != "" {
+		t.Fatalf("unexpected versions: %s", diff)
+	}
+
+	ps, err := s.ProviderSchemas.ProviderSchemaForVersion(addr, testVersion(t, "6.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Source.(PreloadedSchemaSource); !ok {
+		t.Fatalf("expected preloaded schema, given %#v", ps.Source)
+	}
+
+	_, err = s.ProviderSchemas.ProviderSchemaForVersion(addr, testVersion(t, "4.0.0"))
+	if err == nil {
+		t.Fatal("expected error for unknown version")
+	}
+	noSchemaErr := &NoSchemaError{}
+	if !errors.As(err, &noSchemaErr) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestLoadProviderSchemaFiles(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := log.New(io.Discard, "", 0)
+
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "internal.json"), []byte(testProviderSchemaJSON("name")), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err = zw.Write([]byte(strings.ReplaceAll(testProviderSchemaJSON("size"), "acme/internal", "acme/other")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "other.json.gz"), buf.Bytes(), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a schema"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = LoadProviderSchemaFiles(context.Background(), dir, s.ProviderSchemas, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	internalAddr := tfaddr.MustParseProviderSource("acme/internal")
+	ps, err := s.ProviderSchemas.ProviderSchema("", internalAddr, version.Constraints{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["internal_thing"].Attributes["name"]; !ok {
+		t.Fatalf("expected attribute to be loaded, given %#v", ps.Resources["internal_thing"])
+	}
+	_, err = s.ProviderSchemas.ProviderSchema("", tfaddr.MustParseProviderSource("acme/other"), version.Constraints{})
+	if err != nil {
+		t.Fatalf("expected schema from compressed file: %s", err)
+	}
+
+	// reloading replaces previously loaded schemas
+	err = os.WriteFile(filepath.Join(dir, "internal.json"), []byte(testProviderSchemaJSON("label")), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Remove(filepath.Join(dir, "other.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = LoadProviderSchemaFiles(context.Background(), dir, s.ProviderSchemas, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps, err = s.ProviderSchemas.ProviderSchema("", internalAddr, version.Constraints{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["internal_thing"].Attributes["label"]; !ok {
+		t.Fatalf("expected attribute to be reloaded, given %#v", ps.Resources["internal_thing"])
+	}
+	_, err = s.ProviderSchemas.ProviderSchema("", tfaddr.MustParseProviderSource("acme/other"), version.Constraints{})
+	if err == nil {
+		t.Fatal("expected schema of removed file to be unloaded")
+	}
+}
+
+func TestProviderSchema_fileSourceRanking(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modPath := t.TempDir()
+	addr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	preloadedSchema := &tfschema.ProviderSchema{Resources: map[string]*schema.BodySchema{"preloaded": {}}}
+	fileSchema := &tfschema.ProviderSchema{Resources: map[string]*schema.BodySchema{"file": {}}}
+	localSchema := &tfschema.ProviderSchema{Resources: map[string]*schema.BodySchema{"local": {}}}
+
+	err = s.ProviderSchemas.AddPreloadedSchema(addr, testVersion(t, "5.0.0"), preloadedSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.ReplaceFileSchemas("/schemas", []*ProviderSchema{
+		{
+			Address: addr,
+			Source:  FileSchemaSource{Path: "/schemas/aws.json"},
+			Schema:  fileSchema,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := s.ProviderSchemas.ProviderSchema(modPath, addr, version.Constraints{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["file"]; !ok {
+		t.Fatalf("expected schema from file to be preferred over preloaded, given %#v", ps.Resources)
+	}
+
+	otherModPath := filepath.Join(modPath, "other")
+	err = s.ProviderSchemas.AddLocalSchema(otherModPath, addr, localSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.ProviderSchemas.UpdateProviderVersions(otherModPath, map[tfaddr.Provider]*version.Version{
+		addr: testVersion(t, "5.1.0"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps, err = s.ProviderSchemas.ProviderSchema(modPath, addr, version.Constraints{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["local"]; !ok {
+		t.Fatalf("expected local schema to be preferred over file, given %#v", ps.Resources)
+	}
+}
+
+func testProviderSchemaJSON(attrName string) string {
+	return `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/acme/internal": {
+      "provider": {"version": 0, "block": {}},
+      "resource_schemas": {
+        "internal_thing": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "` + attrName + `": {"type": "string", "optional": true}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+}
+
 func TestAllSchemasExist(t *testing.T) {
 	testCases := []struct {
 		Name               string
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/go-version"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
@@ -132,3 +133,74 @@ func (s *RegistryModuleStore) RegistryModuleMeta(addr tfaddr.Module, cons versio
 		Source: addr.String(),
 	}
 }
+
+// RegistryModuleVersions represents all versions
+// of a module published in the registry
+type RegistryModuleVersions struct {
+	Source   tfaddr.Module
+	Versions version.Collection
+}
+
+// CacheVersions caches versions of the given module, replacing any versions
+// cached previously. It returns true if the versions have changed.
+func (s *RegistryModuleStore) CacheVersions(sourceAddr tfaddr.Module, versions version.Collection) (bool, error) {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	obj, err := txn.First(s.versionsTableName, "id", sourceAddr)
+	if err != nil {
+		return false, err
+	}
+	if obj != nil && versionsEqual(obj.(*RegistryModuleVersions).Versions, versions) {
+		return false, nil
+	}
+
+	sorted := make(version.Collection, len(versions))
+	copy(sorted, versions)
+	sort.Sort(sort.Reverse(sorted))
+
+	err = txn.Insert(s.versionsTableName, &RegistryModuleVersions{
+		Source:   sourceAddr,
+		Versions: sorted,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	txn.Commit()
+	return true, nil
+}
+
+// ModuleVersions returns cached versions of the given module,
+// sorted from the newest to the oldest
+func (s *RegistryModuleStore) ModuleVersions(sourceAddr tfaddr.Module) (version.Collection, error) {
+	txn := s.db.Txn(false)
+
+	obj, err := txn.First(s.versionsTableName, "id", sourceAddr)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, &RecordNotFoundError{
+			Source: sourceAddr.String(),
+		}
+	}
+
+	return obj.(*RegistryModuleVersions).Versions, nil
+}
+
+func versionsEqual(a, b version.Collection) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(a))
+	for _, v := range a {
+		seen[v.String()] = true
+	}
+	for _, v := range b {
+		if !seen[v.String()] {
+			return false
+		}
+	}
+	return true
+}
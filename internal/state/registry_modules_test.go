@@ -4,13 +4,16 @@
 package state
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl-lang/lang"
+	lsregistry "github.com/hashicorp/terraform-ls/internal/registry"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/hashicorp/terraform-schema/registry"
+	svchost "github.com/hashicorp/terraform-svchost"
 	"github.com/zclconf/go-cty-debug/ctydebug"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -155,3 +158,142 @@ func TestStateStore_cache_error(t *testing.T) {
 		t.Fatal("should exist")
 	}
 }
+
+func TestStateStore_cache_metadataPerHost(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicSource, err := tfaddr.ParseModuleSource("example/vpc/aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateSource, err := tfaddr.ParseModuleSource("app.example.com/example/vpc/aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := version.Must(version.NewVersion("1.0.0"))
+	c := version.MustConstraints(version.NewConstraint(">= 1.0"))
+
+	err = s.RegistryModules.Cache(privateSource, v, []registry.Input{}, []registry.Output{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exists, err := s.RegistryModules.Exists(privateSource, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Fatal("private module should exist")
+	}
+
+	exists, err = s.RegistryModules.Exists(publicSource, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Fatal("public module with the same name should not exist")
+	}
+}
+
+func TestStateStore_cache_versions(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := tfaddr.ParseModuleSource("terraform-aws-modules/eks/aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.RegistryModules.ModuleVersions(source)
+	if !IsRecordNotFound(err) {
+		t.Fatalf("expected versions not to be found, given: %v", err)
+	}
+
+	versions := version.Collection{
+		version.Must(version.NewVersion("3.10.0")),
+		version.Must(version.NewVersion("4.0.0")),
+		version.Must(version.NewVersion("3.9.1")),
+	}
+	changed, err := s.RegistryModules.CacheVersions(source, versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected versions to be changed")
+	}
+
+	cached, err := s.RegistryModules.ModuleVersions(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedVersions := []string{"4.0.0", "3.10.0", "3.9.1"}
+	givenVersions := make([]string, 0, len(cached))
+	for _, v := range cached {
+		givenVersions = append(givenVersions, v.String())
+	}
+	if diff := cmp.Diff(expectedVersions, givenVersions); diff != "" {
+		t.Fatalf("unexpected versions: %s", diff)
+	}
+
+	// caching the same versions again is not a change
+	changed, err = s.RegistryModules.CacheVersions(source, versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Fatal("expected versions not to be changed")
+	}
+
+	changed, err = s.RegistryModules.CacheVersions(source, append(versions, version.Must(version.NewVersion("4.1.0"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected versions to be changed")
+	}
+}
+
+func TestStateStore_cache_hostServices(t *testing.T) {
+	s, err := NewStateStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host := svchost.Hostname("app.terraform.io")
+	_, ok := s.RegistryModules.HostServices(host)
+	if ok {
+		t.Fatal("expected no services to be cached")
+	}
+
+	hostErr := lsregistry.ClientError{StatusCode: 404}
+	err = s.RegistryModules.CacheHostServices(&lsregistry.HostServices{
+		Host: host,
+		Err:  hostErr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modulesURL, _ := url.Parse("https://app.terraform.io/api/registry/v1/modules/")
+	err = s.RegistryModules.CacheHostServices(&lsregistry.HostServices{
+		Host:       host,
+		ModulesURL: modulesURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hs, ok := s.RegistryModules.HostServices(host)
+	if !ok {
+		t.Fatal("expected services to be cached")
+	}
+	if hs.Err != nil || hs.ModulesURL.String() != modulesURL.String() {
+		t.Fatalf("expected cached services to be replaced, given: %#v", hs)
+	}
+}
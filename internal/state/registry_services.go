@@ -0,0 +1,37 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package state
+
+import (
+	"github.com/hashicorp/terraform-ls/internal/registry"
+	svchost "github.com/hashicorp/terraform-svchost"
+)
+
+// HostServices returns results of service discovery
+// cached for the given registry host
+func (s *RegistryModuleStore) HostServices(host svchost.Hostname) (*registry.HostServices, bool) {
+	txn := s.db.Txn(false)
+
+	obj, err := txn.First(s.servicesTableName, "id", host)
+	if err != nil || obj == nil {
+		return nil, false
+	}
+
+	return obj.(*registry.HostServices), true
+}
+
+// CacheHostServices caches results of service discovery,
+// replacing any results cached previously for the same host
+func (s *RegistryModuleStore) CacheHostServices(hs *registry.HostServices) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	err := txn.Insert(s.servicesTableName, hs)
+	if err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
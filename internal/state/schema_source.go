@@ -34,3 +34,17 @@ func (LocalSchemaSource) isSchemaSrcImpl() schemaSrcSigil {
 func (lss LocalSchemaSource) String() string {
 	return fmt.Sprintf("local(%s)", lss.ModulePath)
 }
+
+// FileSchemaSource represents a schema loaded from a file produced
+// by terraform providers schema -json within a configured directory
+type FileSchemaSource struct {
+	Path string
+}
+
+func (FileSchemaSource) isSchemaSrcImpl() schemaSrcSigil {
+	return schemaSrcSigil{}
+}
+
+func (fss FileSchemaSource) String() string {
+	return fmt.Sprintf("file(%s)", fss.Path)
+}
@@ -21,6 +21,9 @@ const (
 	walkerPathsTableName    = "walker_paths"
 	registryModuleTableName = "registry_module"
 
+	registryModuleVersionsTableName = "registry_module_versions"
+	registryServicesTableName       = "registry_services"
+
 	tracerName = "github.com/hashicorp/terraform-ls/internal/state"
 )
 
@@ -162,6 +165,26 @@ var dbSchema = &memdb.DBSchema{
 				},
 			},
 		},
+		registryModuleVersionsTableName: {
+			Name: registryModuleVersionsTableName,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:    "id",
+					Unique:  true,
+					Indexer: &StringerFieldIndexer{Field: "Source"},
+				},
+			},
+		},
+		registryServicesTableName: {
+			Name: registryServicesTableName,
+			Indexes: map[string]*memdb.IndexSchema{
+				"id": {
+					Name:    "id",
+					Unique:  true,
+					Indexer: &StringerFieldIndexer{Field: "Host"},
+				},
+			},
+		},
 		providerIdsTableName: {
 			Name: providerIdsTableName,
 			Indexes: map[string]*memdb.IndexSchema{
@@ -219,9 +242,11 @@ type ProviderSchemaStore struct {
 	logger    *log.Logger
 }
 type RegistryModuleStore struct {
-	db        *memdb.MemDB
-	tableName string
-	logger    *log.Logger
+	db                *memdb.MemDB
+	tableName         string
+	versionsTableName string
+	servicesTableName string
+	logger            *log.Logger
 }
 
 func NewStateStore() (*StateStore, error) {
@@ -257,9 +282,11 @@ func NewStateStore() (*StateStore, error) {
 			logger:    defaultLogger,
 		},
 		RegistryModules: &RegistryModuleStore{
-			db:        db,
-			tableName: registryModuleTableName,
-			logger:    defaultLogger,
+			db:                db,
+			tableName:         registryModuleTableName,
+			versionsTableName: registryModuleVersionsTableName,
+			servicesTableName: registryServicesTableName,
+			logger:            defaultLogger,
 		},
 		WalkerPaths: &WalkerPathStore{
 			db:              db,
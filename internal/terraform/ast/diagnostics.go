@@ -15,6 +15,10 @@ const (
 	SchemaValidationSource
 	ReferenceValidationSource
 	TerraformValidateSource
+	TerraformTestSource
+	PolicyPreviewSource
+	PlanSource
+	ProviderUpgradeSource
 )
 
 func (d DiagnosticSource) String() string {
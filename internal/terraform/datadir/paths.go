@@ -56,6 +56,12 @@ func PathGlobPatternsForWatching() []WatchPattern {
 			EventType: AnyEventType,
 		})
 	}
+	for _, filename := range stateFilenames {
+		patterns = append(patterns, WatchPattern{
+			Pattern:   "**/" + filename,
+			EventType: AnyEventType,
+		})
+	}
 
 	return patterns
 }
@@ -0,0 +1,464 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package datadir
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const (
+	// StateFilename is the name of the state file of the local backend
+	StateFilename = "terraform.tfstate"
+	// StateJSONFilename is the name of the file which is expected
+	// to contain the output of `terraform show -json`
+	StateJSONFilename = "terraform.tfstate.json"
+)
+
+// SensitiveMark marks sensitive values within the state
+const SensitiveMark = "sensitive"
+
+var stateFilenames = []string{
+	StateFilename,
+	StateJSONFilename,
+}
+
+// State represents resource instances of the root module
+// as recorded in the state
+type State struct {
+	// Resources are keyed by their address, e.g. aws_instance.web
+	// or data.aws_ami.ubuntu
+	Resources map[string]*StateResource
+}
+
+// StateResource represents all instances of a single resource
+type StateResource struct {
+	Address string
+	Mode    tfjson.ResourceMode
+	Type    string
+	Name    string
+
+	Instances []StateInstance
+}
+
+// StateInstance represents a single resource instance
+type StateInstance struct {
+	// Key is the instance key, i.e. a number for count,
+	// a string for for_each or cty.NilVal for neither
+	Key cty.Value
+	// Value is an object of the instance attributes
+	// where sensitive values are marked with [SensitiveMark]
+	Value cty.Value
+}
+
+// Value returns value of the resource as it would be referenced,
+// i.e. an object for a single instance, a tuple for count
+// or an object keyed by instance keys for for_each
+func (r *StateResource) Value() cty.Value {
+	if len(r.Instances) == 0 {
+		return cty.DynamicVal
+	}
+	if r.Instances[0].Key == cty.NilVal {
+		return r.Instances[0].Value
+	}
+
+	if r.Instances[0].Key.Type() == cty.Number {
+		vals := make([]cty.Value, 0, len(r.Instances))
+		for _, instance := range r.Instances {
+			vals = append(vals, instance.Value)
+		}
+		return cty.TupleVal(vals)
+	}
+
+	vals := make(map[string]cty.Value, len(r.Instances))
+	for _, instance := range r.Instances {
+		vals[instance.Key.AsString()] = instance.Value
+	}
+	return cty.ObjectVal(vals)
+}
+
+// Variables returns values of all resources and data sources
+// keyed by the root name as they would be referenced,
+// e.g. aws_instance or data
+func (s *State) Variables() map[string]cty.Value {
+	types := make(map[string]map[string]cty.Value)
+	dataTypes := make(map[string]map[string]cty.Value)
+
+	for _, r := range s.Resources {
+		target := types
+		if r.Mode == tfjson.DataResourceMode {
+			target = dataTypes
+		}
+		if _, ok := target[r.Type]; !ok {
+			target[r.Type] = make(map[string]cty.Value)
+		}
+		target[r.Type][r.Name] = r.Value()
+	}
+
+	vars := make(map[string]cty.Value, len(types)+1)
+	for typ, names := range types {
+		vars[typ] = cty.ObjectVal(names)
+	}
+	if len(dataTypes) > 0 {
+		data := make(map[string]cty.Value, len(dataTypes))
+		for typ, names := range dataTypes {
+			data[typ] = cty.ObjectVal(names)
+		}
+		vars["data"] = cty.ObjectVal(data)
+	}
+	return vars
+}
+
+// ModuleUriFromStateFile returns the URI of the module
+// which the given state file belongs to
+func ModuleUriFromStateFile(rawUri string) (string, bool) {
+	for _, filename := range stateFilenames {
+		suffix := "/" + filename
+		if strings.HasSuffix(rawUri, suffix) {
+			return strings.TrimSuffix(rawUri, suffix), true
+		}
+	}
+	return "", false
+}
+
+// ParseStateFile parses the state of the local backend (terraform.tfstate)
+// or, if it does not exist, a JSON snapshot (terraform.tfstate.json).
+// Only resources of the root module are included.
+func ParseStateFile(filesystem FS, modPath string) (*State, error) {
+	for _, filename := range stateFilenames {
+		src, err := filesystem.ReadFile(filepath.Join(modPath, filename))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		return ParseState(src)
+	}
+
+	return nil, fs.ErrNotExist
+}
+
+// ParseState parses either the state of the local backend
+// or the output of `terraform show -json`
+func ParseState(src []byte) (*State, error) {
+	var header struct {
+		FormatVersion string `json:"format_version"`
+		Version       int    `json:"version"`
+	}
+	err := json.Unmarshal(src, &header)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.FormatVersion != "" {
+		return parseStateJSON(src)
+	}
+	if header.Version != 4 {
+		return nil, fmt.Errorf("unsupported state version %d", header.Version)
+	}
+	return parseStateV4(src)
+}
+
+type stateV4 struct {
+	Resources []resourceStateV4 `json:"resources"`
+}
+
+type resourceStateV4 struct {
+	Module    string            `json:"module"`
+	Mode      string            `json:"mode"`
+	Type      string            `json:"type"`
+	Name      string            `json:"name"`
+	Instances []instanceStateV4 `json:"instances"`
+}
+
+type instanceStateV4 struct {
+	IndexKey            interface{}       `json:"index_key"`
+	Deposed             string            `json:"deposed"`
+	Attributes          json.RawMessage   `json:"attributes"`
+	SensitiveAttributes []json.RawMessage `json:"sensitive_attributes"`
+}
+
+type pathStepV4 struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+func parseStateV4(src []byte) (*State, error) {
+	var sf stateV4
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+	err := dec.Decode(&sf)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{
+		Resources: make(map[string]*StateResource),
+	}
+	for _, rs := range sf.Resources {
+		if rs.Module != "" {
+			continue
+		}
+
+		r := &StateResource{
+			Type:      rs.Type,
+			Name:      rs.Name,
+			Instances: make([]StateInstance, 0, len(rs.Instances)),
+		}
+		switch rs.Mode {
+		case "managed":
+			r.Mode = tfjson.ManagedResourceMode
+			r.Address = fmt.Sprintf("%s.%s", rs.Type, rs.Name)
+		case "data":
+			r.Mode = tfjson.DataResourceMode
+			r.Address = fmt.Sprintf("data.%s.%s", rs.Type, rs.Name)
+		default:
+			continue
+		}
+
+		for _, is := range rs.Instances {
+			if is.Deposed != "" {
+				continue
+			}
+			key, err := instanceKey(is.IndexKey)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", r.Address, err)
+			}
+
+			paths := make([]cty.Path, 0, len(is.SensitiveAttributes))
+			for _, rawPath := range is.SensitiveAttributes {
+				path, ok := sensitivePathV4(rawPath)
+				if ok {
+					paths = append(paths, path)
+				}
+			}
+
+			val, err := instanceValue(is.Attributes, paths)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", r.Address, err)
+			}
+			r.Instances = append(r.Instances, StateInstance{Key: key, Value: val})
+		}
+		sortInstances(r.Instances)
+
+		state.Resources[r.Address] = r
+	}
+
+	return state, nil
+}
+
+// sensitivePathV4 decodes a path of a sensitive attribute,
+// e.g. [{"type":"get_attr","value":"password"}]
+func sensitivePathV4(src json.RawMessage) (cty.Path, bool) {
+	var steps []pathStepV4
+	if err := json.Unmarshal(src, &steps); err != nil {
+		return nil, false
+	}
+
+	path := make(cty.Path, 0, len(steps))
+	for _, step := range steps {
+		switch step.Type {
+		case "get_attr":
+			var name string
+			if err := json.Unmarshal(step.Value, &name); err != nil {
+				return nil, false
+			}
+			path = path.GetAttr(name)
+		case "index":
+			var key struct {
+				Value interface{} `json:"value"`
+			}
+			dec := json.NewDecoder(bytes.NewReader(step.Value))
+			dec.UseNumber()
+			if err := dec.Decode(&key); err != nil {
+				return nil, false
+			}
+			keyVal, err := instanceKey(key.Value)
+			if err != nil || keyVal == cty.NilVal {
+				return nil, false
+			}
+			path = path.Index(keyVal)
+		default:
+			return nil, false
+		}
+	}
+	return path, true
+}
+
+func parseStateJSON(src []byte) (*State, error) {
+	var ps tfjson.State
+	err := ps.UnmarshalJSON(src)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{
+		Resources: make(map[string]*StateResource),
+	}
+	if ps.Values == nil || ps.Values.RootModule == nil {
+		return state, nil
+	}
+
+	for _, rs := range ps.Values.RootModule.Resources {
+		address := fmt.Sprintf("%s.%s", rs.Type, rs.Name)
+		if rs.Mode == tfjson.DataResourceMode {
+			address = "data." + address
+		}
+
+		r, ok := state.Resources[address]
+		if !ok {
+			r = &StateResource{
+				Address:   address,
+				Mode:      rs.Mode,
+				Type:      rs.Type,
+				Name:      rs.Name,
+				Instances: make([]StateInstance, 0),
+			}
+			state.Resources[address] = r
+		}
+
+		key, err := instanceKey(rs.Index)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", address, err)
+		}
+
+		attributes, err := json.Marshal(rs.AttributeValues)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", address, err)
+		}
+		paths := make([]cty.Path, 0)
+		if len(rs.SensitiveValues) > 0 {
+			var sensitive interface{}
+			if err := json.Unmarshal(rs.SensitiveValues, &sensitive); err == nil {
+				paths = sensitivePaths(sensitive, cty.Path{}, paths)
+			}
+		}
+
+		val, err := instanceValue(attributes, paths)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", address, err)
+		}
+		r.Instances = append(r.Instances, StateInstance{Key: key, Value: val})
+	}
+
+	for _, r := range state.Resources {
+		sortInstances(r.Instances)
+	}
+
+	return state, nil
+}
+
+// sensitivePaths collects paths to values which are true within
+// the sensitive_values structure of the JSON state
+func sensitivePaths(v interface{}, path cty.Path, paths []cty.Path) []cty.Path {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			paths = append(paths, path.Copy())
+		}
+	case map[string]interface{}:
+		for name, elem := range v {
+			paths = sensitivePaths(elem, path.GetAttr(name), paths)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			paths = sensitivePaths(elem, path.Index(cty.NumberIntVal(int64(i))), paths)
+		}
+	}
+	return paths
+}
+
+func instanceKey(key interface{}) (cty.Value, error) {
+	switch key := key.(type) {
+	case nil:
+		return cty.NilVal, nil
+	case string:
+		return cty.StringVal(key), nil
+	case float64:
+		return cty.NumberFloatVal(key), nil
+	case json.Number:
+		return cty.ParseNumberVal(key.String())
+	}
+	return cty.NilVal, fmt.Errorf("unsupported instance key %#v", key)
+}
+
+// instanceValue decodes attributes of an instance
+// and marks values at the given paths as sensitive
+func instanceValue(attributes json.RawMessage, sensitive []cty.Path) (cty.Value, error) {
+	if len(attributes) == 0 || string(attributes) == "null" {
+		return cty.EmptyObjectVal, nil
+	}
+
+	ty, err := ctyjson.ImpliedType(attributes)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	val, err := ctyjson.Unmarshal(attributes, ty)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	if len(sensitive) == 0 {
+		return val, nil
+	}
+
+	return cty.Transform(val, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		for _, sp := range sensitive {
+			if pathsEqual(path, sp) {
+				return v.Mark(SensitiveMark), nil
+			}
+		}
+		return v, nil
+	})
+}
+
+// pathsEqual compares paths, considering that maps within the state
+// are decoded as objects, i.e. an index step with a string key
+// is equal to an attribute step of the same name
+func pathsEqual(a, b cty.Path) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		aName, aOk := ihcl.PathStepName(a[i])
+		bName, bOk := ihcl.PathStepName(b[i])
+		if aOk || bOk {
+			if aOk != bOk || aName != bName {
+				return false
+			}
+			continue
+		}
+
+		aIdx, aOk := a[i].(cty.IndexStep)
+		bIdx, bOk := b[i].(cty.IndexStep)
+		if !aOk || !bOk || !aIdx.Key.RawEquals(bIdx.Key) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortInstances(instances []StateInstance) {
+	sort.SliceStable(instances, func(i, j int) bool {
+		a, b := instances[i].Key, instances[j].Key
+		if a == cty.NilVal || b == cty.NilVal || a.Type() != b.Type() {
+			return false
+		}
+		if a.Type() == cty.Number {
+			return a.LessThan(b).True()
+		}
+		return a.AsString() < b.AsString()
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package datadir
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestParseStateFile_v4(t *testing.T) {
+	fs := fstest.MapFS{
+		filepath.Join("foo-module", "terraform.tfstate"): &fstest.MapFile{
+			Data: []byte(`{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "serial": 3,
+  "lineage": "3b2c6bd2-3f5c-2cb4-5a5c-cd0bbcf86f0d",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "random_password",
+      "name": "db",
+      "provider": "provider[\"registry.terraform.io/hashicorp/random\"]",
+      "instances": [
+        {
+          "schema_version": 3,
+          "attributes": {
+            "id": "none",
+            "length": 16,
+            "result": "s3cr3t"
+          },
+          "sensitive_attributes": [
+            [{"type": "get_attr", "value": "result"}]
+          ]
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "index_key": 1,
+          "schema_version": 1,
+          "attributes": {"id": "i-2"},
+          "sensitive_attributes": []
+        },
+        {
+          "index_key": 0,
+          "schema_version": 1,
+          "attributes": {"id": "i-1"},
+          "sensitive_attributes": []
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "aws_ami",
+      "name": "ubuntu",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {"id": "ami-1"},
+          "sensitive_attributes": []
+        }
+      ]
+    },
+    {
+      "module": "module.child",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "schema_version": 1,
+          "attributes": {"id": "i-3"},
+          "sensitive_attributes": []
+        }
+      ]
+    }
+  ]
+}`),
+		},
+	}
+
+	state, err := ParseStateFile(fs, "foo-module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(state.Resources) != 3 {
+		t.Fatalf("expected 3 resources, %d given", len(state.Resources))
+	}
+
+	password := state.Resources["random_password.db"].Value()
+	if !password.GetAttr("result").HasMark(SensitiveMark) {
+		t.Fatalf("expected result to be sensitive")
+	}
+	if password.GetAttr("id").IsMarked() {
+		t.Fatalf("expected id not to be sensitive")
+	}
+
+	expectedWeb := cty.TupleVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("i-1")}),
+		cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("i-2")}),
+	})
+	if web := state.Resources["aws_instance.web"].Value(); !web.RawEquals(expectedWeb) {
+		t.Fatalf("unexpected value: %#v", web)
+	}
+
+	vars := state.Variables()
+	ami := vars["data"].GetAttr("aws_ami").GetAttr("ubuntu").GetAttr("id")
+	if !ami.RawEquals(cty.StringVal("ami-1")) {
+		t.Fatalf("unexpected data source value: %#v", ami)
+	}
+}
+
+func TestParseStateFile_json(t *testing.T) {
+	fs := fstest.MapFS{
+		filepath.Join("foo-module", "terraform.tfstate.json"): &fstest.MapFile{
+			Data: []byte(`{
+  "format_version": "1.0",
+  "terraform_version": "1.9.0",
+  "values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_db_instance.main[\"blue\"]",
+          "mode": "managed",
+          "type": "aws_db_instance",
+          "name": "main",
+          "index": "blue",
+          "provider_name": "registry.terraform.io/hashicorp/aws",
+          "schema_version": 2,
+          "values": {
+            "id": "db-blue",
+            "password": "s3cr3t",
+            "tags": {"env": "prod"}
+          },
+          "sensitive_values": {
+            "password": true,
+            "tags": {}
+          }
+        }
+      ]
+    }
+  }
+}`),
+		},
+	}
+
+	state, err := ParseStateFile(fs, "foo-module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, ok := state.Resources["aws_db_instance.main"]
+	if !ok {
+		t.Fatal("expected aws_db_instance.main in state")
+	}
+	if len(db.Instances) != 1 || !db.Instances[0].Key.RawEquals(cty.StringVal("blue")) {
+		t.Fatalf("unexpected instances: %#v", db.Instances)
+	}
+
+	blue := db.Value().GetAttr("blue")
+	if !blue.GetAttr("password").HasMark(SensitiveMark) {
+		t.Fatalf("expected password to be sensitive")
+	}
+	if tags := blue.GetAttr("tags"); tags.IsMarked() || !tags.GetAttr("env").RawEquals(cty.StringVal("prod")) {
+		t.Fatalf("unexpected tags: %#v", tags)
+	}
+}
+
+func TestParseStateFile_notExists(t *testing.T) {
+	_, err := ParseStateFile(fstest.MapFS{}, "foo-module")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected not exist error, %v given", err)
+	}
+}
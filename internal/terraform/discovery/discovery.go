@@ -6,16 +6,46 @@ package discovery
 import (
 	"fmt"
 	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 )
 
 type DiscoveryFunc func() (string, error)
 
-type Discovery struct{}
+type Discovery struct {
+	// Engine determines which executable is looked up.
+	// Auto looks for terraform first and falls back to tofu.
+	Engine engine.Engine
+}
 
 func (d *Discovery) LookPath() (string, error) {
-	path, err := exec.LookPath(executableName)
-	if err != nil {
-		return "", fmt.Errorf("unable to find %s: %s", executableName, err)
+	return d.LookPathForEngine(d.Engine)
+}
+
+// LookPathForEngine looks up the executable of the given engine,
+// regardless of the engine configured for the discovery
+func (d *Discovery) LookPathForEngine(e engine.Engine) (string, error) {
+	names := executableNames(e)
+
+	var lastErr error
+	for _, name := range names {
+		path, err := exec.LookPath(name)
+		if err == nil {
+			return path, nil
+		}
+		lastErr = err
+	}
+
+	return "", fmt.Errorf("unable to find %s: %s", strings.Join(names, " or "), lastErr)
+}
+
+func executableNames(e engine.Engine) []string {
+	switch e {
+	case engine.Terraform:
+		return []string{terraformExecutableName}
+	case engine.OpenTofu:
+		return []string{tofuExecutableName}
 	}
-	return path, nil
+	return []string{terraformExecutableName, tofuExecutableName}
 }
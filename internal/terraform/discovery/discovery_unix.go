@@ -6,4 +6,7 @@
 
 package discovery
 
-const executableName = "terraform"
+const (
+	terraformExecutableName = "terraform"
+	tofuExecutableName      = "tofu"
+)
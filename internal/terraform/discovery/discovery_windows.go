@@ -3,4 +3,7 @@
 
 package discovery
 
-const executableName = "terraform.exe"
+const (
+	terraformExecutableName = "terraform.exe"
+	tofuExecutableName      = "tofu.exe"
+)
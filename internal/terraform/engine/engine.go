@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Engine represents the flavour of the language and CLI
+// which the configuration is written for and executed with
+type Engine string
+
+const (
+	// Auto means that the engine is detected from the configuration
+	// files and the discovered executable
+	Auto      Engine = ""
+	Terraform Engine = "terraform"
+	OpenTofu  Engine = "opentofu"
+)
+
+func (e Engine) String() string {
+	if e == Auto {
+		return "auto"
+	}
+	return string(e)
+}
+
+// ParseEngine parses the engine as provided by the user via settings
+func ParseEngine(raw string) (Engine, error) {
+	switch strings.ToLower(raw) {
+	case "", "auto":
+		return Auto, nil
+	case "terraform":
+		return Terraform, nil
+	case "opentofu", "tofu":
+		return OpenTofu, nil
+	}
+	return Auto, fmt.Errorf("unknown engine %q, expected one of: auto, terraform, opentofu", raw)
+}
+
+// IsOpenTofuFilename returns true for files which are only
+// ever loaded by OpenTofu, e.g. main.tofu or main.tofutest.hcl
+func IsOpenTofuFilename(name string) bool {
+	return strings.HasSuffix(name, ".tofu") ||
+		strings.HasSuffix(name, ".tofu.json") ||
+		strings.HasSuffix(name, ".tofutest.hcl") ||
+		strings.HasSuffix(name, ".tofutest.json")
+}
+
+// FromFilenames resolves the engine for a directory
+// with the given filenames, based on the fallback engine
+// which is typically the one configured for the session.
+//
+// An explicitly configured engine always takes precedence
+// and presence of any OpenTofu-specific file otherwise
+// switches the directory to OpenTofu.
+func FromFilenames(fallback Engine, filenames []string) Engine {
+	if fallback != Auto {
+		return fallback
+	}
+	for _, name := range filenames {
+		if IsOpenTofuFilename(name) {
+			return OpenTofu
+		}
+	}
+	return Terraform
+}
+
+// FromExecPath infers the engine from the path of a discovered
+// or user-provided executable, e.g. /usr/local/bin/tofu
+func FromExecPath(execPath string) Engine {
+	// we avoid filepath.Base, so that Windows paths are recognised everywhere
+	name := execPath[strings.LastIndexAny(execPath, `/\`)+1:]
+	name = strings.TrimSuffix(name, ".exe")
+	switch name {
+	case "tofu":
+		return OpenTofu
+	case "terraform":
+		return Terraform
+	}
+	return Auto
+}
@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package engine
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseEngine(t *testing.T) {
+	testCases := []struct {
+		raw         string
+		expected    Engine
+		expectedErr bool
+	}{
+		{"", Auto, false},
+		{"auto", Auto, false},
+		{"terraform", Terraform, false},
+		{"OpenTofu", OpenTofu, false},
+		{"tofu", OpenTofu, false},
+		{"pulumi", Auto, true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.raw), func(t *testing.T) {
+			e, err := ParseEngine(tc.raw)
+			if tc.expectedErr && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tc.expectedErr && err != nil {
+				t.Fatal(err)
+			}
+			if e != tc.expected {
+				t.Fatalf("expected %q, given %q", tc.expected, e)
+			}
+		})
+	}
+}
+
+func TestFromFilenames(t *testing.T) {
+	testCases := []struct {
+		fallback  Engine
+		filenames []string
+		expected  Engine
+	}{
+		{Auto, []string{}, Terraform},
+		{Auto, []string{"main.tf", "variables.tf"}, Terraform},
+		{Auto, []string{"main.tf", "providers.tofu"}, OpenTofu},
+		{Auto, []string{"main.tf.json", "main.tofu.json"}, OpenTofu},
+		{Auto, []string{"main.tf", "main.tofutest.hcl"}, OpenTofu},
+		{Terraform, []string{"main.tofu"}, Terraform},
+		{OpenTofu, []string{"main.tf"}, OpenTofu},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			e := FromFilenames(tc.fallback, tc.filenames)
+			if e != tc.expected {
+				t.Fatalf("expected %q, given %q", tc.expected, e)
+			}
+		})
+	}
+}
+
+func TestFromExecPath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected Engine
+	}{
+		{"/usr/local/bin/terraform", Terraform},
+		{"/usr/local/bin/tofu", OpenTofu},
+		{`C:\tools\tofu.exe`, OpenTofu},
+		{"", Auto},
+		{"/opt/bin/tf-wrapper", Auto},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			e := FromExecPath(tc.path)
+			if e != tc.expected {
+				t.Fatalf("expected %q, given %q", tc.expected, e)
+			}
+		})
+	}
+}
@@ -7,8 +7,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log"
+	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-version"
@@ -23,6 +27,10 @@ import (
 
 var defaultExecTimeout = 30 * time.Second
 
+// testInterruptGracePeriod represents how long we wait for
+// terraform test to clean up after being interrupted
+var testInterruptGracePeriod = 2 * time.Minute
+
 const tracerName = "github.com/hashicorp/terraform-ls/internal/terraform/exec"
 
 type ctxKey string
@@ -31,6 +39,7 @@ type Executor struct {
 	tf         *tfexec.Terraform
 	timeout    time.Duration
 	rawLogPath string
+	logger     *log.Logger
 }
 
 func NewExecutor(workDir, execPath string) (TerraformExecutor, error) {
@@ -45,6 +54,7 @@ func NewExecutor(workDir, execPath string) (TerraformExecutor, error) {
 }
 
 func (e *Executor) SetLogger(logger *log.Logger) {
+	e.logger = logger
 	e.tf.SetLogger(logger)
 }
 
@@ -216,3 +226,125 @@ func (e *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchemas
 
 	return ps, e.contextfulError(ctx, "ProviderSchemas", err)
 }
+
+// ShowPlanFile reads the saved plan file at the given path
+// and returns its JSON representation
+func (e *Executor) ShowPlanFile(ctx context.Context, planPath string) (*tfjson.Plan, error) {
+	ctx, cancel := e.withTimeout(ctx)
+	defer cancel()
+	err := e.setLogPath("ShowPlanFile")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "terraform-exec:ShowPlanFile")
+	defer span.End()
+
+	plan, err := e.tf.ShowPlanFile(ctx, planPath)
+	e.setSpanStatus(span, err)
+
+	return plan, e.contextfulError(ctx, "ShowPlanFile", err)
+}
+
+// Test runs terraform test for test files matching the given filters
+// (or all test files if none are provided) and writes the JSON output
+// to w as it is produced.
+//
+// Unlike other commands, tests are not subject to the execution timeout
+// as they typically provision real infrastructure. Canceling the context
+// interrupts Terraform, giving it a chance to destroy what was created.
+//
+// The command exits with an error when any test fails, in which case
+// the output is still complete.
+func (e *Executor) Test(ctx context.Context, w io.Writer, filters ...string) error {
+	// terraform-exec does not support test filters,
+	// so we have to build the command ourselves
+	args := []string{"test", "-json"}
+	for _, filter := range filters {
+		args = append(args, "-filter="+filter)
+	}
+
+	return e.runStreaming(ctx, "Test", "terraform test", w, filters, args)
+}
+
+// PolicyTest runs terraform policy test for policy test files matching
+// the given filters (or all policy test files if none are provided)
+// and writes the JSON output to w as it is produced.
+//
+// The output follows the format of terraform test, where each test case
+// is reported as a run. The command exits with an error when any test
+// case fails, in which case the output is still complete.
+func (e *Executor) PolicyTest(ctx context.Context, w io.Writer, filters ...string) error {
+	args := []string{"policy", "test", "-json"}
+	for _, filter := range filters {
+		args = append(args, "-filter="+filter)
+	}
+
+	return e.runStreaming(ctx, "PolicyTest", "terraform policy test", w, filters, args)
+}
+
+// Query runs terraform query for all query files in the working
+// directory and writes the JSON output to w as it is produced.
+//
+// Like tests, queries are not subject to the execution timeout
+// as listing remote resources may take a long time.
+func (e *Executor) Query(ctx context.Context, w io.Writer) error {
+	// terraform-exec only supports query output as a sequence
+	// of decoded messages, so we have to build the command ourselves
+	args := []string{"query", "-no-color", "-json"}
+
+	return e.runStreaming(ctx, "Query", "terraform query", w, nil, args)
+}
+
+// runStreaming runs a long-running command, which is not subject
+// to the execution timeout, streaming its output to w
+func (e *Executor) runStreaming(ctx context.Context, method, command string, w io.Writer, filters []string, args []string) error {
+	logPath, err := logging.ParseExecLogPath(method, e.rawLogPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "terraform-exec:"+method,
+		trace.WithAttributes(attribute.KeyValue{
+			Key:   attribute.Key("filters"),
+			Value: attribute.StringSliceValue(filters),
+		}))
+	defer span.End()
+
+	cmd := exec.CommandContext(ctx, e.tf.ExecPath(), args...)
+	cmd.Dir = e.tf.WorkingDir()
+	cmd.Env = append(os.Environ(), "TF_IN_AUTOMATION=1")
+	if logPath != "" {
+		cmd.Env = append(cmd.Env, "TF_LOG=TRACE", "TF_LOG_PATH="+logPath)
+	}
+	cmd.Cancel = func() error {
+		err := cmd.Process.Signal(os.Interrupt)
+		if err != nil {
+			// interrupts are not supported on all platforms
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+	cmd.WaitDelay = testInterruptGracePeriod
+
+	var stderr bytes.Buffer
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	if e.logger != nil {
+		e.logger.Printf("[INFO] running Terraform command: %s", cmd.String())
+	}
+
+	err = cmd.Run()
+	e.setSpanStatus(span, err)
+	if err != nil && stderr.Len() > 0 {
+		if e.logger != nil {
+			e.logger.Printf("[ERROR] %s: %s", command, strings.TrimSpace(stderr.String()))
+		}
+		if _, ok := err.(*exec.ExitError); !ok {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	return e.contextfulError(ctx, method, err)
+}
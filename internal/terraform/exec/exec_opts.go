@@ -6,12 +6,21 @@ package exec
 import (
 	"context"
 	"time"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 )
 
 type ExecutorOpts struct {
 	ExecPath    string
 	ExecLogPath string
 	Timeout     time.Duration
+
+	// OpenTofuExecPath is the path to the tofu executable, which is
+	// used instead of ExecPath for modules written for OpenTofu
+	OpenTofuExecPath string
+	// ModuleEngine returns the engine the module at the given path
+	// is written for
+	ModuleEngine func(modPath string) engine.Engine
 }
 
 var ctxExecOpts = ctxKey("executor opts")
@@ -5,6 +5,8 @@ package mock
 import (
 	context "context"
 
+	io "io"
+
 	log "log"
 
 	mock "github.com/stretchr/testify/mock"
@@ -102,6 +104,27 @@ func (_m *Executor) Init(ctx context.Context, opts ...tfexec.InitOption) error {
 	return r0
 }
 
+// PolicyTest provides a mock function with given fields: ctx, w, filters
+func (_m *Executor) PolicyTest(ctx context.Context, w io.Writer, filters ...string) error {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, w)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, ...string) error); ok {
+		r0 = rf(ctx, w, filters...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ProviderSchemas provides a mock function with given fields: ctx
 func (_m *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchemas, error) {
 	ret := _m.Called(ctx)
@@ -125,6 +148,20 @@ func (_m *Executor) ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchema
 	return r0, r1
 }
 
+// Query provides a mock function with given fields: ctx, w
+func (_m *Executor) Query(ctx context.Context, w io.Writer) error {
+	ret := _m.Called(ctx, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer) error); ok {
+		r0 = rf(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetExecLogPath provides a mock function with given fields: path
 func (_m *Executor) SetExecLogPath(path string) error {
 	ret := _m.Called(path)
@@ -149,6 +186,50 @@ func (_m *Executor) SetTimeout(duration time.Duration) {
 	_m.Called(duration)
 }
 
+// ShowPlanFile provides a mock function with given fields: ctx, planPath
+func (_m *Executor) ShowPlanFile(ctx context.Context, planPath string) (*tfjson.Plan, error) {
+	ret := _m.Called(ctx, planPath)
+
+	var r0 *tfjson.Plan
+	if rf, ok := ret.Get(0).(func(context.Context, string) *tfjson.Plan); ok {
+		r0 = rf(ctx, planPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tfjson.Plan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, planPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Test provides a mock function with given fields: ctx, w, filters
+func (_m *Executor) Test(ctx context.Context, w io.Writer, filters ...string) error {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, w)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, ...string) error); ok {
+		r0 = rf(ctx, w, filters...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Validate provides a mock function with given fields: ctx
 func (_m *Executor) Validate(ctx context.Context) ([]tfjson.Diagnostic, error) {
 	ret := _m.Called(ctx)
@@ -5,6 +5,7 @@ package exec
 
 import (
 	"context"
+	"io"
 	"log"
 	"time"
 
@@ -32,4 +33,8 @@ type TerraformExecutor interface {
 	Version(ctx context.Context) (*version.Version, map[string]*version.Version, error)
 	Validate(ctx context.Context) ([]tfjson.Diagnostic, error)
 	ProviderSchemas(ctx context.Context) (*tfjson.ProviderSchemas, error)
+	ShowPlanFile(ctx context.Context, planPath string) (*tfjson.Plan, error)
+	Test(ctx context.Context, w io.Writer, filters ...string) error
+	PolicyTest(ctx context.Context, w io.Writer, filters ...string) error
+	Query(ctx context.Context, w io.Writer) error
 }
@@ -51,11 +51,13 @@ func _() {
 	_ = x[OpTypeDecodeTestReferenceOrigins-40]
 	_ = x[OpTypeDecodeWriteOnlyAttributes-41]
 	_ = x[OpTypeSchemaTestValidation-42]
+	_ = x[OpTypeParseState-43]
+	_ = x[OpTypeLoadProviderSchemaFiles-44]
 }
 
-const _OpType_name = "OpTypeUnknownOpTypeGetTerraformVersionOpTypeGetInstalledTerraformVersionOpTypeObtainSchemaOpTypeParseModuleConfigurationOpTypeParseVariablesOpTypeParseModuleManifestOpTypeParseTerraformSourcesOpTypeLoadModuleMetadataOpTypeDecodeReferenceTargetsOpTypeDecodeReferenceOriginsOpTypeDecodeVarsReferencesOpTypeGetModuleDataFromRegistryOpTypeParseProviderVersionsOpTypePreloadEmbeddedSchemaOpTypeStacksPreloadEmbeddedSchemaOpTypeSearchPreloadEmbeddedSchemaOpTypeSchemaModuleValidationOpTypeSchemaStackValidationOpTypeSchemaSearchValidationOpTypeSchemaVarsValidationOpTypeReferenceValidationOpTypeReferenceStackValidationOpTypeTerraformValidateOpTypeParseStackConfigurationOpTypeParseSearchConfigurationOpTypeParsePolicyConfigurationOpTypeLoadPolicyMetadataOpTypeSchemaPolicyValidationOpTypeReferencePolicyValidationOpTypeParsePolicyTestConfigurationOpTypeLoadPolicyTestMetadataOpTypeSchemaPolicyTestValidationOpTypeReferencePolicyTestValidationOpTypeLoadStackMetadataOpTypeLoadSearchMetadataOpTypeLoadStackRequiredTerraformVersionOpTypeParseTestConfigurationOpTypeLoadTestMetadataOpTypeDecodeTestReferenceTargetsOpTypeDecodeTestReferenceOriginsOpTypeDecodeWriteOnlyAttributesOpTypeSchemaTestValidation"
+const _OpType_name = "OpTypeUnknownOpTypeGetTerraformVersionOpTypeGetInstalledTerraformVersionOpTypeObtainSchemaOpTypeParseModuleConfigurationOpTypeParseVariablesOpTypeParseModuleManifestOpTypeParseTerraformSourcesOpTypeLoadModuleMetadataOpTypeDecodeReferenceTargetsOpTypeDecodeReferenceOriginsOpTypeDecodeVarsReferencesOpTypeGetModuleDataFromRegistryOpTypeParseProviderVersionsOpTypePreloadEmbeddedSchemaOpTypeStacksPreloadEmbeddedSchemaOpTypeSearchPreloadEmbeddedSchemaOpTypeSchemaModuleValidationOpTypeSchemaStackValidationOpTypeSchemaSearchValidationOpTypeSchemaVarsValidationOpTypeReferenceValidationOpTypeReferenceStackValidationOpTypeTerraformValidateOpTypeParseStackConfigurationOpTypeParseSearchConfigurationOpTypeParsePolicyConfigurationOpTypeLoadPolicyMetadataOpTypeSchemaPolicyValidationOpTypeReferencePolicyValidationOpTypeParsePolicyTestConfigurationOpTypeLoadPolicyTestMetadataOpTypeSchemaPolicyTestValidationOpTypeReferencePolicyTestValidationOpTypeLoadStackMetadataOpTypeLoadSearchMetadataOpTypeLoadStackRequiredTerraformVersionOpTypeParseTestConfigurationOpTypeLoadTestMetadataOpTypeDecodeTestReferenceTargetsOpTypeDecodeTestReferenceOriginsOpTypeDecodeWriteOnlyAttributesOpTypeSchemaTestValidationOpTypeParseStateOpTypeLoadProviderSchemaFiles"
 
-var _OpType_index = [...]uint16{0, 13, 38, 72, 90, 120, 140, 165, 192, 216, 244, 272, 298, 329, 356, 383, 416, 449, 477, 504, 532, 558, 583, 613, 636, 665, 695, 725, 749, 777, 808, 842, 870, 902, 937, 960, 984, 1023, 1051, 1073, 1105, 1137, 1168, 1194}
+var _OpType_index = [...]uint16{0, 13, 38, 72, 90, 120, 140, 165, 192, 216, 244, 272, 298, 329, 356, 383, 416, 449, 477, 504, 532, 558, 583, 613, 636, 665, 695, 725, 749, 777, 808, 842, 870, 902, 937, 960, 984, 1023, 1051, 1073, 1105, 1137, 1168, 1194, 1210, 1239}
 
 func (i OpType) String() string {
 	idx := int(i) - 0
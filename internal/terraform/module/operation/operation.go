@@ -60,4 +60,6 @@ const (
 	OpTypeDecodeTestReferenceOrigins
 	OpTypeDecodeWriteOnlyAttributes
 	OpTypeSchemaTestValidation
+	OpTypeParseState
+	OpTypeLoadProviderSchemaFiles
 )
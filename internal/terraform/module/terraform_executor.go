@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-ls/internal/terraform/engine"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 )
 
@@ -16,7 +17,7 @@ func TerraformExecutorForModule(ctx context.Context, modPath string) (exec.Terra
 		return nil, fmt.Errorf("no terraform executor provided")
 	}
 
-	execPath, err := TerraformExecPath(ctx)
+	execPath, err := TerraformExecPathForModule(ctx, modPath)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +38,18 @@ func TerraformExecutorForModule(ctx context.Context, modPath string) (exec.Terra
 	return tfExec, nil
 }
 
+// TerraformExecPathForModule returns the path to the executable
+// of the engine the module at the given path is written for,
+// i.e. tofu for OpenTofu modules if it was found
+func TerraformExecPathForModule(ctx context.Context, modPath string) (string, error) {
+	opts, ok := exec.ExecutorOptsFromContext(ctx)
+	if ok && opts.OpenTofuExecPath != "" && opts.ModuleEngine != nil &&
+		opts.ModuleEngine(modPath) == engine.OpenTofu {
+		return opts.OpenTofuExecPath, nil
+	}
+	return TerraformExecPath(ctx)
+}
+
 func TerraformExecPath(ctx context.Context) (string, error) {
 	opts, ok := exec.ExecutorOptsFromContext(ctx)
 	if ok && opts.ExecPath != "" {
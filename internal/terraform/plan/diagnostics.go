@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+// Diagnostics returns diagnostics for attributes and blocks
+// of resources in the given files which are updated in place
+// or replaced by the plan
+//
+// Attributes whose changes force replacement are reported
+// as warnings, while other changed attributes are reported
+// with the information severity.
+func (p *Plan) Diagnostics(files map[string]*hcl.File) map[string]hcl.Diagnostics {
+	diags := make(map[string]hcl.Diagnostics, len(files))
+	for filename, f := range files {
+		diags[filename] = make(hcl.Diagnostics, 0)
+
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" && block.Type != "data" || len(block.Labels) != 2 {
+				continue
+			}
+			address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+			if block.Type == "data" {
+				address = "data." + address
+			}
+
+			diags[filename] = append(diags[filename], blockDiagnostics(block, p.Changes[address])...)
+		}
+	}
+	return diags
+}
+
+func blockDiagnostics(block *hclsyntax.Block, changes []InstanceChange) hcl.Diagnostics {
+	type attributeChanges struct {
+		lines            []string
+		forceReplacement bool
+	}
+	attributes := make(map[string]*attributeChanges)
+	names := make([]string, 0)
+
+	for _, change := range changes {
+		if !change.Actions.Update() && !change.Actions.Replace() {
+			continue
+		}
+		for _, name := range change.ChangedAttributes {
+			ac, ok := attributes[name]
+			if !ok {
+				ac = &attributeChanges{}
+				attributes[name] = ac
+				names = append(names, name)
+			}
+			ac.lines = append(ac.lines, fmt.Sprintf("%s: %s", change, change.AttributeChange(name)))
+			if change.ForcesReplacement(name) {
+				ac.forceReplacement = true
+			}
+		}
+	}
+
+	diags := make(hcl.Diagnostics, 0)
+	for _, name := range names {
+		rng, ok := attributeRange(block.Body, name)
+		if !ok {
+			continue
+		}
+		ac := attributes[name]
+
+		diag := &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  fmt.Sprintf("Planned change of %q", name),
+			Detail:   strings.Join(ac.lines, "\n"),
+			Subject:  rng.Ptr(),
+		}
+		if ac.forceReplacement {
+			diag.Summary = fmt.Sprintf("Planned change of %q forces replacement", name)
+		} else {
+			diag.Extra = ihcl.Informational{}
+		}
+		diags = append(diags, diag)
+	}
+	return diags
+}
+
+// attributeRange returns the range of the name
+// of the attribute or the type of the first block
+// with the given name
+func attributeRange(body *hclsyntax.Body, name string) (hcl.Range, bool) {
+	if attr, ok := body.Attributes[name]; ok {
+		return attr.NameRange, true
+	}
+	for _, block := range body.Blocks {
+		if block.Type == name {
+			return block.TypeRange, true
+		}
+	}
+	return hcl.Range{}, false
+}
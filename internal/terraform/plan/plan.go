@@ -0,0 +1,270 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Plan contains planned changes of resource instances
+// of the root module, keyed by the resource address
+type Plan struct {
+	Changes map[string][]InstanceChange
+}
+
+// InstanceChange describes a planned change of a single resource instance
+type InstanceChange struct {
+	// Address is the address of the resource, without the instance key
+	Address string
+	// Key is the formatted instance key, e.g. [0] or ["blue"],
+	// or empty if the resource has a single instance
+	Key     string
+	Actions tfjson.Actions
+
+	// ChangedAttributes are names of top-level attributes
+	// and blocks whose values change
+	ChangedAttributes []string
+	// ReplaceAttributes are names of top-level attributes
+	// and blocks whose changes force replacement
+	ReplaceAttributes []string
+
+	before          map[string]interface{}
+	after           map[string]interface{}
+	afterUnknown    map[string]interface{}
+	beforeSensitive map[string]interface{}
+	afterSensitive  map[string]interface{}
+}
+
+// String returns the address of the instance
+func (c InstanceChange) String() string {
+	return c.Address + c.Key
+}
+
+// Summary describes the change in a few words
+func (c InstanceChange) Summary() string {
+	var summary string
+	switch {
+	case c.Actions.Replace():
+		summary = "will be replaced"
+	case c.Actions.Create():
+		summary = "will be created"
+	case c.Actions.Delete():
+		summary = "will be destroyed"
+	case c.Actions.Update():
+		summary = "update in place: " + pluralize("attribute", "attributes", len(c.ChangedAttributes))
+	case c.Actions.Read():
+		summary = "will be read during apply"
+	case c.Actions.Forget():
+		summary = "will be removed from state"
+	default:
+		summary = "no changes"
+	}
+
+	if c.Key != "" {
+		return fmt.Sprintf("%s %s", c.Key, summary)
+	}
+	return summary
+}
+
+// ForcesReplacement returns true if the change
+// of the given attribute forces replacement
+func (c InstanceChange) ForcesReplacement(name string) bool {
+	for _, attr := range c.ReplaceAttributes {
+		if attr == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributeChange describes the change of the given attribute,
+// e.g. "t2.micro" -> "t3.micro", leaving out sensitive values
+func (c InstanceChange) AttributeChange(name string) string {
+	before := formatValue(c.before[name], c.beforeSensitive[name], false)
+	after := formatValue(c.after[name], c.afterSensitive[name], containsTrue(c.afterUnknown[name]))
+	return fmt.Sprintf("%s -> %s", before, after)
+}
+
+// Addresses returns sorted addresses of resources with planned changes
+func (p *Plan) Addresses() []string {
+	addresses := make([]string, 0, len(p.Changes))
+	for address := range p.Changes {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// ParsePlan parses the JSON representation of a plan,
+// as produced by terraform show -json
+func ParsePlan(src []byte) (*Plan, error) {
+	var p tfjson.Plan
+	err := p.UnmarshalJSON(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromJSON(&p)
+}
+
+// FromJSON collects changes of resource instances of the root module
+// from the given plan. Instances which do not change are left out.
+func FromJSON(p *tfjson.Plan) (*Plan, error) {
+	plan := &Plan{
+		Changes: make(map[string][]InstanceChange),
+	}
+
+	for _, rc := range p.ResourceChanges {
+		if rc.ModuleAddress != "" || rc.DeposedKey != "" || rc.Change == nil {
+			continue
+		}
+		if rc.Change.Actions.NoOp() {
+			continue
+		}
+
+		key, err := instanceKey(rc.Index)
+		if err != nil {
+			return nil, err
+		}
+
+		address := fmt.Sprintf("%s.%s", rc.Type, rc.Name)
+		if rc.Mode == tfjson.DataResourceMode {
+			address = "data." + address
+		}
+
+		change := InstanceChange{
+			Address:         address,
+			Key:             key,
+			Actions:         rc.Change.Actions,
+			before:          asMap(rc.Change.Before),
+			after:           asMap(rc.Change.After),
+			afterUnknown:    asMap(rc.Change.AfterUnknown),
+			beforeSensitive: asMap(rc.Change.BeforeSensitive),
+			afterSensitive:  asMap(rc.Change.AfterSensitive),
+		}
+		change.ChangedAttributes = changedAttributes(change)
+		change.ReplaceAttributes = replaceAttributes(rc.Change.ReplacePaths)
+
+		plan.Changes[address] = append(plan.Changes[address], change)
+	}
+
+	return plan, nil
+}
+
+func instanceKey(key interface{}) (string, error) {
+	switch key := key.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return fmt.Sprintf("[%q]", key), nil
+	case float64:
+		return fmt.Sprintf("[%v]", key), nil
+	case json.Number:
+		return fmt.Sprintf("[%s]", key), nil
+	}
+	return "", fmt.Errorf("unsupported instance key %#v", key)
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
+
+func changedAttributes(c InstanceChange) []string {
+	names := make(map[string]struct{})
+	for name := range c.before {
+		names[name] = struct{}{}
+	}
+	for name := range c.after {
+		names[name] = struct{}{}
+	}
+	for name := range c.afterUnknown {
+		names[name] = struct{}{}
+	}
+
+	changed := make([]string, 0)
+	for name := range names {
+		if containsTrue(c.afterUnknown[name]) || !reflect.DeepEqual(c.before[name], c.after[name]) {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func replaceAttributes(paths []interface{}) []string {
+	seen := make(map[string]struct{})
+	attrs := make([]string, 0)
+	for _, path := range paths {
+		steps, ok := path.([]interface{})
+		if !ok || len(steps) == 0 {
+			continue
+		}
+		name, ok := steps[0].(string)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		attrs = append(attrs, name)
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+// containsTrue returns true if the given value, as found
+// in after_unknown or sensitive values of the plan,
+// marks the value or any of its elements
+func containsTrue(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case []interface{}:
+		for _, elem := range v {
+			if containsTrue(elem) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, elem := range v {
+			if containsTrue(elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func formatValue(v, sensitive interface{}, unknown bool) string {
+	if containsTrue(sensitive) {
+		return "(sensitive value)"
+	}
+	if unknown {
+		return "(known after apply)"
+	}
+	if v == nil {
+		return "null"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "?"
+	}
+	return string(b)
+}
+
+func pluralize(singular, plural string, n int) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,191 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ihcl "github.com/hashicorp/terraform-ls/internal/hcl"
+)
+
+const testPlan = `{
+  "format_version": "1.2",
+  "terraform_version": "1.9.0",
+  "resource_changes": [
+    {
+      "address": "aws_instance.web[0]",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "index": 0,
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {"ami": "ami-1", "instance_type": "t2.micro", "tags": {"env": "dev"}},
+        "after": {"ami": "ami-2", "instance_type": "t2.micro", "tags": {"env": "dev"}},
+        "after_unknown": {"id": true, "tags": {}},
+        "before_sensitive": {},
+        "after_sensitive": {},
+        "replace_paths": [["ami"]]
+      }
+    },
+    {
+      "address": "aws_instance.web[1]",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "index": 1,
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["update"],
+        "before": {"ami": "ami-1", "instance_type": "t2.micro", "tags": {"env": "dev"}, "user_data": "a"},
+        "after": {"ami": "ami-1", "instance_type": "t3.micro", "tags": {"env": "prod"}, "user_data": "b"},
+        "after_unknown": {"tags": {}},
+        "before_sensitive": {"user_data": true},
+        "after_sensitive": {"user_data": true}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.logs[\"blue\"]",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "index": "blue",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"bucket": "logs"},
+        "after_unknown": {"id": true}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.unchanged",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "unchanged",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["no-op"],
+        "before": {"bucket": "unchanged"},
+        "after": {"bucket": "unchanged"}
+      }
+    },
+    {
+      "address": "module.child.aws_instance.web",
+      "module_address": "module.child",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {
+        "actions": ["delete"],
+        "before": {"ami": "ami-1"},
+        "after": null
+      }
+    }
+  ]
+}`
+
+func TestParsePlan(t *testing.T) {
+	p, err := ParsePlan([]byte(testPlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAddresses := []string{"aws_instance.web", "aws_s3_bucket.logs"}
+	if diff := cmp.Diff(expectedAddresses, p.Addresses()); diff != "" {
+		t.Fatalf("unexpected addresses: %s", diff)
+	}
+
+	summaries := make([]string, 0)
+	for _, address := range p.Addresses() {
+		for _, change := range p.Changes[address] {
+			summaries = append(summaries, change.String()+": "+change.Summary())
+		}
+	}
+	expectedSummaries := []string{
+		"aws_instance.web[0]: [0] will be replaced",
+		"aws_instance.web[1]: [1] update in place: 3 attributes",
+		`aws_s3_bucket.logs["blue"]: ["blue"] will be created`,
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("unexpected summaries: %s", diff)
+	}
+
+	update := p.Changes["aws_instance.web"][1]
+	if diff := cmp.Diff([]string{"instance_type", "tags", "user_data"}, update.ChangedAttributes); diff != "" {
+		t.Fatalf("unexpected changed attributes: %s", diff)
+	}
+	if change := update.AttributeChange("user_data"); change != "(sensitive value) -> (sensitive value)" {
+		t.Fatalf("unexpected change of sensitive attribute: %s", change)
+	}
+}
+
+func TestPlan_Diagnostics(t *testing.T) {
+	p, err := ParsePlan([]byte(testPlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := []byte(`resource "aws_instance" "web" {
+  count         = 2
+  ami           = "ami-2"
+  instance_type = "t3.micro"
+
+  tags {
+    env = "prod"
+  }
+}
+`)
+	f, diags := hclsyntax.ParseConfig(src, "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	fileDiags := p.Diagnostics(map[string]*hcl.File{"main.tf": f})["main.tf"]
+
+	type result struct {
+		Summary       string
+		Detail        string
+		Line          int
+		Informational bool
+	}
+	results := make([]result, 0, len(fileDiags))
+	for _, diag := range fileDiags {
+		_, informational := hcl.DiagnosticExtra[ihcl.Informational](diag)
+		results = append(results, result{
+			Summary:       diag.Summary,
+			Detail:        diag.Detail,
+			Line:          diag.Subject.Start.Line,
+			Informational: informational,
+		})
+	}
+
+	expected := []result{
+		{
+			Summary: `Planned change of "ami" forces replacement`,
+			Detail:  `aws_instance.web[0]: "ami-1" -> "ami-2"`,
+			Line:    3,
+		},
+		{
+			Summary:       `Planned change of "instance_type"`,
+			Detail:        `aws_instance.web[1]: "t2.micro" -> "t3.micro"`,
+			Line:          4,
+			Informational: true,
+		},
+		{
+			Summary:       `Planned change of "tags"`,
+			Detail:        `aws_instance.web[1]: {"env":"dev"} -> {"env":"prod"}`,
+			Line:          6,
+			Informational: true,
+		},
+	}
+	if diff := cmp.Diff(expected, results); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}